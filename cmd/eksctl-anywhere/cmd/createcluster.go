@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -28,6 +29,10 @@ type createClusterOptions struct {
 	skipIpCheck           bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
+	checkOSImageURL       bool
+	keepLocalBoots        bool
+	bmcTimeout            time.Duration
+	templateGeneration    int
 	installPackages       string
 }
 
@@ -47,7 +52,11 @@ func init() {
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
+	applyTinkerbellBMCContactableTimeoutFlag(createClusterCmd.Flags(), &cc.bmcTimeout)
+	applyTinkerbellTemplateGenerationFlag(createClusterCmd.Flags(), &cc.templateGeneration)
 	createClusterCmd.Flags().StringVar(&cc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	createClusterCmd.Flags().BoolVar(&cc.checkOSImageURL, "tinkerbell-check-os-image-url", false, "Check the tinkerbell OS image URLs are reachable before creating the cluster")
+	createClusterCmd.Flags().BoolVar(&cc.keepLocalBoots, "tinkerbell-keep-local-boots-on-failure", true, "Keep the local tinkerbell boots container running when the create fails so its logs can be inspected")
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
@@ -119,6 +128,10 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	}
 
 	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithTinkerbellOSImageURLCheck(cc.checkOSImageURL).
+		WithTinkerbellKeepLocalBootsOnFailure(cc.keepLocalBoots).
+		WithTinkerbellRufioContactableTimeout(cc.bmcTimeout).
+		WithTinkerbellDeterministicTemplateNames(cc.templateGeneration).
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
@@ -2,19 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 const (
-	TinkerbellHardwareCSVFlagName        = "hardware-csv"
-	TinkerbellHardwareCSVFlagAlias       = "z"
-	TinkerbellHardwareCSVFlagDescription = "Path to a CSV file containing hardware data."
-	KubeconfigFile                       = "kubeconfig"
+	TinkerbellHardwareCSVFlagName             = "hardware-csv"
+	TinkerbellHardwareCSVFlagAlias            = "z"
+	TinkerbellHardwareCSVFlagDescription      = "Path to a CSV file containing hardware data."
+	TinkerbellHardwareCSVOrDirFlagDescription = "Path to a CSV file, or a directory of CSV files, containing hardware data."
+	KubeconfigFile                            = "kubeconfig"
+	TinkerbellBMCContactableTimeoutFlagName   = "tinkerbell-bmc-contactable-timeout"
+	TinkerbellTemplateGenerationFlagName      = "tinkerbell-template-generation"
 )
 
 func bindFlagsToViper(cmd *cobra.Command, args []string) error {
@@ -40,7 +45,25 @@ func applyTinkerbellHardwareFlag(flagSet *pflag.FlagSet, pathOut *string) {
 		TinkerbellHardwareCSVFlagName,
 		TinkerbellHardwareCSVFlagAlias,
 		"",
-		TinkerbellHardwareCSVFlagDescription,
+		TinkerbellHardwareCSVOrDirFlagDescription,
+	)
+}
+
+func applyTinkerbellBMCContactableTimeoutFlag(flagSet *pflag.FlagSet, timeoutOut *time.Duration) {
+	flagSet.DurationVar(
+		timeoutOut,
+		TinkerbellBMCContactableTimeoutFlagName,
+		tinkerbell.DefaultRufioContactableTimeout,
+		"Override how long to wait for the hardware baseboard management controllers to be contactable",
+	)
+}
+
+func applyTinkerbellTemplateGenerationFlag(flagSet *pflag.FlagSet, generationOut *int) {
+	flagSet.IntVar(
+		generationOut,
+		TinkerbellTemplateGenerationFlagName,
+		0,
+		"Suffix the generated tinkerbell machine and kubeadm config template names with this generation instead of a timestamp. Bump it whenever the templates change",
 	)
 }
 
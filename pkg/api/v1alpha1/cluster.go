@@ -7,14 +7,19 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -188,20 +193,26 @@ func NewCluster(clusterName string) *Cluster {
 var clusterConfigValidations = []func(*Cluster) error{
 	validateClusterConfigName,
 	validateControlPlaneEndpoint,
-	validateExternalEtcdSupport,
 	validateMachineGroupRefs,
 	validateControlPlaneReplicas,
 	validateWorkerNodeGroups,
 	validateNetworking,
 	validateGitOps,
 	validateEtcdReplicas,
+	validateEtcdBackup,
 	validateIdentityProviderRefs,
 	validateProxyConfig,
 	validateMirrorConfig,
 	validatePodIAMConfig,
 	validateCPUpgradeRolloutStrategy,
 	validateControlPlaneLabels,
+	validateAuditLogRotation,
 	validatePackageControllerConfiguration,
+	validateCAPIObjectLabels,
+	validateCoreDNSConfiguration,
+	validatePriorityClasses,
+	validateAPIServerExtraArgs,
+	validateKubeletExtraArgs,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -372,16 +383,6 @@ func validateClusterConfigName(clusterConfig *Cluster) error {
 	return nil
 }
 
-func validateExternalEtcdSupport(cluster *Cluster) error {
-	if cluster.Spec.DatacenterRef.Kind == TinkerbellDatacenterKind {
-		if cluster.Spec.ExternalEtcdConfiguration != nil {
-			return errors.New("tinkerbell external etcd configuration is unsupported")
-		}
-	}
-
-	return nil
-}
-
 func validateMachineGroupRefs(cluster *Cluster) error {
 	if cluster.Spec.DatacenterRef.Kind != DockerDatacenterKind {
 		if cluster.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
@@ -426,6 +427,35 @@ func validateControlPlaneLabels(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateAuditLogRotation(clusterConfig *Cluster) error {
+	rotation := clusterConfig.Spec.ControlPlaneConfiguration.AuditLogRotation
+	if rotation == nil {
+		return nil
+	}
+	if rotation.MaxAge <= 0 {
+		return fmt.Errorf("auditLogRotation maxAge %d is invalid, it must be positive", rotation.MaxAge)
+	}
+	if rotation.MaxBackup <= 0 {
+		return fmt.Errorf("auditLogRotation maxBackup %d is invalid, it must be positive", rotation.MaxBackup)
+	}
+	if rotation.MaxSize <= 0 {
+		return fmt.Errorf("auditLogRotation maxSize %d is invalid, it must be positive", rotation.MaxSize)
+	}
+	return nil
+}
+
+func validateCAPIObjectLabels(clusterConfig *Cluster) error {
+	for key := range clusterConfig.Spec.CAPIObjectLabels {
+		if strings.HasPrefix(key, clusterv1.GroupVersion.Group) {
+			return fmt.Errorf("capiObjectLabels key %s is invalid, keys with prefix %s are reserved", key, clusterv1.GroupVersion.Group)
+		}
+	}
+	if err := validateNodeLabels(clusterConfig.Spec.CAPIObjectLabels, field.NewPath("spec", "capiObjectLabels")); err != nil {
+		return fmt.Errorf("capiObjectLabels is invalid: %v", err)
+	}
+	return nil
+}
+
 func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 	if (clusterConfig.Spec.ControlPlaneConfiguration.Endpoint == nil || len(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host) <= 0) && clusterConfig.Spec.DatacenterRef.Kind != DockerDatacenterKind {
 		return errors.New("cluster controlPlaneConfiguration.Endpoint.Host is not set or is empty")
@@ -464,6 +494,10 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 			return fmt.Errorf("validating upgrade rollout strategy configuration: %v", err)
 		}
 
+		if err := validateMDDeletePolicy(&workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("validating delete policy: %v", err)
+		}
+
 		if workerNodeGroupNames[workerNodeGroupConfig.Name] {
 			return errors.New("worker node group names must be unique")
 		}
@@ -555,6 +589,26 @@ func validateEtcdReplicas(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateEtcdBackup(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.ExternalEtcdConfiguration == nil || clusterConfig.Spec.ExternalEtcdConfiguration.Backup == nil {
+		return nil
+	}
+	backup := clusterConfig.Spec.ExternalEtcdConfiguration.Backup
+	if backup.Schedule == "" {
+		return errors.New("etcd backup schedule cannot be empty")
+	}
+	if _, err := cron.ParseStandard(backup.Schedule); err != nil {
+		return fmt.Errorf("invalid etcd backup schedule %q: %v", backup.Schedule, err)
+	}
+	if backup.Destination == "" {
+		return errors.New("etcd backup destination cannot be empty")
+	}
+	if !path.IsAbs(backup.Destination) {
+		return fmt.Errorf("etcd backup destination %s must be an absolute path", backup.Destination)
+	}
+	return nil
+}
+
 func validateNetworking(clusterConfig *Cluster) error {
 	clusterNetwork := clusterConfig.Spec.ClusterNetwork
 
@@ -609,9 +663,30 @@ func validateNetworking(clusterConfig *Cluster) error {
 		return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
 	}
 
+	// each node is allocated one node-mask sized subnet from the pod CIDR, so the pod CIDR
+	// needs to fit a subnet for every node the cluster can scale up to
+	maxNodes := 1 << (nodeCidrMaskSize - podMaskSize)
+	if nodes := maxClusterNodeCount(clusterConfig); nodes > maxNodes {
+		return fmt.Errorf("pods CIDR block %s with node-mask %d supports at most %d nodes, but the cluster can have up to %d nodes", clusterNetwork.Pods.CidrBlocks[0], nodeCidrMaskSize, maxNodes, nodes)
+	}
+
 	return validateCNIPlugin(clusterNetwork)
 }
 
+// maxClusterNodeCount returns the number of nodes the cluster can scale up to,
+// using the autoscaling max count for worker node groups when configured.
+func maxClusterNodeCount(cluster *Cluster) int {
+	count := cluster.Spec.ControlPlaneConfiguration.Count
+	for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.AutoScalingConfiguration != nil {
+			count += w.AutoScalingConfiguration.MaxCount
+		} else if w.Count != nil {
+			count += *w.Count
+		}
+	}
+	return count
+}
+
 func validateCNIPlugin(network ClusterNetwork) error {
 	if network.CNI != "" {
 		if network.CNIConfig != nil {
@@ -838,6 +913,148 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration) error {
 	return nil
 }
 
+func validateCoreDNSConfiguration(clusterConfig *Cluster) error {
+	coreDNS := clusterConfig.Spec.CoreDNSConfiguration
+	if coreDNS == nil || coreDNS.Replicas == nil {
+		return nil
+	}
+
+	if *coreDNS.Replicas <= 0 {
+		return fmt.Errorf("coreDNSConfiguration replicas must be positive, got %d", *coreDNS.Replicas)
+	}
+
+	return nil
+}
+
+// managedAPIServerExtraArgs are the kube-apiserver flags EKS Anywhere sets itself for
+// OIDC, AWS IAM Authenticator, Pod IAM, feature gates and TLS.
+var managedAPIServerExtraArgs = map[string]struct{}{
+	"oidc-client-id":                           {},
+	"oidc-groups-claim":                        {},
+	"oidc-groups-prefix":                       {},
+	"oidc-issuer-url":                          {},
+	"oidc-required-claim":                      {},
+	"oidc-username-claim":                      {},
+	"oidc-username-prefix":                     {},
+	"authentication-token-webhook-config-file": {},
+	"service-account-issuer":                   {},
+	"feature-gates":                            {},
+	"tls-cipher-suites":                        {},
+}
+
+// vsphereManagedAPIServerExtraArgs are the kube-apiserver flags the vSphere control plane
+// template sets, including the ones derived from controlPlaneConfiguration.auditLogRotation.
+var vsphereManagedAPIServerExtraArgs = map[string]struct{}{
+	"cloud-provider":      {},
+	"audit-policy-file":   {},
+	"audit-log-path":      {},
+	"audit-log-maxage":    {},
+	"audit-log-maxbackup": {},
+	"audit-log-maxsize":   {},
+	"profiling":           {},
+}
+
+func validateAPIServerExtraArgs(clusterConfig *Cluster) error {
+	for key := range clusterConfig.Spec.APIServerExtraArgs {
+		if key == "" {
+			return errors.New("apiServerExtraArgs: flag name can't be empty")
+		}
+		if _, ok := managedAPIServerExtraArgs[key]; ok {
+			return fmt.Errorf("apiServerExtraArgs: flag %s is managed by EKS Anywhere and can't be overridden", key)
+		}
+		if _, ok := vsphereManagedAPIServerExtraArgs[key]; ok && clusterConfig.Spec.DatacenterRef.Kind == VSphereDatacenterKind {
+			return fmt.Errorf("apiServerExtraArgs: flag %s is managed by EKS Anywhere on vSphere and can't be overridden", key)
+		}
+	}
+	return nil
+}
+
+// managedKubeletExtraArgs are the kubelet flags EKS Anywhere sets itself and that can't be overridden.
+// Node labels and the resolv.conf path are configured through the node group labels and clusterNetwork.dns.resolvConf.
+var managedKubeletExtraArgs = map[string]struct{}{
+	"tls-cipher-suites": {},
+	"node-labels":       {},
+	"resolv-conf":       {},
+}
+
+func validateKubeletExtraArgs(clusterConfig *Cluster) error {
+	if err := validateKubeletExtraArgsFlags("controlPlaneConfiguration.kubeletExtraArgs", clusterConfig.Spec.ControlPlaneConfiguration.KubeletExtraArgs); err != nil {
+		return err
+	}
+	for _, nodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		field := fmt.Sprintf("workerNodeGroupConfigurations[%s].kubeletExtraArgs", nodeGroup.Name)
+		if err := validateKubeletExtraArgsFlags(field, nodeGroup.KubeletExtraArgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateKubeletExtraArgsFlags(field string, args map[string]string) error {
+	for key := range args {
+		if key == "" {
+			return fmt.Errorf("%s: flag name can't be empty", field)
+		}
+		if _, ok := managedKubeletExtraArgs[key]; ok {
+			return fmt.Errorf("%s: flag %s is managed by EKS Anywhere and can't be overridden", field, key)
+		}
+	}
+	return nil
+}
+
+// highestUserDefinablePriority is the highest value the API server accepts for a user defined PriorityClass.
+const highestUserDefinablePriority = 1000000000
+
+func validatePriorityClasses(clusterConfig *Cluster) error {
+	names := make(map[string]struct{}, len(clusterConfig.Spec.PriorityClasses))
+	globalDefault := ""
+	for _, pc := range clusterConfig.Spec.PriorityClasses {
+		if pc.Name == "" {
+			return errors.New("priorityClasses: name must be specified")
+		}
+		if errs := utilvalidation.IsDNS1123Subdomain(pc.Name); len(errs) > 0 {
+			return fmt.Errorf("priorityClasses: invalid name %s: %s", pc.Name, strings.Join(errs, ", "))
+		}
+		if strings.HasPrefix(pc.Name, "system-") {
+			return fmt.Errorf("priorityClasses: name %s is invalid, the system- prefix is reserved", pc.Name)
+		}
+		if _, ok := names[pc.Name]; ok {
+			return fmt.Errorf("priorityClasses: duplicate name %s", pc.Name)
+		}
+		names[pc.Name] = struct{}{}
+
+		if pc.Value > highestUserDefinablePriority {
+			return fmt.Errorf("priorityClasses: value %d for %s exceeds the maximum of %d", pc.Value, pc.Name, highestUserDefinablePriority)
+		}
+
+		switch corev1.PreemptionPolicy(pc.PreemptionPolicy) {
+		case "", corev1.PreemptLowerPriority, corev1.PreemptNever:
+		default:
+			return fmt.Errorf("priorityClasses: preemptionPolicy %s for %s is not supported, valid values are %s and %s",
+				pc.PreemptionPolicy, pc.Name, corev1.PreemptLowerPriority, corev1.PreemptNever)
+		}
+
+		if pc.GlobalDefault {
+			if globalDefault != "" {
+				return fmt.Errorf("priorityClasses: only one globalDefault is allowed, found %s and %s", globalDefault, pc.Name)
+			}
+			globalDefault = pc.Name
+		}
+	}
+
+	return nil
+}
+
+func validateMDDeletePolicy(w *WorkerNodeGroupConfiguration) error {
+	switch clusterv1.MachineSetDeletePolicy(w.DeletePolicy) {
+	case "", clusterv1.RandomMachineSetDeletePolicy, clusterv1.NewestMachineSetDeletePolicy, clusterv1.OldestMachineSetDeletePolicy:
+		return nil
+	default:
+		return fmt.Errorf("WorkerNodeGroupConfiguration: deletePolicy %s is not supported, valid values are %s, %s and %s",
+			w.DeletePolicy, clusterv1.RandomMachineSetDeletePolicy, clusterv1.NewestMachineSetDeletePolicy, clusterv1.OldestMachineSetDeletePolicy)
+	}
+}
+
 func validatePackageControllerConfiguration(clusterConfig *Cluster) error {
 	if clusterConfig.IsManaged() {
 		if clusterConfig.Spec.Packages != nil {
@@ -95,52 +95,6 @@ func TestClusterNameLength(t *testing.T) {
 	}
 }
 
-func TestValidateExternalEtcdSupport(t *testing.T) {
-	g := NewWithT(t)
-	tests := []struct {
-		name        string
-		wantCluster *Cluster
-		wantErr     bool
-	}{
-		{
-			name: "tinkerbell config without external etcd",
-			wantCluster: &Cluster{
-				Spec: ClusterSpec{
-					DatacenterRef: Ref{
-						Kind: TinkerbellDatacenterKind,
-						Name: "eksa-unit-test",
-					},
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "tinkerbell config with external etcd",
-			wantCluster: &Cluster{
-				Spec: ClusterSpec{
-					ExternalEtcdConfiguration: &ExternalEtcdConfiguration{Count: 1},
-					DatacenterRef: Ref{
-						Kind: TinkerbellDatacenterKind,
-						Name: "eksa-unit-test",
-					},
-				},
-			},
-			wantErr: true,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(tt *testing.T) {
-			got := validateExternalEtcdSupport(tc.wantCluster)
-			if tc.wantErr {
-				g.Expect(got).To(MatchError(ContainSubstring("external etcd configuration is unsupported")))
-			} else {
-				g.Expect(got).To(Succeed())
-			}
-		})
-	}
-}
-
 func TestGetAndValidateClusterConfig(t *testing.T) {
 	tests := []struct {
 		testName    string
@@ -2194,6 +2148,75 @@ func TestValidateNetworking(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "pods CIDR block large enough for node count",
+			wantErr: nil,
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Count: 3,
+					},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Count: ptr.Int(3),
+						},
+						{
+							Count: ptr.Int(1),
+							AutoScalingConfiguration: &AutoScalingConfiguration{
+								MinCount: 1,
+								MaxCount: 10,
+							},
+						},
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/20",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "pods CIDR block too small for node count",
+			wantErr: fmt.Errorf("pods CIDR block 192.168.0.0/22 with node-mask 24 supports at most 4 nodes, but the cluster can have up to 13 nodes"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Count: 3,
+					},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Count: ptr.Int(1),
+							AutoScalingConfiguration: &AutoScalingConfiguration{
+								MinCount: 1,
+								MaxCount: 10,
+							},
+						},
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/22",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
 		{
 			name:    "both pods CIDR block and service CIDR block do not conflict with control plane endpoint",
 			wantErr: nil,
@@ -3098,6 +3121,51 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 	}
 }
 
+func TestValidateMDDeletePolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantErr      string
+		deletePolicy string
+	}{
+		{
+			name:         "delete policy not set",
+			wantErr:      "",
+			deletePolicy: "",
+		},
+		{
+			name:         "delete policy random",
+			wantErr:      "",
+			deletePolicy: "Random",
+		},
+		{
+			name:         "delete policy newest",
+			wantErr:      "",
+			deletePolicy: "Newest",
+		},
+		{
+			name:         "delete policy oldest",
+			wantErr:      "",
+			deletePolicy: "Oldest",
+		},
+		{
+			name:         "delete policy invalid",
+			wantErr:      "WorkerNodeGroupConfiguration: deletePolicy Unhealthy is not supported, valid values are Random, Newest and Oldest",
+			deletePolicy: "Unhealthy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateMDDeletePolicy(&WorkerNodeGroupConfiguration{DeletePolicy: tt.deletePolicy})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func TestGetClusterDefaultKubernetesVersion(t *testing.T) {
 	g := NewWithT(t)
 	g.Expect(GetClusterDefaultKubernetesVersion()).To(Equal(Kube125))
@@ -3255,3 +3323,449 @@ func TestClusterMDUpgradeRolloutStrategyNotNil(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCAPIObjectLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		labels  map[string]string
+	}{
+		{
+			name:    "no labels",
+			wantErr: "",
+			labels:  nil,
+		},
+		{
+			name:    "valid labels",
+			wantErr: "",
+			labels: map[string]string{
+				"environment": "test",
+				"cost-center": "1234",
+			},
+		},
+		{
+			name:    "reserved prefix",
+			wantErr: "capiObjectLabels key cluster.x-k8s.io/cluster-name is invalid, keys with prefix cluster.x-k8s.io are reserved",
+			labels: map[string]string{
+				"cluster.x-k8s.io/cluster-name": "test",
+			},
+		},
+		{
+			name:    "invalid value",
+			wantErr: "capiObjectLabels is invalid",
+			labels: map[string]string{
+				"environment": "-test",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					CAPIObjectLabels: tt.labels,
+				},
+			}
+			err := validateCAPIObjectLabels(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateCoreDNSConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		coreDNS *CoreDNSConfiguration
+	}{
+		{
+			name:    "not set",
+			wantErr: "",
+			coreDNS: nil,
+		},
+		{
+			name:    "replicas not set",
+			wantErr: "",
+			coreDNS: &CoreDNSConfiguration{PodAntiAffinity: true},
+		},
+		{
+			name:    "positive replicas",
+			wantErr: "",
+			coreDNS: &CoreDNSConfiguration{Replicas: ptr.Int(3), PodAntiAffinity: true},
+		},
+		{
+			name:    "zero replicas",
+			wantErr: "coreDNSConfiguration replicas must be positive, got 0",
+			coreDNS: &CoreDNSConfiguration{Replicas: ptr.Int(0)},
+		},
+		{
+			name:    "negative replicas",
+			wantErr: "coreDNSConfiguration replicas must be positive, got -1",
+			coreDNS: &CoreDNSConfiguration{Replicas: ptr.Int(-1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					CoreDNSConfiguration: tt.coreDNS,
+				},
+			}
+			err := validateCoreDNSConfiguration(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePriorityClasses(t *testing.T) {
+	tests := []struct {
+		name            string
+		wantErr         string
+		priorityClasses []PriorityClass
+	}{
+		{
+			name:            "not set",
+			wantErr:         "",
+			priorityClasses: nil,
+		},
+		{
+			name:    "valid",
+			wantErr: "",
+			priorityClasses: []PriorityClass{
+				{Name: "critical", Value: 1000000000, PreemptionPolicy: "PreemptLowerPriority"},
+				{Name: "batch", Value: -10, GlobalDefault: true, PreemptionPolicy: "Never"},
+			},
+		},
+		{
+			name:            "missing name",
+			wantErr:         "priorityClasses: name must be specified",
+			priorityClasses: []PriorityClass{{Value: 10}},
+		},
+		{
+			name:            "invalid name",
+			wantErr:         "priorityClasses: invalid name Critical",
+			priorityClasses: []PriorityClass{{Name: "Critical", Value: 10}},
+		},
+		{
+			name:            "reserved prefix",
+			wantErr:         "priorityClasses: name system-critical is invalid, the system- prefix is reserved",
+			priorityClasses: []PriorityClass{{Name: "system-critical", Value: 10}},
+		},
+		{
+			name:            "duplicate name",
+			wantErr:         "priorityClasses: duplicate name critical",
+			priorityClasses: []PriorityClass{{Name: "critical", Value: 10}, {Name: "critical", Value: 20}},
+		},
+		{
+			name:            "value too high",
+			wantErr:         "priorityClasses: value 1000000001 for critical exceeds the maximum of 1000000000",
+			priorityClasses: []PriorityClass{{Name: "critical", Value: 1000000001}},
+		},
+		{
+			name:            "invalid preemption policy",
+			wantErr:         "priorityClasses: preemptionPolicy Always for critical is not supported, valid values are PreemptLowerPriority and Never",
+			priorityClasses: []PriorityClass{{Name: "critical", Value: 10, PreemptionPolicy: "Always"}},
+		},
+		{
+			name:    "multiple global defaults",
+			wantErr: "priorityClasses: only one globalDefault is allowed, found critical and batch",
+			priorityClasses: []PriorityClass{
+				{Name: "critical", Value: 10, GlobalDefault: true},
+				{Name: "batch", Value: 5, GlobalDefault: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					PriorityClasses: tt.priorityClasses,
+				},
+			}
+			err := validatePriorityClasses(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerExtraArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantErr        string
+		datacenterKind string
+		args           map[string]string
+	}{
+		{
+			name:    "not set",
+			wantErr: "",
+			args:    nil,
+		},
+		{
+			name:           "valid",
+			wantErr:        "",
+			datacenterKind: TinkerbellDatacenterKind,
+			args:           map[string]string{"audit-log-maxbackup": "5", "request-timeout": "2m"},
+		},
+		{
+			name:    "empty flag name",
+			wantErr: "apiServerExtraArgs: flag name can't be empty",
+			args:    map[string]string{"": "true"},
+		},
+		{
+			name:    "managed flag",
+			wantErr: "apiServerExtraArgs: flag oidc-issuer-url is managed by EKS Anywhere and can't be overridden",
+			args:    map[string]string{"oidc-issuer-url": "https://issuer"},
+		},
+		{
+			name:           "vsphere audit log flag",
+			wantErr:        "apiServerExtraArgs: flag audit-log-maxbackup is managed by EKS Anywhere on vSphere and can't be overridden",
+			datacenterKind: VSphereDatacenterKind,
+			args:           map[string]string{"audit-log-maxbackup": "5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef:      Ref{Kind: tt.datacenterKind},
+					APIServerExtraArgs: tt.args,
+				},
+			}
+			err := validateAPIServerExtraArgs(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateKubeletExtraArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    string
+		cpArgs     map[string]string
+		workerArgs map[string]string
+	}{
+		{
+			name:    "not set",
+			wantErr: "",
+		},
+		{
+			name:       "valid",
+			wantErr:    "",
+			cpArgs:     map[string]string{"system-reserved": "cpu=500m,memory=1Gi"},
+			workerArgs: map[string]string{"kube-reserved": "cpu=1,memory=2Gi"},
+		},
+		{
+			name:    "empty control plane flag name",
+			wantErr: "controlPlaneConfiguration.kubeletExtraArgs: flag name can't be empty",
+			cpArgs:  map[string]string{"": "true"},
+		},
+		{
+			name:    "managed control plane flag",
+			wantErr: "controlPlaneConfiguration.kubeletExtraArgs: flag tls-cipher-suites is managed by EKS Anywhere and can't be overridden",
+			cpArgs:  map[string]string{"tls-cipher-suites": "TLS_RSA_WITH_AES_128_CBC_SHA"},
+		},
+		{
+			name:       "managed worker flag",
+			wantErr:    "workerNodeGroupConfigurations[md-0].kubeletExtraArgs: flag tls-cipher-suites is managed by EKS Anywhere and can't be overridden",
+			workerArgs: map[string]string{"tls-cipher-suites": "TLS_RSA_WITH_AES_128_CBC_SHA"},
+		},
+		{
+			name:    "managed control plane node labels",
+			wantErr: "controlPlaneConfiguration.kubeletExtraArgs: flag node-labels is managed by EKS Anywhere and can't be overridden",
+			cpArgs:  map[string]string{"node-labels": "key=value"},
+		},
+		{
+			name:       "managed worker resolv conf",
+			wantErr:    "workerNodeGroupConfigurations[md-0].kubeletExtraArgs: flag resolv-conf is managed by EKS Anywhere and can't be overridden",
+			workerArgs: map[string]string{"resolv-conf": "/etc/my-resolv.conf"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{KubeletExtraArgs: tt.cpArgs},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Name: "md-0", KubeletExtraArgs: tt.workerArgs},
+					},
+				},
+			}
+			err := validateKubeletExtraArgs(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateEtcdBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		backup  *EtcdBackupConfiguration
+	}{
+		{
+			name:    "not set",
+			wantErr: "",
+			backup:  nil,
+		},
+		{
+			name:    "valid",
+			wantErr: "",
+			backup:  &EtcdBackupConfiguration{Schedule: "0 */6 * * *", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "valid with names, ranges and lists",
+			wantErr: "",
+			backup:  &EtcdBackupConfiguration{Schedule: "30 1,13 * JAN-jun mon-fri", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "valid macro",
+			wantErr: "",
+			backup:  &EtcdBackupConfiguration{Schedule: "@daily", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "missing schedule",
+			wantErr: "etcd backup schedule cannot be empty",
+			backup:  &EtcdBackupConfiguration{Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "wrong number of fields",
+			wantErr: "invalid etcd backup schedule \"0 */6 * *\": expected exactly 5 fields, found 4",
+			backup:  &EtcdBackupConfiguration{Schedule: "0 */6 * *", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "value out of range",
+			wantErr: "invalid etcd backup schedule \"61 * * * *\": end of range (61) above maximum (59)",
+			backup:  &EtcdBackupConfiguration{Schedule: "61 * * * *", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "invalid step",
+			wantErr: "step of range should be a positive number: */0",
+			backup:  &EtcdBackupConfiguration{Schedule: "0 */0 * * *", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "invalid range",
+			wantErr: "beginning of range (20) beyond end of range (10): 20-10",
+			backup:  &EtcdBackupConfiguration{Schedule: "0 0 20-10 * *", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "invalid value",
+			wantErr: "failed to parse int from someday",
+			backup:  &EtcdBackupConfiguration{Schedule: "0 0 * * someday", Destination: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "missing destination",
+			wantErr: "etcd backup destination cannot be empty",
+			backup:  &EtcdBackupConfiguration{Schedule: "@hourly"},
+		},
+		{
+			name:    "relative destination",
+			wantErr: "etcd backup destination backups must be an absolute path",
+			backup:  &EtcdBackupConfiguration{Schedule: "@hourly", Destination: "backups"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ExternalEtcdConfiguration: &ExternalEtcdConfiguration{
+						Count:  3,
+						Backup: tt.backup,
+					},
+				},
+			}
+			err := validateEtcdBackup(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateAuditLogRotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		rotation *AuditLogRotation
+	}{
+		{
+			name:     "not set",
+			wantErr:  "",
+			rotation: nil,
+		},
+		{
+			name:     "valid",
+			wantErr:  "",
+			rotation: &AuditLogRotation{MaxAge: 7, MaxBackup: 3, MaxSize: 100},
+		},
+		{
+			name:     "max age not positive",
+			wantErr:  "auditLogRotation maxAge 0 is invalid, it must be positive",
+			rotation: &AuditLogRotation{MaxBackup: 3, MaxSize: 100},
+		},
+		{
+			name:     "max backup not positive",
+			wantErr:  "auditLogRotation maxBackup -1 is invalid, it must be positive",
+			rotation: &AuditLogRotation{MaxAge: 7, MaxBackup: -1, MaxSize: 100},
+		},
+		{
+			name:     "max size not positive",
+			wantErr:  "auditLogRotation maxSize 0 is invalid, it must be positive",
+			rotation: &AuditLogRotation{MaxAge: 7, MaxBackup: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						AuditLogRotation: tt.rotation,
+					},
+				},
+			}
+			err := validateAuditLogRotation(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestControlPlaneConfigurationEqualAuditLogRotation(t *testing.T) {
+	g := NewWithT(t)
+	rotation := &AuditLogRotation{MaxAge: 7, MaxBackup: 3, MaxSize: 100}
+	cp := &ControlPlaneConfiguration{Count: 1, AuditLogRotation: rotation}
+
+	g.Expect(cp.Equal(&ControlPlaneConfiguration{Count: 1, AuditLogRotation: &AuditLogRotation{MaxAge: 7, MaxBackup: 3, MaxSize: 100}})).To(BeTrue())
+	g.Expect(cp.Equal(&ControlPlaneConfiguration{Count: 1, AuditLogRotation: &AuditLogRotation{MaxAge: 7, MaxBackup: 3, MaxSize: 200}})).To(BeFalse())
+	g.Expect(cp.Equal(&ControlPlaneConfiguration{Count: 1})).To(BeFalse())
+}
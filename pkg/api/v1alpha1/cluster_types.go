@@ -57,6 +57,15 @@ type ClusterSpec struct {
 	Packages                    *PackageConfiguration        `json:"packages,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster
 	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
+	// CAPIObjectLabels are labels added to the metadata of every CAPI object generated for the cluster
+	CAPIObjectLabels map[string]string `json:"capiObjectLabels,omitempty"`
+	// CoreDNSConfiguration defines customizations applied to the CoreDNS deployment
+	CoreDNSConfiguration *CoreDNSConfiguration `json:"coreDNSConfiguration,omitempty"`
+	// PriorityClasses are pod PriorityClasses ensured in the workload cluster after it's created
+	PriorityClasses []PriorityClass `json:"priorityClasses,omitempty"`
+	// APIServerExtraArgs are additional kube-apiserver flags applied to the control plane nodes.
+	// Flags managed by EKS Anywhere can't be overridden.
+	APIServerExtraArgs map[string]string `json:"apiServerExtraArgs,omitempty"`
 }
 
 // HasAWSIamConfig checks if AWSIamConfig is configured for the cluster.
@@ -116,6 +125,18 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.BundlesRef.Equal(o.Spec.BundlesRef) {
 		return false
 	}
+	if !MapEqual(n.Spec.CAPIObjectLabels, o.Spec.CAPIObjectLabels) {
+		return false
+	}
+	if !n.Spec.CoreDNSConfiguration.Equal(o.Spec.CoreDNSConfiguration) {
+		return false
+	}
+	if !PriorityClassesSliceEqual(n.Spec.PriorityClasses, o.Spec.PriorityClasses) {
+		return false
+	}
+	if !MapEqual(n.Spec.APIServerExtraArgs, o.Spec.APIServerExtraArgs) {
+		return false
+	}
 
 	return true
 }
@@ -148,6 +169,63 @@ func (n *ProxyConfiguration) Equal(o *ProxyConfiguration) bool {
 	return n.HttpProxy == o.HttpProxy && n.HttpsProxy == o.HttpsProxy && SliceEqual(n.NoProxy, o.NoProxy)
 }
 
+// CoreDNSConfiguration defines the customizations applied to the CoreDNS deployment.
+type CoreDNSConfiguration struct {
+	// Replicas is the number of CoreDNS pods. When not set, the kubeadm default is kept.
+	Replicas *int `json:"replicas,omitempty"`
+	// PodAntiAffinity prefers scheduling CoreDNS pods on different nodes
+	PodAntiAffinity bool `json:"podAntiAffinity,omitempty"`
+}
+
+// Equal compares two CoreDNSConfigurations.
+func (n *CoreDNSConfiguration) Equal(o *CoreDNSConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if (n.Replicas == nil) != (o.Replicas == nil) {
+		return false
+	}
+	if n.Replicas != nil && *n.Replicas != *o.Replicas {
+		return false
+	}
+	return n.PodAntiAffinity == o.PodAntiAffinity
+}
+
+// PriorityClass defines a pod PriorityClass to create in the workload cluster.
+type PriorityClass struct {
+	// Name is the name of the PriorityClass object
+	Name string `json:"name"`
+	// Value is the priority of pods using this class. User defined classes can't exceed 1000000000.
+	Value int32 `json:"value"`
+	// GlobalDefault makes this the priority class for pods without a priorityClassName.
+	// Only one PriorityClass can be the global default.
+	GlobalDefault bool `json:"globalDefault,omitempty"`
+	// PreemptionPolicy is either PreemptLowerPriority or Never. Defaults to PreemptLowerPriority.
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty"`
+	// Description is an arbitrary string describing when the class should be used
+	Description string `json:"description,omitempty"`
+}
+
+// PriorityClassesSliceEqual compares two PriorityClass slices, ignoring order.
+func PriorityClassesSliceEqual(a, b []PriorityClass) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	m := make(map[string]PriorityClass, len(a))
+	for _, v := range a {
+		m[v.Name] = v
+	}
+	for _, v := range b {
+		if m[v.Name] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // RegistryMirrorConfiguration defines the settings for image registry mirror.
 type RegistryMirrorConfiguration struct {
 	// Endpoint defines the registry mirror endpoint to use for pulling images
@@ -232,6 +310,33 @@ type ControlPlaneConfiguration struct {
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *ControlPlaneUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// AuditLogRotation configures the rotation of the kube-apiserver audit log.
+	// Defaults to 30 days, 10 backups and 512 megabytes.
+	AuditLogRotation *AuditLogRotation `json:"auditLogRotation,omitempty"`
+	// KubeletExtraArgs are additional kubelet flags applied to the control plane nodes.
+	// Flags managed by EKS Anywhere can't be overridden.
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+}
+
+// AuditLogRotation defines the rotation policy of the kube-apiserver audit log.
+type AuditLogRotation struct {
+	// MaxAge is the maximum number of days to retain old audit log files.
+	MaxAge int `json:"maxAge"`
+	// MaxBackup is the maximum number of old audit log files to retain.
+	MaxBackup int `json:"maxBackup"`
+	// MaxSize is the maximum size in megabytes of the audit log file before it gets rotated.
+	MaxSize int `json:"maxSize"`
+}
+
+// Equal checks if two AuditLogRotation are equal.
+func (n *AuditLogRotation) Equal(o *AuditLogRotation) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MaxAge == o.MaxAge && n.MaxBackup == o.MaxBackup && n.MaxSize == o.MaxSize
 }
 
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
@@ -276,7 +381,8 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 		return false
 	}
 	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) &&
-		TaintsSliceEqual(n.Taints, o.Taints) && MapEqual(n.Labels, o.Labels)
+		TaintsSliceEqual(n.Taints, o.Taints) && MapEqual(n.Labels, o.Labels) && n.AuditLogRotation.Equal(o.AuditLogRotation) &&
+		MapEqual(n.KubeletExtraArgs, o.KubeletExtraArgs)
 }
 
 type Endpoint struct {
@@ -310,6 +416,12 @@ type WorkerNodeGroupConfiguration struct {
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// DeletePolicy defines the policy used to choose the machines to delete when scaling down.
+	// Valid values are Random, Newest and Oldest. Defaults to Random.
+	DeletePolicy string `json:"deletePolicy,omitempty"`
+	// KubeletExtraArgs are additional kubelet flags applied to the worker nodes.
+	// Flags managed by EKS Anywhere can't be overridden.
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
@@ -348,7 +460,8 @@ func WorkerNodeGroupConfigurationsSliceEqual(a, b []WorkerNodeGroupConfiguration
 		return false
 	}
 
-	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b)
+	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b) &&
+		WorkerNodeGroupConfigurationsKubeletExtraArgsMapEqual(a, b) && WorkerNodeGroupConfigurationsDeletePolicyEqual(a, b)
 }
 
 func WorkerNodeGroupConfigurationSliceTaintsEqual(a, b []WorkerNodeGroupConfiguration) bool {
@@ -393,6 +506,38 @@ func WorkerNodeGroupConfigurationsLabelsMapEqual(a, b []WorkerNodeGroupConfigura
 	return true
 }
 
+// WorkerNodeGroupConfigurationsKubeletExtraArgsMapEqual compares the kubelet extra args of the node groups
+// present in both a and b.
+func WorkerNodeGroupConfigurationsKubeletExtraArgsMapEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]map[string]string, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.KubeletExtraArgs
+	}
+
+	for _, nodeGroup := range b {
+		if args, ok := m[nodeGroup.Name]; ok && !MapEqual(args, nodeGroup.KubeletExtraArgs) {
+			return false
+		}
+	}
+	return true
+}
+
+// WorkerNodeGroupConfigurationsDeletePolicyEqual compares the delete policy of the node groups
+// present in both a and b.
+func WorkerNodeGroupConfigurationsDeletePolicyEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]string, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.DeletePolicy
+	}
+
+	for _, nodeGroup := range b {
+		if policy, ok := m[nodeGroup.Name]; ok && policy != nodeGroup.DeletePolicy {
+			return false
+		}
+	}
+	return true
+}
+
 type ClusterNetwork struct {
 	// Comma-separated list of CIDR blocks to use for pod and service subnets.
 	// Defaults to 192.168.0.0/16 for pod subnet.
@@ -892,6 +1037,9 @@ type ExternalEtcdConfiguration struct {
 	Count int `json:"count,omitempty"`
 	// MachineGroupRef defines the machine group configuration for the etcd machines.
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
+	// Backup configures periodic snapshots of the external etcd cluster.
+	// +optional
+	Backup *EtcdBackupConfiguration `json:"backup,omitempty"`
 }
 
 func (n *ExternalEtcdConfiguration) Equal(o *ExternalEtcdConfiguration) bool {
@@ -901,7 +1049,25 @@ func (n *ExternalEtcdConfiguration) Equal(o *ExternalEtcdConfiguration) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Count == o.Count && n.MachineGroupRef.Equal(o.MachineGroupRef)
+	return n.Count == o.Count && n.MachineGroupRef.Equal(o.MachineGroupRef) && n.Backup.Equal(o.Backup)
+}
+
+// EtcdBackupConfiguration defines how snapshots of the external etcd cluster are taken.
+type EtcdBackupConfiguration struct {
+	// Schedule is the cron expression the etcd snapshots are taken on.
+	Schedule string `json:"schedule"`
+	// Destination is the absolute path on the control plane nodes where the etcd snapshots are written.
+	Destination string `json:"destination"`
+}
+
+func (n *EtcdBackupConfiguration) Equal(o *EtcdBackupConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Schedule == o.Schedule && n.Destination == o.Destination
 }
 
 type ManagementCluster struct {
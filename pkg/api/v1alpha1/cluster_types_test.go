@@ -556,6 +556,53 @@ func TestClusterEqualWorkerNodeGroupConfigurations(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			testName: "both exist, same delete policy",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:         "md-0",
+					DeletePolicy: "Oldest",
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:         "md-0",
+					DeletePolicy: "Oldest",
+				},
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, delete policy diff",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:         "md-0",
+					DeletePolicy: "Oldest",
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:         "md-0",
+					DeletePolicy: "Newest",
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, one with no delete policy",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name: "md-0",
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:         "md-0",
+					DeletePolicy: "Random",
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
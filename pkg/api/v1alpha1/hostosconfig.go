@@ -19,6 +19,10 @@ func validateHostOSConfig(config *HostOSConfiguration, osFamily OSFamily) error
 		return err
 	}
 
+	if err := validateRunCmd(config.RunCmd, osFamily); err != nil {
+		return err
+	}
+
 	return validateBotterocketConfig(config.BottlerocketConfiguration, osFamily)
 }
 
@@ -52,6 +56,24 @@ func addNTPScheme(server string) string {
 	return fmt.Sprintf("udp://%s", server)
 }
 
+func validateRunCmd(commands []string, osFamily OSFamily) error {
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if osFamily == Bottlerocket {
+		return fmt.Errorf("RunCmd can not be used with osFamily: \"%s\"", Bottlerocket)
+	}
+
+	for _, cmd := range commands {
+		if strings.TrimSpace(cmd) == "" {
+			return errors.New("RunCmd can not have an empty command")
+		}
+	}
+
+	return nil
+}
+
 func validateBotterocketConfig(config *BottlerocketConfiguration, osFamily OSFamily) error {
 	if config == nil {
 		return nil
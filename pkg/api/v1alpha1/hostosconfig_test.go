@@ -66,6 +66,33 @@ func TestValidateHostOSConfig(t *testing.T) {
 			osFamily: Bottlerocket,
 			wantErr:  "",
 		},
+		{
+			name: "valid RunCmd",
+			hostOSConfig: &HostOSConfiguration{
+				RunCmd: []string{
+					"mount -t nfs 10.0.0.5:/exports /mnt/shared",
+					"/usr/local/bin/fetch-license --output /etc/license",
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "",
+		},
+		{
+			name: "empty RunCmd entry",
+			hostOSConfig: &HostOSConfiguration{
+				RunCmd: []string{"echo hello", "  "},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "RunCmd can not have an empty command",
+		},
+		{
+			name: "RunCmd with bottlerocket",
+			hostOSConfig: &HostOSConfiguration{
+				RunCmd: []string{"echo hello"},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "RunCmd can not be used with osFamily: \"bottlerocket\"",
+		},
 		{
 			name: "empty Bottlerocket config",
 			hostOSConfig: &HostOSConfiguration{
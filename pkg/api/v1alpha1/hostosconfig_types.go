@@ -9,6 +9,11 @@ type HostOSConfiguration struct {
 
 	// +optional
 	BottlerocketConfiguration *BottlerocketConfiguration `json:"bottlerocketConfiguration,omitempty"`
+
+	// RunCmd defines a list of commands run on the host OS on first boot, after the node has been provisioned.
+	// These commands can't be used when the `osFamily` is bottlerocket.
+	// +optional
+	RunCmd []string `json:"runCmd,omitempty"`
 }
 
 // NTPConfiguration defines the NTP configuration on the host OS.
@@ -51,9 +51,17 @@ func validateOIDCConfig(config *OIDCConfig) field.ErrorList {
 	if config.Spec.ClientId == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "clientId"), config.Spec.ClientId, "OIDCConfig clientId is required"))
 	}
+	if config.Spec.GroupsPrefix != "" && config.Spec.GroupsClaim == "" {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "groupsPrefix"), config.Spec.GroupsPrefix, "OIDCConfig groupsPrefix requires groupsClaim to be set"))
+	}
 	if len(config.Spec.RequiredClaims) > 1 {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "requiredClaims"), config.Spec.RequiredClaims, "only one OIDConfig requiredClaim is supported at this time"))
 	}
+	for i, r := range config.Spec.RequiredClaims {
+		if r.Claim == "" && r.Value != "" {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "requiredClaims").Index(i).Child("claim"), r.Claim, "OIDCConfig requiredClaim claim is required when value is set"))
+		}
+	}
 	if config.Spec.IssuerUrl == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "issuerUrl"), config.Spec.IssuerUrl, "OIDCConfig issuerUrl is required"))
 		return errs
@@ -83,6 +83,32 @@ func TestClusterValidateCreateInvalidOIDCConfig(t *testing.T) {
 			},
 			err: "only one OIDConfig requiredClaim is supported at this time",
 		},
+		{
+			name: "Groups prefix without groups claim",
+			config: v1alpha1.OIDCConfig{
+				Spec: v1alpha1.OIDCConfigSpec{
+					ClientId:     "test",
+					IssuerUrl:    "https://test.com",
+					GroupsPrefix: "prefix-for-groups",
+				},
+			},
+			err: "OIDCConfig groupsPrefix requires groupsClaim to be set",
+		},
+		{
+			name: "Required claim value without claim",
+			config: v1alpha1.OIDCConfig{
+				Spec: v1alpha1.OIDCConfigSpec{
+					ClientId:  "test",
+					IssuerUrl: "https://test.com",
+					RequiredClaims: []v1alpha1.OIDCConfigRequiredClaim{
+						{
+							Value: "val1",
+						},
+					},
+				},
+			},
+			err: "spec.requiredClaims[0].claim: Invalid value: \"\": OIDCConfig requiredClaim claim is required when value is set",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
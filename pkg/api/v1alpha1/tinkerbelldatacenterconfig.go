@@ -12,6 +12,13 @@ import (
 
 const TinkerbellDatacenterKind = "TinkerbellDatacenterConfig"
 
+const (
+	// minTinkerbellMTU is the minimum MTU required by IPv6.
+	minTinkerbellMTU = 1280
+	// maxTinkerbellMTU is the largest jumbo frame MTU commonly supported by NICs and switches.
+	maxTinkerbellMTU = 9216
+)
+
 // Used for generating yaml for generate clusterconfig command.
 func NewTinkerbellDatacenterConfigGenerate(clusterName string) *TinkerbellDatacenterConfigGenerate {
 	return &TinkerbellDatacenterConfigGenerate{
@@ -72,6 +79,10 @@ func validateDatacenterConfig(config *TinkerbellDatacenterConfig) error {
 		return fmt.Errorf("TinkerbellDatacenterConfig: invalid tinkerbell ip: %v", err)
 	}
 
+	if config.Spec.MTU != 0 && (config.Spec.MTU < minTinkerbellMTU || config.Spec.MTU > maxTinkerbellMTU) {
+		return fmt.Errorf("TinkerbellDatacenterConfig: invalid mtu %d, must be between %d and %d", config.Spec.MTU, minTinkerbellMTU, maxTinkerbellMTU)
+	}
+
 	return nil
 }
 
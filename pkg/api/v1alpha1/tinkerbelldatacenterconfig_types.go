@@ -18,6 +18,8 @@ type TinkerbellDatacenterConfigSpec struct {
 	// SkipLoadBalancerDeployment when set to "true" can be used to skip deploying a load balancer to expose Tinkerbell stack.
 	// Users will need to deploy and configure a load balancer manually after the cluster is created.
 	SkipLoadBalancerDeployment bool `json:"skipLoadBalancerDeployment,omitempty"`
+	// MTU sets the MTU of the node network interface in the default templates. The OS default is used when unset.
+	MTU int `json:"mtu,omitempty"`
 }
 
 // TinkerbellDatacenterConfigStatus defines the observed state of TinkerbellDatacenterConfig
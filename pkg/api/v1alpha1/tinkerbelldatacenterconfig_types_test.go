@@ -50,6 +50,20 @@ func TestTinkerbellDatacenterConfigValidateFail(t *testing.T) {
 			}),
 			wantErr: "TinkerbellDatacenterConfig: missing name",
 		},
+		{
+			name: "MTU too small",
+			tinkDC: newTinkerbellDatacenterConfig(func(dc *v1alpha1.TinkerbellDatacenterConfig) {
+				dc.Spec.MTU = 576
+			}),
+			wantErr: "invalid mtu 576, must be between 1280 and 9216",
+		},
+		{
+			name: "MTU too large",
+			tinkDC: newTinkerbellDatacenterConfig(func(dc *v1alpha1.TinkerbellDatacenterConfig) {
+				dc.Spec.MTU = 65536
+			}),
+			wantErr: "invalid mtu 65536, must be between 1280 and 9216",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,6 +80,15 @@ func TestTinkerbellDatacenterConfigValidateSuccess(t *testing.T) {
 	g.Expect(tinkDC.Validate()).To(Succeed())
 }
 
+func TestTinkerbellDatacenterConfigValidateSuccessWithMTU(t *testing.T) {
+	tinkDC := newTinkerbellDatacenterConfig(func(dc *v1alpha1.TinkerbellDatacenterConfig) {
+		dc.Spec.MTU = 9000
+	})
+
+	g := NewWithT(t)
+	g.Expect(tinkDC.Validate()).To(Succeed())
+}
+
 func newTinkerbellDatacenterConfig(opts ...func(*v1alpha1.TinkerbellDatacenterConfig)) *v1alpha1.TinkerbellDatacenterConfig {
 	c := createTinkerbellDatacenterConfig()
 	for _, o := range opts {
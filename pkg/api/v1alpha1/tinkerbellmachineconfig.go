@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
@@ -123,6 +124,16 @@ func validateTinkerbellMachineConfig(config *TinkerbellMachineConfig) error {
 		return fmt.Errorf("TinkerbellMachineConfig: missing spec.Users: %s", config.Name)
 	}
 
+	if config.Spec.OSImageURL != "" {
+		if _, err := url.ParseRequestURI(config.Spec.OSImageURL); err != nil {
+			return fmt.Errorf("TinkerbellMachineConfig: parsing spec.osImageURL for %s: %v", config.Name, err)
+		}
+	}
+
+	if config.Spec.NodeStartupTimeout != nil && config.Spec.NodeStartupTimeout.Duration <= 0 {
+		return fmt.Errorf("TinkerbellMachineConfig: spec.nodeStartupTimeout must be positive: %s", config.Name)
+	}
+
 	if err := validateHostOSConfig(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
 		return fmt.Errorf("HostOSConfiguration is invalid for TinkerbellMachineConfig %s: %v", config.Name, err)
 	}
@@ -10,11 +10,17 @@ import (
 
 // TinkerbellMachineConfigSpec defines the desired state of TinkerbellMachineConfig.
 type TinkerbellMachineConfigSpec struct {
-	HardwareSelector    HardwareSelector     `json:"hardwareSelector"`
-	TemplateRef         Ref                  `json:"templateRef,omitempty"`
-	OSFamily            OSFamily             `json:"osFamily"`
+	HardwareSelector HardwareSelector `json:"hardwareSelector"`
+	TemplateRef      Ref              `json:"templateRef,omitempty"`
+	OSFamily         OSFamily         `json:"osFamily"`
+	// OSImageURL overrides the TinkerbellDatacenterConfig OSImageURL for machines using this config.
+	// When empty, the datacenter OSImageURL is used.
+	OSImageURL          string               `json:"osImageURL,omitempty"`
 	Users               []UserConfiguration  `json:"users,omitempty"`
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// NodeStartupTimeout overrides the MachineHealthCheck node startup timeout for machines using this config.
+	// When empty, the cluster wide node startup timeout is used.
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
 }
 
 // HardwareSelector models a simple key-value selector used in Tinkerbell provisioning.
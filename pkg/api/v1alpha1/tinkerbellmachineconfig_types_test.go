@@ -55,6 +55,20 @@ func TestTinkerbellMachineConfigValidateFail(t *testing.T) {
 			}),
 			expectedErr: "unsupported spec.osFamily (invalid OS); Please use one of the following",
 		},
+		{
+			name: "Invalid OS image URL",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.OSImageURL = "test"
+			}),
+			expectedErr: "TinkerbellMachineConfig: parsing spec.osImageURL for tinkerbellmachineconfig: parse \"test\": invalid URI for request",
+		},
+		{
+			name: "Non positive node startup timeout",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.NodeStartupTimeout = &metav1.Duration{}
+			}),
+			expectedErr: "TinkerbellMachineConfig: spec.nodeStartupTimeout must be positive: tinkerbellmachineconfig",
+		},
 		{
 			name: "Invalid hostOSConfiguration",
 			machineConfig: CreateTinkerbellMachineConfig(
@@ -19,11 +19,21 @@ manage_etc_hosts: localhost
 warnings:
   dsid_missing_source: off
 `
+
+	// netplanMTU is merged by netplan into the id0 interface written by the write-netplan action.
+	// It's written to a file that sorts after config.yaml so it's applied on top of it.
+	netplanMTU = `network:
+  version: 2
+  ethernets:
+    id0:
+      mtu: %d
+`
 )
 
 // GetDefaultActionsFromBundle constructs a set of default actions for the given osFamily using the
-// bundle as the source of action images.
-func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle, osImageOverride, tinkerbellLocalIP, tinkerbellLBIP string, osFamily OSFamily) []ActionOpt {
+// bundle as the source of action images. When mtu is greater than 0 the node network interface MTU
+// is set to it.
+func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle, osImageOverride, tinkerbellLocalIP, tinkerbellLBIP string, osFamily OSFamily, mtu int) []ActionOpt {
 	// The metadata string will have two URLs:
 	// 1. one that will be used initially for bootstrap and will point to hegel running on kind.
 	// 2. one that will be used when the workload cluster is up and will point to hegel running on
@@ -78,8 +88,11 @@ func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle
 
 		partitionPath := fmt.Sprintf(paritionPathFmt, "1")
 
+		actions = append(actions, withNetplanAction(b, partitionPath, osFamily))
+		if mtu > 0 {
+			actions = append(actions, withNetplanMTUAction(b, partitionPath, mtu))
+		}
 		actions = append(actions,
-			withNetplanAction(b, partitionPath, osFamily),
 			withDisableCloudInitNetworkCapabilities(b, partitionPath),
 			withTinkCloudInitAction(b, partitionPath, strings.Join(mu, ",")),
 			withDsCloudInitAction(b, partitionPath),
@@ -88,8 +101,11 @@ func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle
 	default:
 		partitionPath := fmt.Sprintf(paritionPathFmt, "2")
 
+		actions = append(actions, withNetplanAction(b, partitionPath, osFamily))
+		if mtu > 0 {
+			actions = append(actions, withNetplanMTUAction(b, partitionPath, mtu))
+		}
 		actions = append(actions,
-			withNetplanAction(b, partitionPath, osFamily),
 			withDisableCloudInitNetworkCapabilities(b, partitionPath),
 			withTinkCloudInitAction(b, partitionPath, strings.Join(metadataURLs, ",")),
 			withDsCloudInitAction(b, partitionPath),
@@ -160,6 +176,27 @@ func withNetplanAction(b v1alpha1.VersionsBundle, disk string, osFamily OSFamily
 	}
 }
 
+func withNetplanMTUAction(b v1alpha1.VersionsBundle, disk string, mtu int) ActionOpt {
+	return func(a *[]tinkerbell.Action) {
+		*a = append(*a, tinkerbell.Action{
+			Name:    "write-netplan-mtu",
+			Image:   b.Tinkerbell.TinkerbellStack.Actions.WriteFile.URI,
+			Timeout: 90,
+			Environment: map[string]string{
+				"CONTENTS":  fmt.Sprintf(netplanMTU, mtu),
+				"DEST_DISK": disk,
+				"DEST_PATH": "/etc/netplan/mtu.yaml",
+				"DIRMODE":   "0755",
+				"FS_TYPE":   "ext4",
+				"GID":       "0",
+				"MODE":      "0644",
+				"UID":       "0",
+			},
+			Pid: "host",
+		})
+	}
+}
+
 func withDisableCloudInitNetworkCapabilities(b v1alpha1.VersionsBundle, disk string) ActionOpt {
 	return func(a *[]tinkerbell.Action) {
 		*a = append(*a, tinkerbell.Action{
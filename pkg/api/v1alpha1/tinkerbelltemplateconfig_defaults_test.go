@@ -28,6 +28,7 @@ warnings:
 		testName        string
 		osFamily        OSFamily
 		osImageOverride string
+		mtu             int
 		clusterSpec     *Cluster
 		wantActions     []tinkerbell.Action
 	}{
@@ -349,6 +350,109 @@ warnings:
 				},
 			},
 		},
+		{
+			testName:        "Ubuntu-sda-with-mtu",
+			osFamily:        Ubuntu,
+			clusterSpec:     &Cluster{},
+			osImageOverride: "http://tinkerbell-example:8080/ubuntu-kube-v1.21.5.gz",
+			mtu:             9000,
+			wantActions: []tinkerbell.Action{
+				{
+					Name:    "stream-image",
+					Image:   "public.ecr.aws/eks-anywhere/image2disk:latest",
+					Timeout: 600,
+					Environment: map[string]string{
+						"IMG_URL":    "http://tinkerbell-example:8080/ubuntu-kube-v1.21.5.gz",
+						"DEST_DISK":  "{{ index .Hardware.Disks 0 }}",
+						"COMPRESSED": "true",
+					},
+				},
+				{
+					Name:    "write-netplan",
+					Image:   "public.ecr.aws/eks-anywhere/writefile:latest",
+					Timeout: 90,
+					Environment: map[string]string{
+						"DEST_DISK":      "{{ formatPartition ( index .Hardware.Disks 0 ) 2 }}",
+						"DEST_PATH":      "/etc/netplan/config.yaml",
+						"DIRMODE":        "0755",
+						"FS_TYPE":        "ext4",
+						"GID":            "0",
+						"MODE":           "0644",
+						"UID":            "0",
+						"STATIC_NETPLAN": "true",
+					},
+					Pid: "host",
+				},
+				{
+					Name:    "write-netplan-mtu",
+					Image:   "public.ecr.aws/eks-anywhere/writefile:latest",
+					Timeout: 90,
+					Environment: map[string]string{
+						"CONTENTS":  "network:\n  version: 2\n  ethernets:\n    id0:\n      mtu: 9000\n",
+						"DEST_DISK": "{{ formatPartition ( index .Hardware.Disks 0 ) 2 }}",
+						"DEST_PATH": "/etc/netplan/mtu.yaml",
+						"DIRMODE":   "0755",
+						"FS_TYPE":   "ext4",
+						"GID":       "0",
+						"MODE":      "0644",
+						"UID":       "0",
+					},
+					Pid: "host",
+				},
+				{
+					Name:    "disable-cloud-init-network-capabilities",
+					Image:   "public.ecr.aws/eks-anywhere/writefile:latest",
+					Timeout: 90,
+					Environment: map[string]string{
+						"CONTENTS":  "network: {config: disabled}",
+						"DEST_DISK": "{{ formatPartition ( index .Hardware.Disks 0 ) 2 }}",
+						"DEST_PATH": "/etc/cloud/cloud.cfg.d/99-disable-network-config.cfg",
+						"DIRMODE":   "0700",
+						"FS_TYPE":   "ext4",
+						"GID":       "0",
+						"MODE":      "0600",
+						"UID":       "0",
+					},
+				},
+				{
+					Name:    "add-tink-cloud-init-config",
+					Image:   "public.ecr.aws/eks-anywhere/writefile:latest",
+					Timeout: 90,
+					Environment: map[string]string{
+						"DEST_DISK": "{{ formatPartition ( index .Hardware.Disks 0 ) 2 }}",
+						"FS_TYPE":   "ext4",
+						"DEST_PATH": "/etc/cloud/cloud.cfg.d/10_tinkerbell.cfg",
+						"CONTENTS":  fmt.Sprintf(cloudInit, metadataString),
+						"UID":       "0",
+						"GID":       "0",
+						"MODE":      "0600",
+						"DIRMODE":   "0700",
+					},
+				},
+				{
+					Name:    "add-tink-cloud-init-ds-config",
+					Image:   "public.ecr.aws/eks-anywhere/writefile:latest",
+					Timeout: 90,
+					Environment: map[string]string{
+						"DEST_DISK": "{{ formatPartition ( index .Hardware.Disks 0 ) 2 }}",
+						"FS_TYPE":   "ext4",
+						"DEST_PATH": "/etc/cloud/ds-identify.cfg",
+						"CONTENTS":  "datasource: Ec2\n",
+						"UID":       "0",
+						"GID":       "0",
+						"MODE":      "0600",
+						"DIRMODE":   "0700",
+					},
+				},
+				{
+					Name:    "reboot-image",
+					Image:   "public.ecr.aws/eks-anywhere/reboot:latest",
+					Timeout: 90,
+					Pid:     "host",
+					Volumes: []string{"/worker:/worker"},
+				},
+			},
+		},
 		{
 			testName:        "Ubuntu-nvme",
 			osFamily:        Ubuntu,
@@ -541,7 +645,7 @@ warnings:
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			givenActions := []tinkerbell.Action{}
-			opts := GetDefaultActionsFromBundle(tt.clusterSpec, vBundle, tt.osImageOverride, tinkerbellLocalIp, tinkerbellLBIP, tt.osFamily)
+			opts := GetDefaultActionsFromBundle(tt.clusterSpec, vBundle, tt.osImageOverride, tinkerbellLocalIp, tinkerbellLBIP, tt.osFamily, tt.mtu)
 			for _, opt := range opts {
 				opt(&givenActions)
 			}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -16,12 +17,24 @@ type VSphereDatacenterConfigSpec struct {
 
 	Datacenter string `json:"datacenter"`
 	DisableCSI bool   `json:"disableCSI,omitempty"`
-	Network    string `json:"network"`
-	Server     string `json:"server"`
-	Thumbprint string `json:"thumbprint"`
-	Insecure   bool   `json:"insecure"`
+	// DisableDefaultStorageClass installs the vSphere CSI StorageClass without marking it as the
+	// cluster default StorageClass, for clusters that already have a default one.
+	DisableDefaultStorageClass bool   `json:"disableDefaultStorageClass,omitempty"`
+	Network                    string `json:"network"`
+	Server                     string `json:"server"`
+	Thumbprint                 string `json:"thumbprint"`
+	Insecure                   bool   `json:"insecure"`
+	// CgroupDriver pins the kubelet cgroup driver on the worker nodes. The only supported value is systemd,
+	// for templates built with cgroup v2. When empty, systemd is only pinned for Kubernetes 1.21.
+	CgroupDriver CgroupDriver `json:"cgroupDriver,omitempty"`
 }
 
+// CgroupDriver is the cgroup driver used by kubelet.
+type CgroupDriver string
+
+// CgroupDriverSystemd is the systemd cgroup driver.
+const CgroupDriverSystemd CgroupDriver = "systemd"
+
 // VSphereDatacenterConfigStatus defines the observed state of VSphereDatacenterConfig.
 type VSphereDatacenterConfigStatus struct { // Important: Run "make generate" to regenerate code after modifying this file
 	// SpecValid is set to true if vspheredatacenterconfig is validated.
@@ -101,6 +114,10 @@ func (v *VSphereDatacenterConfig) Validate() error {
 		return err
 	}
 
+	if v.Spec.CgroupDriver != "" && v.Spec.CgroupDriver != CgroupDriverSystemd {
+		return fmt.Errorf("VSphereDatacenterConfig cgroupDriver %s is not supported, only %s is supported", v.Spec.CgroupDriver, CgroupDriverSystemd)
+	}
+
 	return nil
 }
 
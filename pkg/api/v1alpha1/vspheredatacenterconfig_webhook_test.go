@@ -159,3 +159,11 @@ func TestVSphereDatacenterValidateCreateFail(t *testing.T) {
 	g := NewWithT(t)
 	g.Expect(dataCenterConfig.ValidateCreate()).To(MatchError(ContainSubstring("VSphereDatacenterConfig datacenter is not set or is empty")))
 }
+
+func TestVSphereDatacenterValidateCreateUnsupportedCgroupDriver(t *testing.T) {
+	dataCenterConfig := vsphereDatacenterConfig()
+	dataCenterConfig.Spec.CgroupDriver = "cgroupfs"
+
+	g := NewWithT(t)
+	g.Expect(dataCenterConfig.ValidateCreate()).To(MatchError(ContainSubstring("VSphereDatacenterConfig cgroupDriver cgroupfs is not supported, only systemd is supported")))
+}
@@ -22,6 +22,7 @@ package v1alpha1
 import (
 	snowapiv1beta1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 	apiv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -224,6 +225,21 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogRotation) DeepCopyInto(out *AuditLogRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogRotation.
+func (in *AuditLogRotation) DeepCopy() *AuditLogRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -749,6 +765,30 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(BundlesRef)
 		**out = **in
 	}
+	if in.CAPIObjectLabels != nil {
+		in, out := &in.CAPIObjectLabels, &out.CAPIObjectLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CoreDNSConfiguration != nil {
+		in, out := &in.CoreDNSConfiguration, &out.CoreDNSConfiguration
+		*out = new(CoreDNSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]PriorityClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIServerExtraArgs != nil {
+		in, out := &in.APIServerExtraArgs, &out.APIServerExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -825,6 +865,18 @@ func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration
 		*out = new(ControlPlaneUpgradeRolloutStrategy)
 		**out = **in
 	}
+	if in.AuditLogRotation != nil {
+		in, out := &in.AuditLogRotation, &out.AuditLogRotation
+		*out = new(AuditLogRotation)
+		**out = **in
+	}
+	if in.KubeletExtraArgs != nil {
+		in, out := &in.KubeletExtraArgs, &out.KubeletExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
@@ -868,6 +920,26 @@ func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRol
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSConfiguration) DeepCopyInto(out *CoreDNSConfiguration) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSConfiguration.
+func (in *CoreDNSConfiguration) DeepCopy() *CoreDNSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNS) DeepCopyInto(out *DNS) {
 	*out = *in
@@ -1007,6 +1079,21 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupConfiguration) DeepCopyInto(out *EtcdBackupConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupConfiguration.
+func (in *EtcdBackupConfiguration) DeepCopy() *EtcdBackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEtcdConfiguration) DeepCopyInto(out *ExternalEtcdConfiguration) {
 	*out = *in
@@ -1015,6 +1102,11 @@ func (in *ExternalEtcdConfiguration) DeepCopyInto(out *ExternalEtcdConfiguration
 		*out = new(Ref)
 		**out = **in
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(EtcdBackupConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalEtcdConfiguration.
@@ -1311,6 +1403,11 @@ func (in *HostOSConfiguration) DeepCopyInto(out *HostOSConfiguration) {
 		*out = new(BottlerocketConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RunCmd != nil {
+		in, out := &in.RunCmd, &out.RunCmd
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOSConfiguration.
@@ -1964,6 +2061,21 @@ func (in *Pods) DeepCopy() *Pods {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClass) DeepCopyInto(out *PriorityClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClass.
+func (in *PriorityClass) DeepCopy() *PriorityClass {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
 	*out = *in
@@ -2582,6 +2694,11 @@ func (in *TinkerbellMachineConfigSpec) DeepCopyInto(out *TinkerbellMachineConfig
 		*out = new(HostOSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellMachineConfigSpec.
@@ -2956,6 +3073,13 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 		*out = new(WorkerNodesUpgradeRolloutStrategy)
 		**out = **in
 	}
+	if in.KubeletExtraArgs != nil {
+		in, out := &in.KubeletExtraArgs, &out.KubeletExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
@@ -5,6 +5,9 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -24,6 +27,7 @@ type KubernetesClient interface {
 		cluster *types.Cluster,
 		namespace string,
 	) ([]byte, error)
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
 }
 
 // Installer provides the necessary behavior for installing the AWS IAM Authenticator.
@@ -66,6 +70,21 @@ func (i *Installer) CreateAndInstallAWSIAMAuthCASecret(ctx context.Context, mana
 	return nil
 }
 
+// CASecretExists checks if the AWS IAM Authenticator CA secret for the cluster identified by clusterName
+// is already present in cluster.
+func (i *Installer) CASecretExists(ctx context.Context, cluster *types.Cluster, clusterName string) (bool, error) {
+	secretName := CASecretName(clusterName)
+	err := i.k8s.GetObject(ctx, "secret", secretName, constants.EksaSystemNamespace, cluster.KubeconfigFile, &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting aws-iam-authenticator ca secret %s: %v", secretName, err)
+	}
+
+	return true, nil
+}
+
 // InstallAWSIAMAuth installs AWS IAM Authenticator deployment manifests into the workload cluster.
 // It writes a Kubeconfig to disk for kubectl access using AWS IAM Authentication.
 func (i *Installer) InstallAWSIAMAuth(
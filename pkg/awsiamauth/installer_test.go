@@ -8,7 +8,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -242,6 +246,54 @@ func TestCreateAndInstallAWSIAMAuthCASecret(t *testing.T) {
 	test.AssertContentToFile(t, string(manifest), "testdata/CreateAndInstallAWSIAMAuthCASecret-manifest.yaml")
 }
 
+func TestCASecretExists(t *testing.T) {
+	secretGR := schema.GroupResource{Resource: "secrets"}
+	cases := []struct {
+		Name       string
+		GetErr     error
+		WantExists bool
+		WantErr    string
+	}{
+		{
+			Name:       "SecretExists",
+			WantExists: true,
+		},
+		{
+			Name:   "SecretNotFound",
+			GetErr: apierrors.NewNotFound(secretGR, "test-cluster-aws-iam-authenticator-ca"),
+		},
+		{
+			Name:    "GetObjectFails",
+			GetErr:  errors.New("connection refused"),
+			WantErr: "getting aws-iam-authenticator ca secret test-cluster-aws-iam-authenticator-ca: connection refused",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctrl := gomock.NewController(t)
+			clusterID := uuid.MustParse("36db102f-9e1e-4ca4-8300-271d30b14161")
+			cluster := &types.Cluster{KubeconfigFile: "management.kubeconfig"}
+
+			k8s := NewMockKubernetesClient(ctrl)
+			k8s.EXPECT().
+				GetObject(gomock.Any(), "secret", "test-cluster-aws-iam-authenticator-ca", "eksa-system", "management.kubeconfig", &corev1.Secret{}).
+				Return(tc.GetErr)
+
+			installer := awsiamauth.NewInstaller(cryptomocks.NewMockCertificateGenerator(ctrl), clusterID, k8s, filewritermock.NewMockFileWriter(ctrl))
+
+			exists, err := installer.CASecretExists(context.Background(), cluster, "test-cluster")
+			if tc.WantErr != "" {
+				g.Expect(err).To(MatchError(tc.WantErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(exists).To(Equal(tc.WantExists))
+		})
+	}
+}
+
 func TestCreateAndInstallAWSIAMAuthCASecretErrors(t *testing.T) {
 	cases := []struct {
 		Name           string
@@ -10,6 +10,7 @@ import (
 
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // MockKubernetesClient is a mock of KubernetesClient interface.
@@ -78,3 +79,17 @@ func (mr *MockKubernetesClientMockRecorder) GetClusterCATlsCert(ctx, clusterName
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterCATlsCert", reflect.TypeOf((*MockKubernetesClient)(nil).GetClusterCATlsCert), ctx, clusterName, cluster, namespace)
 }
+
+// GetObject mocks base method.
+func (m *MockKubernetesClient) GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", ctx, resourceType, name, namespace, kubeconfig, obj)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubernetesClientMockRecorder) GetObject(ctx, resourceType, name, namespace, kubeconfig, obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubernetesClient)(nil).GetObject), ctx, resourceType, name, namespace, kubeconfig, obj)
+}
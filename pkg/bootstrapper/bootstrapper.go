@@ -28,6 +28,7 @@ type ClusterClient interface {
 	WithExtraDockerMounts() BootstrapClusterClientOption
 	WithExtraPortMappings([]int) BootstrapClusterClientOption
 	WithEnv(env map[string]string) BootstrapClusterClientOption
+	WithImage(image string) BootstrapClusterClientOption
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
 	GetKubeconfig(ctx context.Context, clusterName string) (string, error)
@@ -152,3 +153,10 @@ func WithEnv(env map[string]string) BootstrapClusterOption {
 		return b.clusterClient.WithEnv(env)
 	}
 }
+
+// WithImage sets the node image for the bootstrap cluster instead of the default one from the bundle.
+func WithImage(image string) BootstrapClusterOption {
+	return func(b *Bootstrapper) BootstrapClusterClientOption {
+		return b.clusterClient.WithImage(image)
+	}
+}
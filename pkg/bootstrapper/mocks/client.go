@@ -199,3 +199,17 @@ func (mr *MockClusterClientMockRecorder) WithExtraPortMappings(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithExtraPortMappings", reflect.TypeOf((*MockClusterClient)(nil).WithExtraPortMappings), arg0)
 }
+
+// WithImage mocks base method.
+func (m *MockClusterClient) WithImage(arg0 string) bootstrapper.BootstrapClusterClientOption {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithImage", arg0)
+	ret0, _ := ret[0].(bootstrapper.BootstrapClusterClientOption)
+	return ret0
+}
+
+// WithImage indicates an expected call of WithImage.
+func (mr *MockClusterClientMockRecorder) WithImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithImage", reflect.TypeOf((*MockClusterClient)(nil).WithImage), arg0)
+}
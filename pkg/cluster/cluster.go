@@ -1,5 +1,16 @@
 package cluster
 
+import (
+	"fmt"
+	"net"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// defaultMaxPods is the kubelet default for the max number of pods per node.
+const defaultMaxPods = 110
+
 func clusterEntry() *ConfigManagerEntry {
 	return &ConfigManagerEntry{
 		Defaulters: []Defaulter{
@@ -12,6 +23,88 @@ func clusterEntry() *ConfigManagerEntry {
 			func(c *Config) error {
 				return c.Cluster.Validate()
 			},
+			validateMaxPodsPerNode,
 		},
 	}
 }
+
+// validateMaxPodsPerNode checks that the node subnet allocated from each pods CIDR block has enough
+// IPs for the max number of pods of the machines, which is the kubelet default unless configured.
+func validateMaxPodsPerNode(c *Config) error {
+	maxPods := machinesMaxPods(c)
+	nodeMask := nodeCIDRMaskSize(c.Cluster)
+
+	for _, block := range c.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks {
+		ips, ok := podIPsPerNode(block, nodeMask)
+		if !ok {
+			continue
+		}
+
+		for _, m := range maxPods {
+			if m.value > ips {
+				return fmt.Errorf("%s %d is greater than the %d pod IPs available per node in pods CIDR block %s with node-mask %d", m.source, m.value, ips, block, nodeMask)
+			}
+		}
+	}
+
+	return nil
+}
+
+type maxPods struct {
+	value  int
+	source string
+}
+
+// machinesMaxPods returns the max pods of the machines in c. Only Bottlerocket machines can configure
+// it, the rest run with the kubelet default.
+func machinesMaxPods(c *Config) []maxPods {
+	var hostOSConfigs []*anywherev1.HostOSConfiguration
+	for _, m := range c.VSphereMachineConfigs {
+		hostOSConfigs = append(hostOSConfigs, m.Spec.HostOSConfiguration)
+	}
+	for _, m := range c.TinkerbellMachineConfigs {
+		hostOSConfigs = append(hostOSConfigs, m.Spec.HostOSConfiguration)
+	}
+
+	var configured []maxPods
+	usesDefault := len(hostOSConfigs) == 0
+	for _, h := range hostOSConfigs {
+		if h == nil || h.BottlerocketConfiguration == nil || h.BottlerocketConfiguration.Kubernetes == nil || h.BottlerocketConfiguration.Kubernetes.MaxPods == 0 {
+			usesDefault = true
+			continue
+		}
+		configured = append(configured, maxPods{
+			value:  h.BottlerocketConfiguration.Kubernetes.MaxPods,
+			source: "BottlerocketConfiguration.Kubernetes.MaxPods",
+		})
+	}
+
+	if usesDefault {
+		configured = append(configured, maxPods{value: defaultMaxPods, source: "kubelet default max pods"})
+	}
+
+	return configured
+}
+
+// podIPsPerNode returns the number of pod IPs in the subnet allocated to each node from the pods CIDR block.
+// It returns false if the block can't be parsed or the count is too large to matter.
+func podIPsPerNode(block string, nodeMask int) (int, bool) {
+	_, podCIDR, err := net.ParseCIDR(block)
+	if err != nil {
+		return 0, false
+	}
+	_, bits := podCIDR.Mask.Size()
+	hostBits := bits - nodeMask
+	if hostBits < 0 || hostBits > 30 {
+		return 0, false
+	}
+
+	return 1 << hostBits, true
+}
+
+func nodeCIDRMaskSize(cluster *anywherev1.Cluster) int {
+	if nodes := cluster.Spec.ClusterNetwork.Nodes; nodes != nil && nodes.CIDRMaskSize != nil {
+		return *nodes.CIDRMaskSize
+	}
+	return constants.DefaultNodeCidrMaskSize
+}
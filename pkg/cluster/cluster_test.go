@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestValidateMaxPodsPerNodeEveryPodsCIDRBlock(t *testing.T) {
+	g := NewWithT(t)
+	c := &Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				ClusterNetwork: anywherev1.ClusterNetwork{
+					Pods: anywherev1.Pods{
+						CidrBlocks: []string{"fd00::/48", "192.168.0.0/16"},
+					},
+					Nodes: &anywherev1.Nodes{
+						CIDRMaskSize: ptr.Int(26),
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(validateMaxPodsPerNode(c)).To(
+		MatchError("kubelet default max pods 110 is greater than the 64 pod IPs available per node in pods CIDR block 192.168.0.0/16 with node-mask 26"),
+	)
+}
+
+func TestValidateMaxPodsPerNodeConfiguredMaxPodsFit(t *testing.T) {
+	g := NewWithT(t)
+	c := &Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				ClusterNetwork: anywherev1.ClusterNetwork{
+					Pods: anywherev1.Pods{
+						CidrBlocks: []string{"192.168.0.0/16"},
+					},
+					Nodes: &anywherev1.Nodes{
+						CIDRMaskSize: ptr.Int(26),
+					},
+				},
+			},
+		},
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"br": {
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					HostOSConfiguration: &anywherev1.HostOSConfiguration{
+						BottlerocketConfiguration: &anywherev1.BottlerocketConfiguration{
+							Kubernetes: &v1beta1.BottlerocketKubernetesSettings{
+								MaxPods: 60,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(validateMaxPodsPerNode(c)).To(Succeed())
+}
@@ -164,7 +164,7 @@ func getVersionsBundleForKubernetesVersion(kubernetesVersion v1alpha1.Kubernetes
 }
 
 func GetOIDCForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch OIDCFetch) (*v1alpha1.OIDCConfig, error) {
-	if fetch == nil || cluster.Spec.IdentityProviderRefs == nil {
+	if fetch == nil || len(cluster.Spec.IdentityProviderRefs) == 0 {
 		return nil, nil
 	}
 
@@ -181,7 +181,7 @@ func GetOIDCForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch OID
 }
 
 func GetAWSIamConfigForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch AWSIamConfigFetch) (*v1alpha1.AWSIamConfig, error) {
-	if fetch == nil || cluster.Spec.IdentityProviderRefs == nil {
+	if fetch == nil || len(cluster.Spec.IdentityProviderRefs) == 0 {
 		return nil, nil
 	}
 
@@ -5,9 +5,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -75,3 +77,52 @@ func TestValidateConfigDifferentNamespace(t *testing.T) {
 		MatchError(ContainSubstring("VSphereDatacenterConfig and Cluster objects must have the same namespace specified")),
 	)
 }
+
+func TestValidateConfigMaxPodsFitNodeCIDR(t *testing.T) {
+	g := NewWithT(t)
+	c := bottlerocketConfigWithMaxPods(t, 200)
+
+	g.Expect(cluster.ValidateConfig(c)).To(Succeed())
+}
+
+func TestValidateConfigMaxPodsExceedNodeCIDR(t *testing.T) {
+	g := NewWithT(t)
+	c := bottlerocketConfigWithMaxPods(t, 110)
+	c.Cluster.Spec.ClusterNetwork.Nodes = &anywherev1.Nodes{
+		CIDRMaskSize: ptr.Int(26),
+	}
+
+	g.Expect(cluster.ValidateConfig(c)).To(
+		MatchError(ContainSubstring("BottlerocketConfiguration.Kubernetes.MaxPods 110 is greater than the 64 pod IPs available per node in pods CIDR block 192.168.0.0/16 with node-mask 26")),
+	)
+}
+
+func TestValidateConfigDefaultMaxPodsExceedNodeCIDR(t *testing.T) {
+	g := NewWithT(t)
+	c := clusterConfigFromFile(t, "testdata/cluster_1_19.yaml")
+	c.Cluster.Spec.ClusterNetwork.Nodes = &anywherev1.Nodes{
+		CIDRMaskSize: ptr.Int(26),
+	}
+
+	g.Expect(cluster.ValidateConfig(c)).To(
+		MatchError(ContainSubstring("kubelet default max pods 110 is greater than the 64 pod IPs available per node in pods CIDR block 192.168.0.0/16 with node-mask 26")),
+	)
+}
+
+func bottlerocketConfigWithMaxPods(t *testing.T, maxPods int) *cluster.Config {
+	t.Helper()
+	c := clusterConfigFromFile(t, "testdata/cluster_1_19.yaml")
+	for _, m := range c.VSphereMachineConfigs {
+		m.Spec.OSFamily = anywherev1.Bottlerocket
+		m.Spec.Users[0].Name = "ec2-user"
+		m.Spec.HostOSConfiguration = &anywherev1.HostOSConfiguration{
+			BottlerocketConfiguration: &anywherev1.BottlerocketConfiguration{
+				Kubernetes: &v1beta1.BottlerocketKubernetesSettings{
+					MaxPods: maxPods,
+				},
+			},
+		}
+	}
+
+	return c
+}
@@ -81,6 +81,30 @@ func ResolvConfExtraArgs(resolvConf *v1alpha1.ResolvConf) ExtraArgs {
 	return args
 }
 
+// AuditLogRotationExtraArgs returns the kube-apiserver audit log rotation args, using the defaults if rotation is not configured.
+func AuditLogRotationExtraArgs(rotation *v1alpha1.AuditLogRotation) ExtraArgs {
+	if rotation == nil {
+		rotation = &v1alpha1.AuditLogRotation{MaxAge: 30, MaxBackup: 10, MaxSize: 512}
+	}
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("audit-log-maxage", strconv.Itoa(rotation.MaxAge))
+	args.AddIfNotEmpty("audit-log-maxbackup", strconv.Itoa(rotation.MaxBackup))
+	args.AddIfNotEmpty("audit-log-maxsize", strconv.Itoa(rotation.MaxSize))
+	return args
+}
+
+// ClusterAPIServerExtraArgs returns the user provided kube-apiserver flags from the cluster spec.
+func ClusterAPIServerExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	if cluster == nil {
+		return args
+	}
+	for k, v := range cluster.Spec.APIServerExtraArgs {
+		args.AddIfNotEmpty(k, v)
+	}
+	return args
+}
+
 // We don't need to add these once the Kubernetes components default to using the secure cipher suites.
 func SecureTlsCipherSuitesExtraArgs() ExtraArgs {
 	args := ExtraArgs{}
@@ -102,6 +126,24 @@ func ControlPlaneNodeLabelsExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) Ext
 	return nodeLabelsExtraArgs(cpc.Labels)
 }
 
+// ControlPlaneKubeletExtraArgs returns the user provided kubelet flags for the control plane nodes.
+func ControlPlaneKubeletExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return userKubeletExtraArgs(cpc.KubeletExtraArgs)
+}
+
+// WorkerKubeletExtraArgs returns the user provided kubelet flags for the nodes of a worker node group.
+func WorkerKubeletExtraArgs(wnc v1alpha1.WorkerNodeGroupConfiguration) ExtraArgs {
+	return userKubeletExtraArgs(wnc.KubeletExtraArgs)
+}
+
+func userKubeletExtraArgs(kubeletExtraArgs map[string]string) ExtraArgs {
+	args := ExtraArgs{}
+	for k, v := range kubeletExtraArgs {
+		args.AddIfNotEmpty(k, v)
+	}
+	return args
+}
+
 // CgroupDriverExtraArgs args added for kube versions below 1.24.
 func CgroupDriverCgroupfsExtraArgs() ExtraArgs {
 	args := ExtraArgs{}
@@ -137,6 +179,17 @@ func (e ExtraArgs) Append(args ExtraArgs) ExtraArgs {
 	return e
 }
 
+// AppendIfNotPresent adds the args whose keys are not already set, so existing values always win.
+func (e ExtraArgs) AppendIfNotPresent(args ExtraArgs) ExtraArgs {
+	for k, v := range args {
+		if _, ok := e[k]; !ok {
+			e[k] = v
+		}
+	}
+
+	return e
+}
+
 func (e ExtraArgs) ToPartialYaml() templater.PartialYaml {
 	p := templater.PartialYaml{}
 	for k, v := range e {
@@ -519,6 +519,41 @@ func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 	}
 }
 
+func TestAuditLogRotationExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		rotation *v1alpha1.AuditLogRotation
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "no rotation config",
+			rotation: nil,
+			want: clusterapi.ExtraArgs{
+				"audit-log-maxage":    "30",
+				"audit-log-maxbackup": "10",
+				"audit-log-maxsize":   "512",
+			},
+		},
+		{
+			testName: "with rotation config",
+			rotation: &v1alpha1.AuditLogRotation{MaxAge: 7, MaxBackup: 3, MaxSize: 100},
+			want: clusterapi.ExtraArgs{
+				"audit-log-maxage":    "7",
+				"audit-log-maxbackup": "3",
+				"audit-log-maxsize":   "100",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.AuditLogRotationExtraArgs(tt.rotation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuditLogRotationExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFeatureGatesExtraArgs(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -554,3 +589,66 @@ func TestFeatureGatesExtraArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendIfNotPresent(t *testing.T) {
+	e := clusterapi.ExtraArgs{
+		"key1": "value1",
+	}
+	a := clusterapi.ExtraArgs{
+		"key1": "override",
+		"key2": "value2",
+	}
+	want := clusterapi.ExtraArgs{
+		"key1": "value1",
+		"key2": "value2",
+	}
+	if got := e.AppendIfNotPresent(a); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtraArgs.AppendIfNotPresent() = %v, want %v", got, want)
+	}
+}
+
+func TestClusterAPIServerExtraArgs(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			APIServerExtraArgs: map[string]string{
+				"audit-log-maxbackup": "5",
+				"request-timeout":     "",
+			},
+		},
+	}
+	want := clusterapi.ExtraArgs{
+		"audit-log-maxbackup": "5",
+	}
+	if got := clusterapi.ClusterAPIServerExtraArgs(cluster); !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterAPIServerExtraArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestControlPlaneKubeletExtraArgs(t *testing.T) {
+	cpc := v1alpha1.ControlPlaneConfiguration{
+		KubeletExtraArgs: map[string]string{
+			"system-reserved": "cpu=500m,memory=1Gi",
+			"kube-reserved":   "",
+		},
+	}
+	want := clusterapi.ExtraArgs{
+		"system-reserved": "cpu=500m,memory=1Gi",
+	}
+	if got := clusterapi.ControlPlaneKubeletExtraArgs(cpc); !reflect.DeepEqual(got, want) {
+		t.Errorf("ControlPlaneKubeletExtraArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestWorkerKubeletExtraArgs(t *testing.T) {
+	wnc := v1alpha1.WorkerNodeGroupConfiguration{
+		KubeletExtraArgs: map[string]string{
+			"kube-reserved": "cpu=1,memory=2Gi",
+		},
+	}
+	want := clusterapi.ExtraArgs{
+		"kube-reserved": "cpu=1,memory=2Gi",
+	}
+	if got := clusterapi.WorkerKubeletExtraArgs(wnc); !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkerKubeletExtraArgs() = %v, want %v", got, want)
+	}
+}
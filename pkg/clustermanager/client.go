@@ -3,6 +3,8 @@ package clustermanager
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -12,6 +14,7 @@ import (
 // KubernetesClient allows to interact with the k8s api server.
 type KubernetesClient interface {
 	Apply(ctx context.Context, kubeconfigPath string, obj runtime.Object) error
+	ApplyWithFieldManager(ctx context.Context, kubeconfigPath string, obj runtime.Object, fieldManager string) error
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
@@ -28,13 +31,51 @@ func NewClient(clusterClient ClusterClient) *client {
 	return &client{ClusterClient: clusterClient}
 }
 
+// maxConcurrentDeploymentWaits bounds how many deployments waitForDeployments waits on at the same time.
+const maxConcurrentDeploymentWaits = 10
+
+type namespacedDeployment struct {
+	namespace, name string
+}
+
+// waitForDeployments waits concurrently for all the deployments to be available. All deployments are
+// waited on even if some fail, and the error returned is the one of the first failed deployment in
+// namespace and name order.
 func (c *client) waitForDeployments(ctx context.Context, deploymentsByNamespace map[string][]string, cluster *types.Cluster, timeout string) error {
-	for namespace, deployments := range deploymentsByNamespace {
-		for _, deployment := range deployments {
-			err := c.WaitForDeployment(ctx, cluster, timeout, "Available", deployment, namespace)
-			if err != nil {
-				return fmt.Errorf("waiting for %s in namespace %s: %v", deployment, namespace, err)
+	deployments := make([]namespacedDeployment, 0, len(deploymentsByNamespace))
+	for namespace, names := range deploymentsByNamespace {
+		for _, name := range names {
+			deployments = append(deployments, namespacedDeployment{namespace: namespace, name: name})
+		}
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].namespace != deployments[j].namespace {
+			return deployments[i].namespace < deployments[j].namespace
+		}
+		return deployments[i].name < deployments[j].name
+	})
+
+	errs := make([]error, len(deployments))
+	limit := make(chan struct{}, maxConcurrentDeploymentWaits)
+	var wg sync.WaitGroup
+	for i, d := range deployments {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, d namespacedDeployment) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			if err := c.WaitForDeployment(ctx, cluster, timeout, "Available", d.name, d.namespace); err != nil {
+				errs[i] = fmt.Errorf("waiting for %s in namespace %s: %v", d.name, d.namespace, err)
 			}
+		}(i, d)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -7,16 +7,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
+	"path"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/integer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
@@ -36,14 +53,22 @@ import (
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
-	maxRetries             = 30
-	defaultBackOffPeriod   = 5 * time.Second
-	machineBackoff         = 1 * time.Second
-	defaultMachinesMinWait = 30 * time.Minute
+	maxRetries              = 30
+	defaultBackOffPeriod    = 5 * time.Second
+	machineBackoff          = 1 * time.Second
+	defaultMachinesMinWait  = 30 * time.Minute
+	capiMachineResourceType = "machines.cluster.x-k8s.io"
+
+	// cliFieldManager is the field manager used by the CLI for server-side applies.
+	cliFieldManager = "eks-a-cli"
+
+	defaultFileWriteRetries       = 3
+	defaultFileWriteBackOffPeriod = 1 * time.Second
 
 	// DefaultMaxWaitPerMachine is the default max time the cluster manager will wait per a machine.
 	DefaultMaxWaitPerMachine = 10 * time.Minute
@@ -66,7 +91,17 @@ const (
 	DefaultNodeStartupTimeout = 10 * time.Minute
 )
 
-var eksaClusterResourceType = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+// ErrEmptyWorkloadKubeconfig is returned when the workload cluster kubeconfig is retrieved without any content.
+var ErrEmptyWorkloadKubeconfig = errors.New("empty workload cluster kubeconfig")
+
+// ErrDiagnosticsCollectionTimedOut is returned when saving the cluster logs doesn't finish within the
+// configured diagnostics collection timeout. The support bundle might be incomplete.
+var ErrDiagnosticsCollectionTimedOut = errors.New("timed out collecting support bundle")
+
+var (
+	eksaClusterResourceType  = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	capiProviderResourceType = fmt.Sprintf("providers.%s", clusterctlv1.GroupVersion.Group)
+)
 
 type ClusterManager struct {
 	eksaComponents     EKSAComponents
@@ -87,13 +122,82 @@ type ClusterManager struct {
 	nodeStartupTimeout               time.Duration
 	clusterWaitTimeout               time.Duration
 	deploymentWaitTimeout            time.Duration
+	workerGroupRolloutPause          time.Duration
+	createClusterTimeout             time.Duration
+
+	// workerDeploymentWaitTimeout, when set, overrides deploymentWaitTimeout for the deployment waits
+	// UpgradeCluster runs after rolling out the worker nodes.
+	workerDeploymentWaitTimeout time.Duration
+
+	// machineHealthConfirmations is the number of consecutive healthy observations a machine
+	// needs before it's counted as ready while waiting for nodes.
+	machineHealthConfirmations int
+
+	// requiredMachineConditions are the conditions, on top of the default checks, that must be
+	// "True" for a machine to count as ready when waiting for machines after create and upgrade.
+	requiredMachineConditions []string
+
+	// workerMaxUnhealthy overrides, by worker node group name, the maxUnhealthy rendered
+	// in the worker MachineHealthChecks.
+	workerMaxUnhealthy map[string]intstr.IntOrString
+
+	// controlPlaneRolloutTimeout, when set, replaces the separate control plane not ready and ready waits
+	// during upgrade with a single combined wait with this budget.
+	controlPlaneRolloutTimeout time.Duration
+
+	// upgradeScope selects the parts of the cluster UpgradeCluster upgrades.
+	upgradeScope UpgradeScope
+
+	// upgradeProgressHook, if set, is called at the start of each UpgradeCluster phase.
+	upgradeProgressHook func(phase UpgradePhase)
+
+	// validateClusterConnection enables checking the clusters' kubeconfig and connectivity
+	// before running create, upgrade and move operations.
+	validateClusterConnection bool
+
+	// capiManifestsFileName is the file the applied CAPI manifests are persisted to after create.
+	// They aren't persisted when empty.
+	capiManifestsFileName string
+
+	// fileWriteRetries and fileWriteBackOffPeriod configure how many times and how often the workload
+	// kubeconfig and cluster yaml writes are retried on transient filesystem errors.
+	fileWriteRetries       int
+	fileWriteBackOffPeriod time.Duration
+
+	// postUpgradeSmokeTest, if set, is run against the workload cluster kubeconfig once UpgradeCluster
+	// has finished all its waits.
+	postUpgradeSmokeTest func(ctx context.Context, kubeconfig string) error
+
+	// currentSpecCache, if set, caches the Bundles and EKS-D Releases fetched to build the current
+	// cluster spec from one EKSAClusterSpecChanged call to the next.
+	currentSpecCache *currentSpecCache
+
+	// forceDelete skips the check that a management cluster being deleted doesn't manage any workload clusters.
+	forceDelete bool
+
+	// serverSideApply makes CreateEKSAResources use server-side apply instead of client-side apply.
+	serverSideApply bool
+
+	// chunkedApplyMaxBytes, when set, makes CreateWorkloadCluster apply the CAPI spec in chunks of
+	// whole documents of at most this size instead of in a single call.
+	chunkedApplyMaxBytes int
+
+	// diagnosticsCollectionTimeout, when set, bounds how long SaveLogsManagementCluster and
+	// SaveLogsWorkloadCluster wait for the support bundle to be collected and analyzed.
+	diagnosticsCollectionTimeout time.Duration
+
+	// skipPostCreateMachineWait makes RunPostCreateWorkloadCluster return without waiting for
+	// the cluster machines to be ready.
+	skipPostCreateMachineWait bool
+
+	sleep func(context.Context, time.Duration) error
 }
 
 type ClusterClient interface {
 	KubernetesClient
 	BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
 	MoveManagement(ctx context.Context, org, target *types.Cluster) error
-	WaitForClusterReady(ctx context.Context, cluster *types.Cluster, timeout string, clusterName string) error
+	WaitForClusterCondition(ctx context.Context, cluster *types.Cluster, timeout, condition, clusterName string) error
 	WaitForControlPlaneAvailable(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
 	WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
 	WaitForControlPlaneNotReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
@@ -114,8 +218,10 @@ type ClusterClient interface {
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
 	SaveLog(ctx context.Context, cluster *types.Cluster, deployment *types.Deployment, fileName string, writer filewriter.FileWriter) error
 	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	GetMachinesForMachineDeployment(ctx context.Context, cluster *types.Cluster, machineDeploymentName string) ([]types.Machine, error)
 	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
+	GetEksaClusterStatus(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.ClusterStatus, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	UpdateEnvironmentVariablesInNamespace(ctx context.Context, resourceType, resourceName string, envMap map[string]string, cluster *types.Cluster, namespace string) error
 	GetEksaVSphereMachineConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
@@ -135,6 +241,9 @@ type ClusterClient interface {
 	GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
 	GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error)
 	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	ListObjectsInAllNamespaces(ctx context.Context, resourceType, kubeconfig string, list kubernetes.ObjectList) error
+	Version(ctx context.Context, cluster *types.Cluster) (*executables.VersionResponse, error)
+	ServerSideApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte, namespace, fieldManager string) error
 }
 
 type Networking interface {
@@ -144,6 +253,7 @@ type Networking interface {
 }
 
 type AwsIamAuth interface {
+	CASecretExists(ctx context.Context, managementCluster *types.Cluster, workloadClusterName string) (bool, error)
 	CreateAndInstallAWSIAMAuthCASecret(ctx context.Context, managementCluster *types.Cluster, workloadClusterName string) error
 	InstallAWSIAMAuth(ctx context.Context, management, workload *types.Cluster, spec *cluster.Spec) error
 	UpgradeAWSIAMAuth(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
@@ -174,6 +284,7 @@ func New(clusterClient *RetrierClient, networking Networking, writer filewriter.
 		machineMaxWait:                   DefaultMaxWaitPerMachine,
 		machineBackoff:                   machineBackoff,
 		machinesMinWait:                  defaultMachinesMinWait,
+		machineHealthConfirmations:       1,
 		awsIamAuth:                       awsIamAuth,
 		controlPlaneWaitTimeout:          DefaultControlPlaneWait,
 		controlPlaneWaitAfterMoveTimeout: DefaultControlPlaneWaitAfterMove,
@@ -182,6 +293,10 @@ func New(clusterClient *RetrierClient, networking Networking, writer filewriter.
 		nodeStartupTimeout:               DefaultNodeStartupTimeout,
 		clusterWaitTimeout:               DefaultClusterWait,
 		deploymentWaitTimeout:            DefaultDeploymentWait,
+		fileWriteRetries:                 defaultFileWriteRetries,
+		fileWriteBackOffPeriod:           defaultFileWriteBackOffPeriod,
+		upgradeScope:                     ScopeAll,
+		sleep:                            sleepWithContext,
 	}
 
 	for _, o := range opts {
@@ -203,6 +318,47 @@ func WithExternalEtcdWaitTimeout(timeout time.Duration) ClusterManagerOpt {
 	}
 }
 
+// WithControlPlaneRolloutTimeout makes UpgradeCluster wait for the control plane rollout to complete,
+// observing both the not ready and ready transitions, within a single timeout budget.
+func WithControlPlaneRolloutTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.controlPlaneRolloutTimeout = timeout
+	}
+}
+
+// WithCAPIDeploymentWaitTimeout sets how long the cluster manager waits for each CAPI, etcdadm and provider
+// deployment to be Available after installing or upgrading CAPI.
+func WithCAPIDeploymentWaitTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.deploymentWaitTimeout = timeout
+	}
+}
+
+// WithWorkerDeploymentWaitTimeout sets how long UpgradeCluster waits for each CAPI, etcdadm and provider
+// deployment to be Available once the worker nodes have been rolled out. It defaults to the
+// WithCAPIDeploymentWaitTimeout timeout.
+func WithWorkerDeploymentWaitTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.workerDeploymentWaitTimeout = timeout
+	}
+}
+
+// WithMoveClusterWaitTimeout sets how long MoveCAPI waits for all the CAPI clusters in the source
+// cluster to be ready before moving them. It defaults to DefaultClusterWait.
+func WithMoveClusterWaitTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.clusterWaitTimeout = timeout
+	}
+}
+
+// WithMoveControlPlaneWaitTimeout sets how long MoveCAPI waits for the control planes to be ready
+// in the target cluster after the move. It defaults to DefaultControlPlaneWaitAfterMove.
+func WithMoveControlPlaneWaitTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.controlPlaneWaitAfterMoveTimeout = timeout
+	}
+}
+
 func WithMachineBackoff(machineBackoff time.Duration) ClusterManagerOpt {
 	return func(c *ClusterManager) {
 		c.machineBackoff = machineBackoff
@@ -221,6 +377,26 @@ func WithMachineMinWait(machineMinWait time.Duration) ClusterManagerOpt {
 	}
 }
 
+// WithMachineHealthConfirmations requires a machine to be observed healthy n consecutive times
+// before it's counted as ready, so machines that flap between healthy and unhealthy don't satisfy
+// the wait. Values lower than 1 are ignored.
+func WithMachineHealthConfirmations(n int) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		if n > 0 {
+			c.machineHealthConfirmations = n
+		}
+	}
+}
+
+// WithRequiredMachineConditions extends the set of machine conditions that must be "True" before a machine
+// counts as ready when waiting for machines in RunPostCreateWorkloadCluster and UpgradeCluster.
+// By default only NodeHealthy is checked.
+func WithRequiredMachineConditions(conditionTypes ...string) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.requiredMachineConditions = append(c.requiredMachineConditions, conditionTypes...)
+	}
+}
+
 // WithUnhealthyMachineTimeout sets the timeout of an unhealthy machine health check.
 func WithUnhealthyMachineTimeout(timeout time.Duration) ClusterManagerOpt {
 	return func(c *ClusterManager) {
@@ -235,6 +411,136 @@ func WithNodeStartupTimeout(timeout time.Duration) ClusterManagerOpt {
 	}
 }
 
+// WithWorkerMaxUnhealthy overrides the maxUnhealthy of the MachineHealthCheck for a worker node group.
+// Worker node groups without an override use the default.
+func WithWorkerMaxUnhealthy(workerNodeGroupName string, maxUnhealthy intstr.IntOrString) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		if c.workerMaxUnhealthy == nil {
+			c.workerMaxUnhealthy = map[string]intstr.IntOrString{}
+		}
+		c.workerMaxUnhealthy[workerNodeGroupName] = maxUnhealthy
+	}
+}
+
+// WithUpgradeProgressHook sets a hook called at the start of each phase of UpgradeCluster.
+// Phases that don't apply to the cluster being upgraded are skipped.
+func WithUpgradeProgressHook(hook func(phase UpgradePhase)) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.upgradeProgressHook = hook
+	}
+}
+
+// WithUpgradeScope limits UpgradeCluster to the control plane or the workers, so they can be upgraded
+// in separate runs. The control plane must be upgraded to the new Kubernetes version before the workers.
+// By default the whole cluster is upgraded.
+func WithUpgradeScope(scope UpgradeScope) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.upgradeScope = scope
+	}
+}
+
+// WithWorkerGroupRolloutPause sets a delay between the completion of a worker node group rollout
+// and the start of the next one during an upgrade. When unset, all worker node groups are rolled out at once.
+func WithWorkerGroupRolloutPause(pause time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.workerGroupRolloutPause = pause
+	}
+}
+
+// WithPostUpgradeSmokeTest sets a smoke test that UpgradeCluster runs against the workload cluster
+// kubeconfig after all the upgrade waits pass. If the smoke test fails, the upgrade fails.
+func WithPostUpgradeSmokeTest(smokeTest func(ctx context.Context, kubeconfig string) error) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.postUpgradeSmokeTest = smokeTest
+	}
+}
+
+// WithCreateClusterTimeout sets an overall deadline for CreateWorkloadCluster, on top of the
+// timeouts of each individual step. When unset, the operation has no overall deadline.
+func WithCreateClusterTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.createClusterTimeout = timeout
+	}
+}
+
+// WithCAPIManifestsFile persists the CAPI manifests applied by CreateWorkloadCluster to fileName,
+// so there's a record of the fully expanded control plane and worker objects.
+func WithCAPIManifestsFile(fileName string) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.capiManifestsFileName = fileName
+	}
+}
+
+// WithClusterConnectionValidation makes CreateWorkloadCluster, UpgradeCluster and MoveCAPI check the
+// kubeconfig and connectivity of the clusters they operate on before doing any work.
+func WithClusterConnectionValidation() ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.validateClusterConnection = true
+	}
+}
+
+// WithFileWriteRetries sets how many times the workload kubeconfig and cluster yaml writes are attempted
+// when they fail with a transient filesystem error, and the wait between attempts.
+// Permanent errors, like permission errors, are never retried.
+func WithFileWriteRetries(maxRetries int, backOffPeriod time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.fileWriteRetries = maxRetries
+		c.fileWriteBackOffPeriod = backOffPeriod
+	}
+}
+
+// WithCurrentClusterSpecCache makes the ClusterManager reuse the Bundles and EKS-D Release it fetches to build
+// the current cluster spec, so EKSAClusterSpecChanged and UpgradeCluster don't retrieve them twice during the
+// same upgrade. The cache is cleared at the start of each EKSAClusterSpecChanged call, so every upgrade starts
+// with fresh data.
+func WithCurrentClusterSpecCache() ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.currentSpecCache = newCurrentSpecCache()
+	}
+}
+
+// WithForceDelete allows DeleteCluster to delete a management cluster that still manages workload clusters,
+// leaving them without a management cluster.
+func WithForceDelete() ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.forceDelete = true
+	}
+}
+
+// WithServerSideApply makes CreateEKSAResources apply the EKS-A objects and Bundles with server-side apply,
+// which doesn't store the last-applied-configuration annotation and so isn't limited by its size.
+func WithServerSideApply() ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.serverSideApply = true
+	}
+}
+
+// WithChunkedApply makes CreateWorkloadCluster apply the generated CAPI spec in several calls, each with
+// as many consecutive documents as fit in maxBytes, to stay under the API server request size limits.
+// Documents bigger than maxBytes are applied on their own. By default the spec is applied in a single call.
+func WithChunkedApply(maxBytes int) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.chunkedApplyMaxBytes = maxBytes
+	}
+}
+
+// WithSkipPostCreateMachineWait makes RunPostCreateWorkloadCluster skip waiting for the control plane and
+// worker machines to have a node and be healthy, for callers that gate on the cluster readiness themselves.
+func WithSkipPostCreateMachineWait() ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.skipPostCreateMachineWait = true
+	}
+}
+
+// WithDiagnosticsCollectionTimeout bounds how long SaveLogsManagementCluster and SaveLogsWorkloadCluster
+// wait for the support bundle. When the timeout is hit they return ErrDiagnosticsCollectionTimedOut and
+// the bundle might be incomplete. When unset, collection has no deadline.
+func WithDiagnosticsCollectionTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.diagnosticsCollectionTimeout = timeout
+	}
+}
+
 func WithRetrier(retrier *retrier.Retrier) ClusterManagerOpt {
 	return func(c *ClusterManager) {
 		c.clusterClient.retrier = retrier
@@ -257,20 +563,143 @@ func WithNoTimeouts() ClusterManagerOpt {
 		c.nodeStartupTimeout = maxTime
 		c.clusterWaitTimeout = maxTime
 		c.deploymentWaitTimeout = maxTime
+		c.workerDeploymentWaitTimeout = maxTime
 	}
 }
 
 // BackupCAPI takes backup of management cluster's resources during uograde process.
 func (c *ClusterManager) BackupCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
-	err := c.clusterClient.BackupManagement(ctx, cluster, managementStatePath)
+	_, err := c.BackupCAPIToDir(ctx, cluster, managementStatePath)
+	return err
+}
+
+// BackupCAPIToDir backs up the CAPI resources of cluster to dir and returns the paths of the files written.
+// Relative dirs are placed under the cluster folder. Files already in dir are only returned if the backup
+// rewrote them. If the backup fails partway, the files written before the failure are returned along
+// with the error.
+func (c *ClusterManager) BackupCAPIToDir(ctx context.Context, cluster *types.Cluster, dir string) (manifestPaths []string, err error) {
+	backupDir := executables.ManagementBackupDir(cluster, dir)
+	existing, err := fileModTimes(backupDir)
 	if err != nil {
-		return fmt.Errorf("backing up CAPI resources of management cluster before moving to bootstrap cluster: %v", err)
+		return nil, fmt.Errorf("listing CAPI backup files in %s: %v", backupDir, err)
 	}
 
-	return nil
+	backupErr := c.clusterClient.BackupManagement(ctx, cluster, dir)
+
+	current, err := fileModTimes(backupDir)
+	if err != nil {
+		return nil, fmt.Errorf("listing CAPI backup files in %s: %v", backupDir, err)
+	}
+
+	for path, modTime := range current {
+		if previous, ok := existing[path]; !ok || !previous.Equal(modTime) {
+			manifestPaths = append(manifestPaths, path)
+		}
+	}
+	sort.Strings(manifestPaths)
+
+	if backupErr != nil {
+		return manifestPaths, fmt.Errorf("backing up CAPI resources of management cluster before moving to bootstrap cluster: %v", backupErr)
+	}
+
+	return manifestPaths, nil
+}
+
+// fileModTimes returns the modification time of all regular files under dir keyed by path.
+// A missing dir has no files.
+func fileModTimes(dir string) (map[string]time.Time, error) {
+	files := map[string]time.Time{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = info.ModTime()
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// MoveCAPIWithBackup backs up the CAPI resources of from to backupDir, like BackupCAPI, and then moves them
+// to to, like MoveCAPI. The move isn't attempted if the backup fails. The backup is skipped if backupDir
+// is empty, for clusters where it isn't worth the I/O, like ephemeral test clusters.
+func (c *ClusterManager) MoveCAPIWithBackup(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, backupDir string) error {
+	if backupDir != "" {
+		if err := c.BackupCAPI(ctx, from, backupDir); err != nil {
+			return err
+		}
+	} else {
+		logger.V(3).Info("Skipping CAPI backup before move")
+	}
+
+	return c.MoveCAPI(ctx, from, to, clusterName, clusterSpec)
 }
 
 func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
+	if err := c.validateClusterConnections(ctx, from, to); err != nil {
+		return err
+	}
+
+	if err := c.moveManagement(ctx, from, to, clusterName, checkers...); err != nil {
+		return err
+	}
+
+	return c.waitForCAPIAfterMove(ctx, to, clusterName, clusterSpec, checkers...)
+}
+
+// MoveCAPIResumable moves the CAPI resources like MoveCAPI but can be rerun after a partial failure.
+// If the target cluster already owns the CAPI cluster clusterName, the move already happened so the
+// pre-move checks on from and the move itself are skipped and only the post-move waits run.
+func (c *ClusterManager) MoveCAPIResumable(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
+	if err := c.validateClusterConnections(ctx, from, to); err != nil {
+		return err
+	}
+
+	moved, err := c.ownsCAPICluster(ctx, to, clusterName)
+	if err != nil {
+		return fmt.Errorf("checking if CAPI management was already moved to target: %v", err)
+	}
+
+	if moved {
+		logger.V(3).Info("CAPI management already moved to target, skipping move", "cluster", clusterName)
+	} else if err := c.moveManagement(ctx, from, to, clusterName, checkers...); err != nil {
+		return err
+	}
+
+	return c.waitForCAPIAfterMove(ctx, to, clusterName, clusterSpec, checkers...)
+}
+
+func (c *ClusterManager) ownsCAPICluster(ctx context.Context, cluster *types.Cluster, clusterName string) (bool, error) {
+	clusters, err := c.clusterClient.GetClusters(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	for _, clu := range clusters {
+		if clu.Metadata.Name == clusterName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// moveManagement waits for the machines and clusters in from to be ready and then moves the CAPI
+// resources to to.
+func (c *ClusterManager) moveManagement(ctx context.Context, from, to *types.Cluster, clusterName string, checkers ...types.NodeReadyChecker) error {
 	logger.V(3).Info("Waiting for management machines to be ready before move")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
 	if err := c.waitForNodesReady(ctx, from, clusterName, labels, checkers...); err != nil {
@@ -278,17 +707,21 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 	}
 
 	logger.V(3).Info("Waiting for all clusters to be ready before move")
-	if err := c.waitForAllClustersReady(ctx, from, c.clusterWaitTimeout.String()); err != nil {
+	if err := c.waitForAllClustersReady(ctx, from, c.clusterWaitTimeout); err != nil {
 		return err
 	}
 
-	err := c.clusterClient.MoveManagement(ctx, from, to)
-	if err != nil {
+	if err := c.clusterClient.MoveManagement(ctx, from, to); err != nil {
 		return fmt.Errorf("moving CAPI management from source to target: %v", err)
 	}
 
+	return nil
+}
+
+// waitForCAPIAfterMove waits for the control planes, replicas and machines in to be ready after a move.
+func (c *ClusterManager) waitForCAPIAfterMove(ctx context.Context, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
 	logger.V(3).Info("Waiting for control planes to be ready after move")
-	err = c.waitForAllControlPlanes(ctx, to, c.controlPlaneWaitAfterMoveTimeout)
+	err := c.waitForAllControlPlanes(ctx, to, c.controlPlaneWaitAfterMoveTimeout)
 	if err != nil {
 		return err
 	}
@@ -306,6 +739,7 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 	}
 
 	logger.V(3).Info("Waiting for machines to be ready after move")
+	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
 	if err = c.waitForNodesReady(ctx, to, clusterName, labels, checkers...); err != nil {
 		return err
 	}
@@ -313,88 +747,319 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 	return nil
 }
 
-func (c *ClusterManager) writeCAPISpecFile(clusterName string, content []byte) error {
+func (c *ClusterManager) writeCAPISpecFile(clusterName string, content []byte) (string, error) {
 	fileName := fmt.Sprintf("%s-eks-a-cluster.yaml", clusterName)
-	if _, err := c.writer.Write(fileName, content); err != nil {
-		return fmt.Errorf("writing capi spec file: %v", err)
+	path, err := c.writeFileWithRetries(fileName, content)
+	if err != nil {
+		return "", fmt.Errorf("writing capi spec file: %v", err)
 	}
-	return nil
+	return path, nil
+}
+
+// writeFileWithRetries writes content to fileName, retrying the write while it fails with a transient
+// filesystem error, up to the configured file write retries.
+func (c *ClusterManager) writeFileWithRetries(fileName string, content []byte, opts ...filewriter.FileOptionsFunc) (string, error) {
+	policy := func(totalRetries int, err error) (bool, time.Duration) {
+		return totalRetries < c.fileWriteRetries && isTransientFileError(err), c.fileWriteBackOffPeriod
+	}
+	r := retrier.New(time.Duration(math.MaxInt64), retrier.WithRetryPolicy(policy))
+
+	var path string
+	err := r.Retry(func() error {
+		var err error
+		path, err = c.writer.Write(fileName, content, opts...)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// isTransientFileError reports whether err is a filesystem error that might go away on its own,
+// as opposed to permanent ones like permission errors.
+func isTransientFileError(err error) bool {
+	return errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR) || errors.Is(err, syscall.EBUSY)
+}
+
+// WorkloadClusterArtifacts holds the paths of the files written while creating a workload cluster.
+type WorkloadClusterArtifacts struct {
+	// KubeconfigFile is the path of the workload cluster kubeconfig.
+	KubeconfigFile string
+	// ClusterYAMLFile is the path of the CAPI spec applied to create the workload cluster.
+	ClusterYAMLFile string
 }
 
 // CreateWorkloadCluster creates a workload cluster in the provider that the customer has specified.
 // It applied the kubernetes manifest file on the management cluster, waits for the control plane to be ready,
 // and then generates the kubeconfig for the cluster.
 // It returns a struct of type Cluster containing the name and the kubeconfig of the cluster.
+// If an overall timeout was configured with WithCreateClusterTimeout, the operation is aborted
+// when it expires and the returned error names the step that was in progress.
 func (c *ClusterManager) CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error) {
-	clusterName := clusterSpec.Cluster.Name
+	workloadCluster, _, err := c.CreateWorkloadClusterWithArtifacts(ctx, managementCluster, clusterSpec, provider)
+	return workloadCluster, err
+}
 
-	workloadCluster := &types.Cluster{
-		Name:               clusterName,
-		ExistingManagement: managementCluster.ExistingManagement,
+// CreateWorkloadClusterWithArtifacts creates a workload cluster like CreateWorkloadCluster and also returns
+// the paths of the cluster yaml and kubeconfig files written during the creation, as reported by the writer.
+func (c *ClusterManager) CreateWorkloadClusterWithArtifacts(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, *WorkloadClusterArtifacts, error) {
+	if err := c.ValidateClusterName(clusterSpec); err != nil {
+		return nil, nil, err
 	}
 
-	if err := c.applyProviderManifests(ctx, clusterSpec, managementCluster, provider); err != nil {
-		return nil, err
+	if err := c.validateClusterConnections(ctx, managementCluster); err != nil {
+		return nil, nil, err
 	}
 
-	if err := c.waitUntilControlPlaneAvailable(ctx, clusterSpec, managementCluster); err != nil {
-		return nil, err
+	if managementCluster.ExistingManagement {
+		if err := c.validateControlPlaneEndpointNotInUse(ctx, managementCluster, clusterSpec); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	logger.V(3).Info("Waiting for workload kubeconfig generation", "cluster", clusterName)
+	var step string
+	artifacts := &WorkloadClusterArtifacts{}
+	if c.createClusterTimeout == 0 {
+		workloadCluster, err := c.createWorkloadCluster(ctx, managementCluster, clusterSpec, provider, &step, artifacts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return workloadCluster, artifacts, nil
+	}
 
-	// Use a buffer to cache the kubeconfig.
-	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(ctx, c.createClusterTimeout)
+	defer cancel()
 
-	if err := c.getWorkloadClusterKubeconfig(ctx, clusterName, managementCluster, &buf); err != nil {
-		return nil, fmt.Errorf("waiting for workload kubeconfig: %v", err)
+	workloadCluster, err := c.createWorkloadCluster(ctx, managementCluster, clusterSpec, provider, &step, artifacts)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, nil, fmt.Errorf("creating workload cluster timed out after %s while %s: %v", c.createClusterTimeout, step, err)
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
-	rawKubeconfig := buf.Bytes()
+	return workloadCluster, artifacts, nil
+}
 
-	// The Docker provider wants to update the kubeconfig to patch the server address before
-	// we write it to disk. This is to ensure we can communicate with the cluster even when
-	// hosted inside a Docker Desktop VM.
-	if err := provider.UpdateKubeConfig(&rawKubeconfig, clusterName); err != nil {
-		return nil, err
+// ValidateClusterConnection checks the cluster kubeconfig is a readable kubeconfig file and that the
+// cluster API server responds, so a stale kubeconfig fails with a clear error before any work is done.
+func (c *ClusterManager) ValidateClusterConnection(ctx context.Context, cluster *types.Cluster) error {
+	if err := kubeconfig.ValidateFilename(cluster.KubeconfigFile); err != nil {
+		return fmt.Errorf("invalid kubeconfig for cluster %s: %v", cluster.Name, err)
 	}
 
-	kubeconfigFile, err := c.writer.Write(
-		kubeconfig.FormatWorkloadClusterKubeconfigFilename(clusterName),
-		rawKubeconfig,
-		filewriter.PersistentFile,
-		filewriter.Permission0600,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("writing workload kubeconfig: %v", err)
+	if _, err := c.clusterClient.Version(ctx, cluster); err != nil {
+		return fmt.Errorf("cluster %s is not reachable with kubeconfig %s: %v", cluster.Name, cluster.KubeconfigFile, err)
 	}
-	workloadCluster.KubeconfigFile = kubeconfigFile
 
-	return workloadCluster, nil
+	return nil
 }
 
-func (c *ClusterManager) waitUntilControlPlaneAvailable(
-	ctx context.Context,
-	clusterSpec *cluster.Spec,
-	managementCluster *types.Cluster,
-) error {
-	// If we have external etcd we need to wait for that first as control plane nodes can't
-	// come up without it.
-	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		logger.V(3).Info("Waiting for external etcd to be ready", "cluster", clusterSpec.Cluster.Name)
-		err := c.clusterClient.WaitForManagedExternalEtcdReady(
-			ctx,
-			managementCluster,
-			c.externalEtcdWaitTimeout.String(),
-			clusterSpec.Cluster.Name,
-		)
-		if err != nil {
-			return fmt.Errorf("waiting for external etcd for workload cluster to be ready: %v", err)
-		}
-		logger.V(3).Info("External etcd is ready")
+func (c *ClusterManager) validateClusterConnections(ctx context.Context, clusters ...*types.Cluster) error {
+	if !c.validateClusterConnection {
+		return nil
 	}
 
-	logger.V(3).Info("Waiting for control plane to be available")
+	validated := map[string]struct{}{}
+	for _, cluster := range clusters {
+		if cluster == nil {
+			continue
+		}
+		if _, ok := validated[cluster.KubeconfigFile]; ok {
+			continue
+		}
+		if err := c.ValidateClusterConnection(ctx, cluster); err != nil {
+			return err
+		}
+		validated[cluster.KubeconfigFile] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateControlPlaneEndpointNotInUse checks no other cluster in the management cluster uses the same
+// control plane endpoint host as the new cluster.
+func (c *ClusterManager) validateControlPlaneEndpointNotInUse(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	endpoint := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.Host == "" {
+		return nil
+	}
+
+	clusters := &v1alpha1.ClusterList{}
+	if err := c.clusterClient.ListObjectsInAllNamespaces(ctx, eksaClusterResourceType, managementCluster.KubeconfigFile, clusters); err != nil {
+		return fmt.Errorf("listing clusters to validate control plane endpoint: %v", err)
+	}
+
+	for _, existing := range clusters.Items {
+		if existing.Name == clusterSpec.Cluster.Name && existing.Namespace == clusterSpec.Cluster.Namespace {
+			continue
+		}
+		if e := existing.Spec.ControlPlaneConfiguration.Endpoint; e != nil && e.Host == endpoint.Host {
+			return fmt.Errorf("control plane endpoint %s is already used by cluster %s/%s", endpoint.Host, existing.Namespace, existing.Name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBundleConsistency checks the versions bundle and the EKS-D release it references in clusterSpec
+// correspond to the cluster Kubernetes version, so all the nodes run the same Kubernetes version.
+func (c *ClusterManager) ValidateBundleConsistency(clusterSpec *cluster.Spec) error {
+	kubeVersion := string(clusterSpec.Cluster.Spec.KubernetesVersion)
+	if clusterSpec.VersionsBundle == nil || clusterSpec.VersionsBundle.VersionsBundle == nil {
+		return fmt.Errorf("no versions bundle found for Kubernetes version %s", kubeVersion)
+	}
+
+	bundle := clusterSpec.VersionsBundle
+	if bundle.KubeVersion != kubeVersion {
+		return fmt.Errorf("versions bundle is for Kubernetes version %s, but cluster Kubernetes version is %s", bundle.KubeVersion, kubeVersion)
+	}
+
+	eksd := bundle.EksD
+	if channel := strings.ReplaceAll(kubeVersion, ".", "-"); eksd.ReleaseChannel != channel {
+		return fmt.Errorf("EKS-D release %s is from channel %s, expected channel %s for Kubernetes version %s", eksd.Name, eksd.ReleaseChannel, channel, kubeVersion)
+	}
+
+	if !strings.HasPrefix(eksd.KubeVersion, "v"+kubeVersion+".") {
+		return fmt.Errorf("EKS-D release %s has Kubernetes version %s, which doesn't match cluster Kubernetes version %s", eksd.Name, eksd.KubeVersion, kubeVersion)
+	}
+
+	return nil
+}
+
+// ValidateClusterName checks the cluster name is a valid DNS-1123 subdomain and short enough for the
+// names derived from it (KubeadmControlPlane, etcd cluster and MachineDeployments) to stay within Kubernetes limits.
+// CAPI uses these names as label values, so each of them is limited to 63 characters.
+func (c *ClusterManager) ValidateClusterName(clusterSpec *cluster.Spec) error {
+	clusterName := clusterSpec.Cluster.Name
+	if errs := validation.IsDNS1123Subdomain(clusterName); len(errs) > 0 {
+		return fmt.Errorf("cluster name %s is invalid: %s", clusterName, strings.Join(errs, ", "))
+	}
+
+	derivedNames := map[string]string{
+		"cluster":             clusterName,
+		"KubeadmControlPlane": clusterapi.KubeadmControlPlaneName(clusterSpec.Cluster),
+	}
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		derivedNames["etcd cluster"] = clusterapi.EtcdClusterName(clusterName)
+	}
+	for _, workerNodeGroupConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		name := clusterapi.MachineDeploymentName(clusterSpec.Cluster, workerNodeGroupConfig)
+		derivedNames[fmt.Sprintf("MachineDeployment %s", workerNodeGroupConfig.Name)] = name
+	}
+
+	kinds := make([]string, 0, len(derivedNames))
+	for kind := range derivedNames {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		name := derivedNames[kind]
+		if len(name) > validation.DNS1123LabelMaxLength {
+			return fmt.Errorf("cluster name %s is too long, derived %s name %s must be no more than %d characters",
+				clusterName, kind, name, validation.DNS1123LabelMaxLength)
+		}
+	}
+
+	return nil
+}
+
+// startStep records the step in progress in current and returns an error if ctx is already done.
+func startStep(ctx context.Context, current *string, step string) error {
+	*current = step
+	return ctx.Err()
+}
+
+func (c *ClusterManager) createWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider, step *string, artifacts *WorkloadClusterArtifacts) (*types.Cluster, error) {
+	clusterName := clusterSpec.Cluster.Name
+
+	workloadCluster := &types.Cluster{
+		Name:               clusterName,
+		ExistingManagement: managementCluster.ExistingManagement,
+	}
+
+	if err := startStep(ctx, step, "applying provider manifests"); err != nil {
+		return nil, err
+	}
+	clusterYAMLFile, err := c.applyProviderManifests(ctx, clusterSpec, managementCluster, provider)
+	if err != nil {
+		return nil, err
+	}
+	artifacts.ClusterYAMLFile = clusterYAMLFile
+
+	if err := startStep(ctx, step, "waiting for control plane to be available"); err != nil {
+		return nil, err
+	}
+	if err := c.waitUntilControlPlaneAvailable(ctx, clusterSpec, managementCluster); err != nil {
+		return nil, err
+	}
+
+	if err := startStep(ctx, step, "waiting for workload kubeconfig"); err != nil {
+		return nil, err
+	}
+	logger.V(3).Info("Waiting for workload kubeconfig generation", "cluster", clusterName)
+
+	// Use a buffer to cache the kubeconfig.
+	var buf bytes.Buffer
+
+	if err := c.getWorkloadClusterKubeconfig(ctx, clusterName, managementCluster, &buf); err != nil {
+		return nil, fmt.Errorf("waiting for workload kubeconfig: %v", err)
+	}
+
+	rawKubeconfig := buf.Bytes()
+	if len(rawKubeconfig) == 0 {
+		return nil, fmt.Errorf("%w for cluster %s", ErrEmptyWorkloadKubeconfig, clusterName)
+	}
+
+	if err := startStep(ctx, step, "writing workload kubeconfig"); err != nil {
+		return nil, err
+	}
+	// The Docker provider wants to update the kubeconfig to patch the server address before
+	// we write it to disk. This is to ensure we can communicate with the cluster even when
+	// hosted inside a Docker Desktop VM.
+	if err := provider.UpdateKubeConfig(&rawKubeconfig, clusterName); err != nil {
+		return nil, err
+	}
+
+	kubeconfigFile, err := c.writeFileWithRetries(
+		kubeconfig.FormatWorkloadClusterKubeconfigFilename(clusterName),
+		rawKubeconfig,
+		filewriter.PersistentFile,
+		filewriter.Permission0600,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("writing workload kubeconfig: %v", err)
+	}
+	workloadCluster.KubeconfigFile = kubeconfigFile
+	artifacts.KubeconfigFile = kubeconfigFile
+
+	return workloadCluster, nil
+}
+
+func (c *ClusterManager) waitUntilControlPlaneAvailable(
+	ctx context.Context,
+	clusterSpec *cluster.Spec,
+	managementCluster *types.Cluster,
+) error {
+	// If we have external etcd we need to wait for that first as control plane nodes can't
+	// come up without it.
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		logger.V(3).Info("Waiting for external etcd to be ready", "cluster", clusterSpec.Cluster.Name)
+		err := c.clusterClient.WaitForManagedExternalEtcdReady(
+			ctx,
+			managementCluster,
+			c.externalEtcdWaitTimeout.String(),
+			clusterSpec.Cluster.Name,
+		)
+		if err != nil {
+			return fmt.Errorf("waiting for external etcd for workload cluster to be ready: %v", err)
+		}
+		logger.V(3).Info("External etcd is ready")
+	}
+
+	logger.V(3).Info("Waiting for control plane to be available")
 	err := c.clusterClient.WaitForControlPlaneAvailable(
 		ctx,
 		managementCluster,
@@ -413,26 +1078,109 @@ func (c *ClusterManager) applyProviderManifests(
 	spec *cluster.Spec,
 	management *types.Cluster,
 	provider providers.Provider,
-) error {
+) (string, error) {
 	cpContent, mdContent, err := provider.GenerateCAPISpecForCreate(ctx, management, spec)
 	if err != nil {
-		return fmt.Errorf("generating capi spec: %v", err)
+		return "", fmt.Errorf("generating capi spec: %v", err)
 	}
 
 	content := templater.AppendYamlResources(cpContent, mdContent)
 
-	if err = c.writeCAPISpecFile(spec.Cluster.Name, content); err != nil {
-		return err
+	clusterYAMLFile, err := c.writeCAPISpecFile(spec.Cluster.Name, content)
+	if err != nil {
+		return "", err
 	}
 
-	err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, management, content, constants.EksaSystemNamespace)
-	if err != nil {
-		return fmt.Errorf("applying capi spec: %v", err)
+	if err = c.applyCAPISpec(ctx, management, content); err != nil {
+		return "", fmt.Errorf("applying capi spec: %v", err)
+	}
+
+	if c.capiManifestsFileName != "" {
+		if _, err = c.writer.Write(c.capiManifestsFileName, content, filewriter.PersistentFile); err != nil {
+			return "", fmt.Errorf("writing applied capi manifests file: %v", err)
+		}
+	}
+
+	return clusterYAMLFile, nil
+}
+
+// applyCAPISpec applies content to the management cluster, in chunks if chunked apply is enabled.
+func (c *ClusterManager) applyCAPISpec(ctx context.Context, management *types.Cluster, content []byte) error {
+	if c.chunkedApplyMaxBytes <= 0 {
+		return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, management, content, constants.EksaSystemNamespace)
+	}
+
+	chunks := chunkYAMLDocuments(content, c.chunkedApplyMaxBytes)
+	for i, chunk := range chunks {
+		logger.V(4).Info("Applying capi spec chunk", "chunk", i+1, "total", len(chunks), "bytes", len(chunk))
+		if err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, management, chunk, constants.EksaSystemNamespace); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+var yamlDocumentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// chunkYAMLDocuments splits a multi-document yaml on its "---" separators and groups consecutive documents,
+// preserving their order, in chunks of at most maxBytes. A document bigger than maxBytes gets its own chunk.
+func chunkYAMLDocuments(content []byte, maxBytes int) [][]byte {
+	separator := []byte("\n---\n")
+
+	var chunks [][]byte
+	var current []byte
+	for _, doc := range yamlDocumentSeparator.Split(string(content), -1) {
+		doc = strings.Trim(doc, "\n")
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		if len(current) > 0 && len(current)+len(separator)+len(doc) > maxBytes {
+			chunks = append(chunks, current)
+			current = nil
+		}
+
+		if len(current) > 0 {
+			current = append(current, separator...)
+		}
+		current = append(current, doc...)
+	}
+
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// GetWorkloadClusterKubeconfig retrieves the kubeconfig of the workload cluster clusterName from the management
+// cluster, retrying on errors, for callers that need to fetch it again after create, like after a certificate
+// rotation. Empty kubeconfigs are retried as well and, if still empty, ErrEmptyWorkloadKubeconfig is returned.
+func (c *ClusterManager) GetWorkloadClusterKubeconfig(ctx context.Context, clusterName string, managementCluster *types.Cluster) ([]byte, error) {
+	var kubeconfig []byte
+	err := c.retrier.RetryWithContext(ctx,
+		func() error {
+			var err error
+			kubeconfig, err = c.clusterClient.GetWorkloadKubeconfig(ctx, clusterName, managementCluster)
+			if err != nil {
+				return fmt.Errorf("getting workload kubeconfig: %v", err)
+			}
+
+			if len(kubeconfig) == 0 {
+				return fmt.Errorf("%w for cluster %s", ErrEmptyWorkloadKubeconfig, clusterName)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubeconfig, nil
+}
+
 func (c *ClusterManager) getWorkloadClusterKubeconfig(ctx context.Context, clusterName string, managementCluster *types.Cluster, w io.Writer) error {
 	kubeconfig, err := c.clusterClient.GetWorkloadKubeconfig(ctx, clusterName, managementCluster)
 	if err != nil {
@@ -446,100 +1194,594 @@ func (c *ClusterManager) getWorkloadClusterKubeconfig(ctx context.Context, clust
 	return nil
 }
 
+// RunPostCreateWorkloadCluster waits for the control plane and worker machines of the workload cluster to be ready
+// and then applies the cluster spec PriorityClasses and the external etcd backup CronJob, if configured.
+// The expected number of nodes is recomputed from the KubeadmControlPlane and MachineDeployments on every call
+// and the objects are applied idempotently, so it can be safely called again after a transient timeout.
 func (c *ClusterManager) RunPostCreateWorkloadCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
-	logger.V(3).Info("Waiting for controlplane and worker machines to be ready")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
-	return c.waitForNodesReady(ctx, managementCluster, workloadCluster.Name, labels, types.WithNodeRef())
+	if c.skipPostCreateMachineWait {
+		totalNodes, err := c.getNodesCount(ctx, managementCluster, workloadCluster.Name, labels)
+		if err != nil {
+			return fmt.Errorf("getting the total count of nodes: %v", err)
+		}
+		logger.V(3).Info("Skipping wait for controlplane and worker machines to be ready", "total", totalNodes)
+	} else {
+		logger.V(3).Info("Waiting for controlplane and worker machines to be ready")
+		if err := c.waitForNodesReady(ctx, managementCluster, workloadCluster.Name, labels, c.machineReadyCheckers(types.WithNodeRef())...); err != nil {
+			return err
+		}
+	}
+
+	if err := c.InstallPriorityClasses(ctx, clusterSpec, workloadCluster); err != nil {
+		return err
+	}
+
+	return c.InstallEtcdBackup(ctx, clusterSpec, managementCluster, workloadCluster)
+}
+
+// InstallPriorityClasses applies the PriorityClasses from the cluster spec to the workload cluster.
+// It's a no-op when the spec doesn't define any.
+func (c *ClusterManager) InstallPriorityClasses(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	if len(clusterSpec.Cluster.Spec.PriorityClasses) == 0 {
+		return nil
+	}
+
+	logger.V(3).Info("Applying priority classes")
+	priorityClasses, err := templater.ObjectsToYaml(priorityClassObjects(clusterSpec)...)
+	if err != nil {
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, priorityClasses); err != nil {
+		return fmt.Errorf("applying priority classes: %v", err)
+	}
+	return nil
+}
+
+func priorityClassObjects(clusterSpec *cluster.Spec) []runtime.Object {
+	objs := make([]runtime.Object, 0, len(clusterSpec.Cluster.Spec.PriorityClasses))
+	for _, pc := range clusterSpec.Cluster.Spec.PriorityClasses {
+		priorityClass := &schedulingv1.PriorityClass{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: schedulingv1.SchemeGroupVersion.String(),
+				Kind:       "PriorityClass",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pc.Name,
+			},
+			Value:         pc.Value,
+			GlobalDefault: pc.GlobalDefault,
+			Description:   pc.Description,
+		}
+		if pc.PreemptionPolicy != "" {
+			policy := corev1.PreemptionPolicy(pc.PreemptionPolicy)
+			priorityClass.PreemptionPolicy = &policy
+		}
+		objs = append(objs, priorityClass)
+	}
+	return objs
+}
+
+// InstallEtcdBackup applies a CronJob to the workload cluster that periodically snapshots the external etcd
+// cluster to the configured destination on the control plane nodes.
+// The snapshot is taken from the first external etcd endpoint in the cluster KubeadmControlPlane, so the CronJob
+// is reapplied after every upgrade to follow the etcd machines being replaced.
+// It's a no-op when the cluster doesn't use external etcd or doesn't configure a backup.
+func (c *ClusterManager) InstallEtcdBackup(ctx context.Context, clusterSpec *cluster.Spec, managementCluster, workloadCluster *types.Cluster) error {
+	etcdConfig := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration
+	if etcdConfig == nil || etcdConfig.Backup == nil {
+		return nil
+	}
+
+	kcp, err := c.clusterClient.GetKubeadmControlPlane(ctx, managementCluster, clusterSpec.Cluster.Name, executables.WithCluster(managementCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return fmt.Errorf("getting external etcd endpoints: %v", err)
+	}
+	endpoints := externalEtcdEndpoints(kcp)
+	if len(endpoints) == 0 {
+		return fmt.Errorf("kubeadm control plane %s has no external etcd endpoints", kcp.Name)
+	}
+
+	logger.V(3).Info("Applying etcd backup CronJob")
+	cronJob, err := templater.ObjectsToYaml(etcdBackupCronJob(clusterSpec, endpoints[0]))
+	if err != nil {
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, cronJob); err != nil {
+		return fmt.Errorf("applying etcd backup cronjob: %v", err)
+	}
+	return nil
+}
+
+func externalEtcdEndpoints(kcp *controlplanev1.KubeadmControlPlane) []string {
+	clusterConfig := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfig == nil || clusterConfig.Etcd.External == nil {
+		return nil
+	}
+	return clusterConfig.Etcd.External.Endpoints
+}
+
+const (
+	etcdBackupName          = "eksa-etcd-backup"
+	etcdBackupMountPath     = "/backup"
+	etcdBackupSnapshotPath  = "/snapshot"
+	etcdBackupPKIMountPath  = "/pki"
+	etcdBackupSnapshotFile  = etcdBackupSnapshotPath + "/etcd-snapshot.db"
+	etcdBackupCAFile        = etcdBackupPKIMountPath + "/etcd/ca.crt"
+	etcdBackupClientKeyFile = etcdBackupPKIMountPath + "/apiserver-etcd-client.key"
+)
+
+// etcdBackupScript moves the snapshot taken by the etcdctl init container to the backup destination,
+// naming it after the time it was taken.
+var etcdBackupScript = fmt.Sprintf(`set -e
+mv %[1]s %[2]s/etcd-snapshot-$(date +%%Y%%m%%d%%H%%M%%S).db
+`, etcdBackupSnapshotFile, etcdBackupMountPath)
+
+// etcdClientCertPaths returns the host directory with the kubeadm certificates on the control plane nodes
+// and the name of the apiserver etcd client certificate in it, which are different for Bottlerocket.
+func etcdClientCertPaths(clusterSpec *cluster.Spec) (pkiDir, clientCert string) {
+	if controlPlaneOSFamily(clusterSpec) == v1alpha1.Bottlerocket {
+		return "/var/lib/kubeadm/pki", "server-etcd-client.crt"
+	}
+	return "/etc/kubernetes/pki", "apiserver-etcd-client.crt"
+}
+
+func controlPlaneOSFamily(clusterSpec *cluster.Spec) v1alpha1.OSFamily {
+	machineGroupRef := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef
+	if machineGroupRef == nil {
+		return ""
+	}
+
+	type osFamilyGetter interface{ OSFamily() v1alpha1.OSFamily }
+	var machineConfig osFamilyGetter
+	switch machineGroupRef.Kind {
+	case v1alpha1.VSphereMachineConfigKind:
+		machineConfig = clusterSpec.VSphereMachineConfigs[machineGroupRef.Name]
+	case v1alpha1.CloudStackMachineConfigKind:
+		machineConfig = clusterSpec.CloudStackMachineConfigs[machineGroupRef.Name]
+	case v1alpha1.SnowMachineConfigKind:
+		machineConfig = clusterSpec.SnowMachineConfigs[machineGroupRef.Name]
+	case v1alpha1.NutanixMachineConfigKind:
+		machineConfig = clusterSpec.NutanixMachineConfigs[machineGroupRef.Name]
+	case v1alpha1.TinkerbellMachineConfigKind:
+		machineConfig = clusterSpec.TinkerbellMachineConfigs[machineGroupRef.Name]
+	}
+	if machineConfig == nil || reflect.ValueOf(machineConfig).IsNil() {
+		return ""
+	}
+	return machineConfig.OSFamily()
+}
+
+// etcdBackupCronJob builds the CronJob taking the etcd snapshots. The EKS-D etcd image doesn't ship a shell,
+// so etcdctl runs as the entrypoint of an init container and the EKS-A tools image copies the snapshot over.
+func etcdBackupCronJob(clusterSpec *cluster.Spec, endpoint string) runtime.Object {
+	backup := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Backup
+	pkiDir, clientCert := etcdClientCertPaths(clusterSpec)
+	hostPathDirectoryOrCreate := corev1.HostPathDirectoryOrCreate
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdBackupName,
+			Namespace: constants.KubeSystemNamespace,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          backup.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							HostNetwork:   true,
+							RestartPolicy: corev1.RestartPolicyOnFailure,
+							NodeSelector:  map[string]string{"node-role.kubernetes.io/control-plane": ""},
+							Tolerations: []corev1.Toleration{
+								{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+								{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule},
+							},
+							InitContainers: []corev1.Container{
+								{
+									Name:  "etcd-snapshot",
+									Image: clusterSpec.VersionsBundle.KubeDistro.EtcdImage.VersionedImage(),
+									Command: []string{
+										"etcdctl",
+										"--endpoints=" + endpoint,
+										"--cacert=" + etcdBackupCAFile,
+										"--cert=" + path.Join(etcdBackupPKIMountPath, clientCert),
+										"--key=" + etcdBackupClientKeyFile,
+										"snapshot", "save", etcdBackupSnapshotFile,
+									},
+									Env: []corev1.EnvVar{{Name: "ETCDCTL_API", Value: "3"}},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "pki", MountPath: etcdBackupPKIMountPath, ReadOnly: true},
+										{Name: "snapshot", MountPath: etcdBackupSnapshotPath},
+									},
+								},
+							},
+							Containers: []corev1.Container{
+								{
+									Name:    "etcd-backup",
+									Image:   clusterSpec.VersionsBundle.Eksa.CliTools.VersionedImage(),
+									Command: []string{"/bin/sh", "-c", etcdBackupScript},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "snapshot", MountPath: etcdBackupSnapshotPath},
+										{Name: "backup", MountPath: etcdBackupMountPath},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								{
+									Name:         "pki",
+									VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: pkiDir}},
+								},
+								{
+									Name:         "snapshot",
+									VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+								},
+								{
+									Name: "backup",
+									VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+										Path: backup.Destination,
+										Type: &hostPathDirectoryOrCreate,
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Steps recorded in a DeleteSummary, in the order they run.
+const (
+	DeleteStepPauseReconcile     = "PauseEKSAReconcile"
+	DeleteStepEKSACluster        = "DeleteEKSACluster"
+	DeleteStepGitOpsConfig       = "DeleteGitOpsConfig"
+	DeleteStepOIDCConfig         = "DeleteOIDCConfig"
+	DeleteStepAWSIamConfig       = "DeleteAWSIamConfig"
+	DeleteStepProviderResources  = "DeleteProviderResources"
+	DeleteStepCAPICluster        = "DeleteCAPICluster"
+	DeleteStepPostDeleteValidate = "PostClusterDeleteValidate"
+)
+
+// DeleteSummary describes what DeleteClusterWithSummary deleted.
+type DeleteSummary struct {
+	// Cluster is the name of the deleted cluster.
+	Cluster string
+	// Managed is true when the cluster was managed by another cluster, in which
+	// case its EKS-A objects were deleted from the management cluster.
+	Managed bool
+	// Steps are the delete steps that ran, in order. If a step fails, it's the last one.
+	Steps []DeleteStep
+}
+
+// DeleteStep is a single step of a cluster delete and its outcome.
+type DeleteStep struct {
+	Name string
+	// Object is the name of the object the step deleted, if any.
+	Object string
+	Err    error
+}
+
+func (s *DeleteSummary) run(name, object string, step func() error) error {
+	err := step()
+	s.Steps = append(s.Steps, DeleteStep{Name: name, Object: object, Err: err})
+	return err
 }
 
 func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error {
-	if clusterSpec.Cluster.IsManaged() {
-		if err := c.deleteEKSAObjects(ctx, managementCluster, clusterToDelete, provider, clusterSpec); err != nil {
-			return err
+	_, err := c.DeleteClusterWithSummary(ctx, managementCluster, clusterToDelete, provider, clusterSpec)
+	return err
+}
+
+// DeleteClusterWithSummary deletes a cluster like DeleteCluster and returns a summary of each step
+// that ran and its outcome. The summary is returned even if the delete fails.
+func (c *ClusterManager) DeleteClusterWithSummary(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) (*DeleteSummary, error) {
+	summary := &DeleteSummary{
+		Cluster: clusterSpec.Cluster.Name,
+		Managed: clusterSpec.Cluster.IsManaged(),
+	}
+
+	if clusterSpec.Cluster.IsSelfManaged() && !c.forceDelete {
+		if err := c.validateNoManagedWorkloadClusters(ctx, clusterToDelete, clusterSpec); err != nil {
+			return summary, err
+		}
+	}
+
+	if summary.Managed {
+		if err := c.deleteEKSAObjects(ctx, managementCluster, clusterToDelete, provider, clusterSpec, summary); err != nil {
+			return summary, err
 		}
 	}
 
 	logger.V(1).Info("Deleting CAPI cluster", "name", clusterToDelete.Name)
-	if err := c.clusterClient.DeleteCluster(ctx, managementCluster, clusterToDelete); err != nil {
-		return err
+	if err := summary.run(DeleteStepCAPICluster, clusterToDelete.Name, func() error {
+		return c.clusterClient.DeleteCluster(ctx, managementCluster, clusterToDelete)
+	}); err != nil {
+		return summary, err
+	}
+
+	err := summary.run(DeleteStepPostDeleteValidate, "", func() error {
+		return provider.PostClusterDeleteValidate(ctx, managementCluster)
+	})
+	return summary, err
+}
+
+// validateNoManagedWorkloadClusters checks the management cluster in clusterSpec doesn't manage any
+// workload clusters, since deleting it would leave them orphaned.
+func (c *ClusterManager) validateNoManagedWorkloadClusters(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	clusters := &v1alpha1.ClusterList{}
+	if err := c.clusterClient.ListObjectsInAllNamespaces(ctx, eksaClusterResourceType, managementCluster.KubeconfigFile, clusters); err != nil {
+		return fmt.Errorf("listing clusters managed by %s: %v", clusterSpec.Cluster.Name, err)
+	}
+
+	var workloadClusters []string
+	for _, w := range clusters.Items {
+		if w.Name == clusterSpec.Cluster.Name || w.ManagedBy() != clusterSpec.Cluster.Name {
+			continue
+		}
+		workloadClusters = append(workloadClusters, fmt.Sprintf("%s/%s", w.Namespace, w.Name))
+	}
+
+	if len(workloadClusters) > 0 {
+		return fmt.Errorf("management cluster %s still manages workload clusters %s, delete them first or force the deletion", clusterSpec.Cluster.Name, strings.Join(workloadClusters, ", "))
 	}
 
-	return provider.PostClusterDeleteValidate(ctx, managementCluster)
+	return nil
 }
 
-func (c *ClusterManager) deleteEKSAObjects(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error {
+func (c *ClusterManager) deleteEKSAObjects(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec, summary *DeleteSummary) error {
 	log := logger.Get()
 	log.V(1).Info("Deleting EKS-A objects", "cluster", clusterSpec.Cluster.Name)
 
 	log.V(2).Info("Pausing EKS-A reconciliation", "cluster", clusterSpec.Cluster.Name)
-	if err := c.PauseEKSAControllerReconcile(ctx, clusterToDelete, clusterSpec, provider); err != nil {
+	if err := summary.run(DeleteStepPauseReconcile, clusterSpec.Cluster.Name, func() error {
+		return c.PauseEKSAControllerReconcile(ctx, clusterToDelete, clusterSpec, provider)
+	}); err != nil {
 		return err
 	}
 
 	log.V(2).Info("Deleting EKS-A Cluster", "name", clusterSpec.Cluster.Name)
-	if err := c.clusterClient.DeleteEKSACluster(ctx, managementCluster, clusterSpec.Cluster.Name, clusterSpec.Cluster.Namespace); err != nil {
+	if err := summary.run(DeleteStepEKSACluster, clusterSpec.Cluster.Name, func() error {
+		return c.clusterClient.DeleteEKSACluster(ctx, managementCluster, clusterSpec.Cluster.Name, clusterSpec.Cluster.Namespace)
+	}); err != nil {
 		return err
 	}
 
 	if clusterSpec.GitOpsConfig != nil {
 		log.V(2).Info("Deleting GitOpsConfig", "name", clusterSpec.GitOpsConfig.Name)
-		if err := c.clusterClient.DeleteGitOpsConfig(ctx, managementCluster, clusterSpec.GitOpsConfig.Name, clusterSpec.GitOpsConfig.Namespace); err != nil {
+		if err := summary.run(DeleteStepGitOpsConfig, clusterSpec.GitOpsConfig.Name, func() error {
+			return c.clusterClient.DeleteGitOpsConfig(ctx, managementCluster, clusterSpec.GitOpsConfig.Name, clusterSpec.GitOpsConfig.Namespace)
+		}); err != nil {
 			return err
 		}
 	}
 
 	if clusterSpec.OIDCConfig != nil {
 		log.V(2).Info("Deleting OIDCConfig", "name", clusterSpec.OIDCConfig.Name)
-		if err := c.clusterClient.DeleteOIDCConfig(ctx, managementCluster, clusterSpec.OIDCConfig.Name, clusterSpec.OIDCConfig.Namespace); err != nil {
+		if err := summary.run(DeleteStepOIDCConfig, clusterSpec.OIDCConfig.Name, func() error {
+			return c.clusterClient.DeleteOIDCConfig(ctx, managementCluster, clusterSpec.OIDCConfig.Name, clusterSpec.OIDCConfig.Namespace)
+		}); err != nil {
 			return err
 		}
 	}
 
 	if clusterSpec.AWSIamConfig != nil {
 		log.V(2).Info("Deleting AWSIamConfig", "name", clusterSpec.AWSIamConfig.Name)
-		if err := c.clusterClient.DeleteAWSIamConfig(ctx, managementCluster, clusterSpec.AWSIamConfig.Name, clusterSpec.AWSIamConfig.Namespace); err != nil {
+		if err := summary.run(DeleteStepAWSIamConfig, clusterSpec.AWSIamConfig.Name, func() error {
+			return c.clusterClient.DeleteAWSIamConfig(ctx, managementCluster, clusterSpec.AWSIamConfig.Name, clusterSpec.AWSIamConfig.Namespace)
+		}); err != nil {
 			return err
 		}
 	}
 
 	log.V(2).Info("Cleaning up provider specific resources")
-	if err := provider.DeleteResources(ctx, clusterSpec); err != nil {
+	if err := summary.run(DeleteStepProviderResources, "", func() error {
+		return provider.DeleteResources(ctx, clusterSpec)
+	}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
-	eksaMgmtCluster := workloadCluster
+// UpgradeScope selects the parts of the cluster UpgradeCluster upgrades. Scopes can be combined with |.
+type UpgradeScope int
+
+const (
+	// ScopeControlPlane upgrades the control plane and the external etcd, if any.
+	ScopeControlPlane UpgradeScope = 1 << iota
+	// ScopeWorkers upgrades the worker node groups.
+	ScopeWorkers
+	// ScopeAll upgrades the whole cluster.
+	ScopeAll = ScopeControlPlane | ScopeWorkers
+)
+
+func (s UpgradeScope) includes(scope UpgradeScope) bool {
+	return s&scope != 0
+}
+
+// UpgradePhase is a stage of UpgradeCluster, reported through the hook set with WithUpgradeProgressHook.
+type UpgradePhase int
+
+// Phases of UpgradeCluster, in the order they run.
+const (
+	UpgradePhaseGenerateSpec UpgradePhase = iota
+	UpgradePhaseApplyControlPlane
+	UpgradePhaseWaitExternalEtcd
+	UpgradePhaseWaitControlPlaneNotReady
+	UpgradePhaseWaitControlPlaneReady
+	UpgradePhaseWaitControlPlaneMachines
+	UpgradePhaseApplyWorkers
+	UpgradePhaseDeleteOldWorkerGroups
+	UpgradePhaseWaitMachineDeployments
+	UpgradePhaseWaitCAPI
+	UpgradePhaseFinalize
+)
+
+var upgradePhaseNames = map[UpgradePhase]string{
+	UpgradePhaseGenerateSpec:             "GenerateSpec",
+	UpgradePhaseApplyControlPlane:        "ApplyControlPlane",
+	UpgradePhaseWaitExternalEtcd:         "WaitExternalEtcd",
+	UpgradePhaseWaitControlPlaneNotReady: "WaitControlPlaneNotReady",
+	UpgradePhaseWaitControlPlaneReady:    "WaitControlPlaneReady",
+	UpgradePhaseWaitControlPlaneMachines: "WaitControlPlaneMachines",
+	UpgradePhaseApplyWorkers:             "ApplyWorkers",
+	UpgradePhaseDeleteOldWorkerGroups:    "DeleteOldWorkerGroups",
+	UpgradePhaseWaitMachineDeployments:   "WaitMachineDeployments",
+	UpgradePhaseWaitCAPI:                 "WaitCAPI",
+	UpgradePhaseFinalize:                 "Finalize",
+}
+
+func (p UpgradePhase) String() string {
+	if name, ok := upgradePhaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("UpgradePhase(%d)", int(p))
+}
+
+func (c *ClusterManager) reportUpgradePhase(phase UpgradePhase) {
+	if c.upgradeProgressHook != nil {
+		c.upgradeProgressHook(phase)
+	}
+}
+
+// UpgradeClusterDryRun generates the CAPI control plane and worker specs UpgradeCluster would apply,
+// without applying them, waiting or writing any files.
+func (c *ClusterManager) UpgradeClusterDryRun(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) (controlPlane, workers []byte, err error) {
+	eksaMgmtCluster := eksaManagementCluster(managementCluster, workloadCluster)
+
+	if err := c.validateClusterConnections(ctx, managementCluster, eksaMgmtCluster); err != nil {
+		return nil, nil, err
+	}
+
+	_, controlPlane, workers, err = c.generateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, newClusterSpec, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return controlPlane, workers, nil
+}
+
+// eksaManagementCluster returns the cluster holding the EKS-A objects of workloadCluster.
+func eksaManagementCluster(managementCluster, workloadCluster *types.Cluster) *types.Cluster {
 	if managementCluster != nil && managementCluster.ExistingManagement {
-		eksaMgmtCluster = managementCluster
+		return managementCluster
 	}
+	return workloadCluster
+}
 
-	currentSpec, err := c.GetCurrentClusterSpec(ctx, eksaMgmtCluster, newClusterSpec.Cluster.Name)
+func (c *ClusterManager) generateCAPISpecForUpgrade(ctx context.Context, managementCluster, eksaMgmtCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) (currentSpec *cluster.Spec, controlPlane, workers []byte, err error) {
+	currentSpec, err = c.GetCurrentClusterSpec(ctx, eksaMgmtCluster, newClusterSpec.Cluster.Name)
 	if err != nil {
-		return fmt.Errorf("getting current cluster spec: %v", err)
+		return nil, nil, nil, fmt.Errorf("getting current cluster spec: %v", err)
 	}
 
-	cpContent, mdContent, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, currentSpec, newClusterSpec)
+	controlPlane, workers, err = provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, currentSpec, newClusterSpec)
 	if err != nil {
-		return fmt.Errorf("generating capi spec: %v", err)
+		return nil, nil, nil, fmt.Errorf("generating capi spec: %v", err)
 	}
 
-	if err = c.writeCAPISpecFile(newClusterSpec.Cluster.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
+	return currentSpec, controlPlane, workers, nil
+}
+
+func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
+	eksaMgmtCluster := eksaManagementCluster(managementCluster, workloadCluster)
+
+	if err := c.validateClusterConnections(ctx, managementCluster, eksaMgmtCluster); err != nil {
 		return err
 	}
-	err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace)
+
+	c.reportUpgradePhase(UpgradePhaseGenerateSpec)
+	currentSpec, cpContent, mdContent, err := c.generateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, newClusterSpec, provider)
+	if err != nil {
+		return err
+	}
+
+	if err = c.validateUpgradeScope(ctx, managementCluster, newClusterSpec); err != nil {
+		return err
+	}
+
+	if _, err = c.writeCAPISpecFile(newClusterSpec.Cluster.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
+		return err
+	}
+
+	if c.upgradeScope.includes(ScopeControlPlane) {
+		if err = c.upgradeControlPlane(ctx, managementCluster, workloadCluster, currentSpec, newClusterSpec, provider, cpContent); err != nil {
+			return err
+		}
+	}
+
+	if c.upgradeScope.includes(ScopeWorkers) {
+		if err = c.upgradeWorkers(ctx, managementCluster, provider, currentSpec, newClusterSpec, mdContent); err != nil {
+			return err
+		}
+	}
+
+	c.reportUpgradePhase(UpgradePhaseWaitCAPI)
+	logger.V(3).Info("Waiting for workload cluster capi components to be ready after upgrade")
+	deploymentWaitTimeout := c.deploymentWaitTimeout
+	if c.workerDeploymentWaitTimeout > 0 {
+		deploymentWaitTimeout = c.workerDeploymentWaitTimeout
+	}
+	externalEtcdTopology := newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil
+	err = c.waitForCAPI(ctx, eksaMgmtCluster, provider, externalEtcdTopology, deploymentWaitTimeout)
+	if err != nil {
+		return fmt.Errorf("waiting for workload cluster capi components to be ready: %v", err)
+	}
+
+	c.reportUpgradePhase(UpgradePhaseFinalize)
+	if newClusterSpec.AWSIamConfig != nil {
+		logger.V(3).Info("Run aws-iam-authenticator upgrade operations")
+		if err = c.awsIamAuth.UpgradeAWSIAMAuth(ctx, workloadCluster, newClusterSpec); err != nil {
+			return fmt.Errorf("running aws-iam-authenticator upgrade operations: %v", err)
+		}
+	}
+
+	if err = c.InstallStorageClass(ctx, workloadCluster, provider); err != nil {
+		return fmt.Errorf("installing storage class during upgrade: %v", err)
+	}
+
+	if err = c.InstallPriorityClasses(ctx, newClusterSpec, workloadCluster); err != nil {
+		return err
+	}
+
+	if err = c.InstallEtcdBackup(ctx, newClusterSpec, managementCluster, workloadCluster); err != nil {
+		return err
+	}
+
+	if c.postUpgradeSmokeTest != nil {
+		logger.V(3).Info("Running post upgrade smoke test")
+		if err = c.postUpgradeSmokeTest(ctx, workloadCluster.KubeconfigFile); err != nil {
+			return fmt.Errorf("running post upgrade smoke test: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeControlPlane applies the new control plane spec and waits for the external etcd, if any, and
+// the control plane to be rolled out and ready.
+func (c *ClusterManager) upgradeControlPlane(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec, provider providers.Provider, cpContent []byte) error {
+	c.reportUpgradePhase(UpgradePhaseApplyControlPlane)
+	err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace)
 	if err != nil {
 		return fmt.Errorf("applying capi control plane spec: %v", err)
 	}
 
-	var externalEtcdTopology bool
 	if newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		c.reportUpgradePhase(UpgradePhaseWaitExternalEtcd)
 		logger.V(3).Info("Waiting for external etcd upgrade to be in progress")
 		err = c.clusterClient.WaitForManagedExternalEtcdNotReady(ctx, managementCluster, etcdInProgressStr, newClusterSpec.Cluster.Name)
 		if err != nil {
-			if !strings.Contains(fmt.Sprint(err), "timed out waiting for the condition on clusters") {
+			if !isNotReadyWaitTimeout(err) {
 				return fmt.Errorf("error waiting for external etcd upgrade not ready: %v", err)
 			} else {
 				logger.V(3).Info("Timed out while waiting for external etcd to be in progress, likely caused by no external etcd upgrade")
@@ -556,33 +1798,49 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 			}
 			return fmt.Errorf("waiting for external etcd for workload cluster to be ready: %v", err)
 		}
-		externalEtcdTopology = true
 		logger.V(3).Info("External etcd is ready")
 	}
 
-	logger.V(3).Info("Waiting for control plane upgrade to be in progress")
-	err = c.clusterClient.WaitForControlPlaneNotReady(ctx, managementCluster, controlPlaneInProgressStr, newClusterSpec.Cluster.Name)
-	if err != nil {
-		if !strings.Contains(fmt.Sprint(err), "timed out waiting for the condition on clusters") {
-			return fmt.Errorf("error waiting for control plane not ready: %v", err)
-		} else {
-			logger.V(3).Info("Timed out while waiting for control plane to be in progress, likely caused by no control plane upgrade")
+	if c.controlPlaneRolloutTimeout > 0 {
+		c.reportUpgradePhase(UpgradePhaseWaitControlPlaneNotReady)
+		if err = c.waitForControlPlaneRollout(ctx, managementCluster, newClusterSpec.Cluster.Name, c.controlPlaneRolloutTimeout, func() {
+			c.reportUpgradePhase(UpgradePhaseWaitControlPlaneReady)
+		}); err != nil {
+			return err
 		}
-	}
-	logger.V(3).Info("Run post control plane upgrade operations")
-	err = provider.RunPostControlPlaneUpgrade(ctx, currentSpec, newClusterSpec, workloadCluster, managementCluster)
-	if err != nil {
-		return fmt.Errorf("running post control plane upgrade operations: %v", err)
-	}
 
-	logger.V(3).Info("Waiting for control plane to be ready")
-	err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, c.controlPlaneWaitTimeout.String(), newClusterSpec.Cluster.Name)
-	if err != nil {
-		return fmt.Errorf("waiting for workload cluster control plane to be ready: %v", err)
+		logger.V(3).Info("Run post control plane upgrade operations")
+		if err = provider.RunPostControlPlaneUpgrade(ctx, currentSpec, newClusterSpec, workloadCluster, managementCluster); err != nil {
+			return fmt.Errorf("running post control plane upgrade operations: %v", err)
+		}
+	} else {
+		c.reportUpgradePhase(UpgradePhaseWaitControlPlaneNotReady)
+		logger.V(3).Info("Waiting for control plane upgrade to be in progress")
+		err = c.clusterClient.WaitForControlPlaneNotReady(ctx, managementCluster, controlPlaneInProgressStr, newClusterSpec.Cluster.Name)
+		if err != nil {
+			if !isNotReadyWaitTimeout(err) {
+				return fmt.Errorf("error waiting for control plane not ready: %v", err)
+			} else {
+				logger.V(3).Info("Timed out while waiting for control plane to be in progress, likely caused by no control plane upgrade")
+			}
+		}
+		logger.V(3).Info("Run post control plane upgrade operations")
+		err = provider.RunPostControlPlaneUpgrade(ctx, currentSpec, newClusterSpec, workloadCluster, managementCluster)
+		if err != nil {
+			return fmt.Errorf("running post control plane upgrade operations: %v", err)
+		}
+
+		c.reportUpgradePhase(UpgradePhaseWaitControlPlaneReady)
+		logger.V(3).Info("Waiting for control plane to be ready")
+		err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, c.controlPlaneWaitTimeout.String(), newClusterSpec.Cluster.Name)
+		if err != nil {
+			return fmt.Errorf("waiting for workload cluster control plane to be ready: %v", err)
+		}
 	}
 
+	c.reportUpgradePhase(UpgradePhaseWaitControlPlaneMachines)
 	logger.V(3).Info("Waiting for control plane machines to be ready")
-	if err = c.waitForNodesReady(ctx, managementCluster, newClusterSpec.Cluster.Name, []string{clusterv1.MachineControlPlaneLabelName}, types.WithNodeRef(), types.WithNodeHealthy()); err != nil {
+	if err = c.waitForNodesReady(ctx, managementCluster, newClusterSpec.Cluster.Name, []string{clusterv1.MachineControlPlaneLabelName}, c.machineReadyCheckers(types.WithNodeRef(), types.WithNodeHealthy())...); err != nil {
 		return err
 	}
 
@@ -603,113 +1861,341 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("waiting for workload cluster control plane replicas to be ready: %v", err)
 	}
 
-	err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace)
-	if err != nil {
-		return fmt.Errorf("applying capi machine deployment spec: %v", err)
+	return nil
+}
+
+// upgradeWorkers applies the new worker node groups spec, removes the old ones and waits for the
+// machine deployments to be rolled out and ready.
+func (c *ClusterManager) upgradeWorkers(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, currentSpec, newClusterSpec *cluster.Spec, mdContent []byte) error {
+	c.reportUpgradePhase(UpgradePhaseApplyWorkers)
+	if err := c.applyWorkerNodeGroups(ctx, managementCluster, newClusterSpec, mdContent); err != nil {
+		return err
 	}
 
-	if err = c.removeOldWorkerNodeGroups(ctx, managementCluster, provider, currentSpec, newClusterSpec); err != nil {
+	c.reportUpgradePhase(UpgradePhaseDeleteOldWorkerGroups)
+	if err := c.removeOldWorkerNodeGroups(ctx, managementCluster, provider, currentSpec, newClusterSpec); err != nil {
 		return fmt.Errorf("removing old worker node groups: %v", err)
 	}
 
+	c.reportUpgradePhase(UpgradePhaseWaitMachineDeployments)
 	logger.V(3).Info("Waiting for workload cluster machine deployment replicas to be ready after upgrade")
-	err = c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, newClusterSpec)
+	err := c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, newClusterSpec)
 	if err != nil {
 		return fmt.Errorf("waiting for workload cluster machinedeployment replicas to be ready: %v", err)
 	}
 
 	logger.V(3).Info("Waiting for machine deployment machines to be ready")
-	if err = c.waitForNodesReady(ctx, managementCluster, newClusterSpec.Cluster.Name, []string{clusterv1.MachineDeploymentLabelName}, types.WithNodeRef(), types.WithNodeHealthy()); err != nil {
+	if err = c.waitForNodesReady(ctx, managementCluster, newClusterSpec.Cluster.Name, []string{clusterv1.MachineDeploymentLabelName}, c.machineReadyCheckers(types.WithNodeRef(), types.WithNodeHealthy())...); err != nil {
 		return err
 	}
 
-	logger.V(3).Info("Waiting for workload cluster capi components to be ready after upgrade")
-	err = c.waitForCAPI(ctx, eksaMgmtCluster, provider, externalEtcdTopology)
+	return nil
+}
+
+// validateUpgradeScope checks that a workers only upgrade doesn't leave the workers on a newer Kubernetes
+// version than the control plane. It compares against the version the KubeadmControlPlane is running, since
+// the EKS-A cluster object doesn't tell whether a previous control plane only upgrade already rolled out.
+func (c *ClusterManager) validateUpgradeScope(ctx context.Context, managementCluster *types.Cluster, newClusterSpec *cluster.Spec) error {
+	if c.upgradeScope.includes(ScopeControlPlane) {
+		return nil
+	}
+
+	kcp, err := c.clusterClient.GetKubeadmControlPlane(ctx, managementCluster, newClusterSpec.Cluster.Name, executables.WithCluster(managementCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 	if err != nil {
-		return fmt.Errorf("waiting for workload cluster capi components to be ready: %v", err)
+		return fmt.Errorf("getting the control plane kubernetes version: %v", err)
 	}
 
-	if newClusterSpec.AWSIamConfig != nil {
-		logger.V(3).Info("Run aws-iam-authenticator upgrade operations")
-		if err = c.awsIamAuth.UpgradeAWSIAMAuth(ctx, workloadCluster, newClusterSpec); err != nil {
-			return fmt.Errorf("running aws-iam-authenticator upgrade operations: %v", err)
+	currentVersion := kcp.Spec.Version
+	newVersion := newClusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
+	if currentVersion != newVersion {
+		return fmt.Errorf("can't upgrade only the workers to kubernetes version %s, the control plane is on version %s: upgrade the control plane first", newVersion, currentVersion)
+	}
+
+	return nil
+}
+
+// WaitForControlPlaneRollout waits for a control plane rollout to complete within a single timeout budget.
+// It first waits for the control plane to leave ready, which signals the rollout started, and then waits
+// for it to be ready again with whatever budget is left. If the control plane never leaves ready, there's
+// no rollout in progress and it only waits for it to be ready.
+func (c *ClusterManager) WaitForControlPlaneRollout(ctx context.Context, managementCluster *types.Cluster, clusterName string, timeout time.Duration) error {
+	return c.waitForControlPlaneRollout(ctx, managementCluster, clusterName, timeout, func() {})
+}
+
+// waitForControlPlaneRollout is WaitForControlPlaneRollout, calling beforeReadyWait once the not ready wait is done.
+func (c *ClusterManager) waitForControlPlaneRollout(ctx context.Context, managementCluster *types.Cluster, clusterName string, timeout time.Duration, beforeReadyWait func()) error {
+	start := time.Now()
+
+	notReadyTimeout, _ := time.ParseDuration(controlPlaneInProgressStr)
+	if notReadyTimeout > timeout {
+		notReadyTimeout = timeout
+	}
+
+	logger.V(3).Info("Waiting for control plane upgrade to be in progress")
+	err := c.clusterClient.WaitForControlPlaneNotReady(ctx, managementCluster, notReadyTimeout.String(), clusterName)
+	if err != nil {
+		if !isNotReadyWaitTimeout(err) {
+			return fmt.Errorf("error waiting for control plane not ready: %v", err)
 		}
+		logger.V(3).Info("Timed out while waiting for control plane to be in progress, likely caused by no control plane upgrade")
 	}
 
-	if err = c.InstallStorageClass(ctx, workloadCluster, provider); err != nil {
-		return fmt.Errorf("installing storage class during upgrade: %v", err)
+	remaining := timeout - time.Since(start)
+	if remaining <= 0 {
+		return fmt.Errorf("control plane rollout didn't complete within %s", timeout)
+	}
+
+	beforeReadyWait()
+	logger.V(3).Info("Waiting for control plane to be ready", "timeout", remaining)
+	if err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, remaining.String(), clusterName); err != nil {
+		return fmt.Errorf("waiting for workload cluster control plane to be ready: %v", err)
 	}
 
 	return nil
 }
 
+// isNotReadyWaitTimeout reports whether err comes from a wait for a NOT-ready condition timing out.
+// That means the object never left ready, so there's nothing being rolled out and the wait can be skipped.
+// Both the timeout reported by kubectl and the one raised when the wait deadline is reached before
+// running kubectl are considered. Any other error is a genuine failure.
+func isNotReadyWaitTimeout(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "timed out waiting for the condition on") ||
+		strings.Contains(msg, "timed out waiting for condition")
+}
+
 func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, newClusterSpec *cluster.Spec) (bool, error) {
-	cc, err := c.clusterClient.GetEksaCluster(ctx, cluster, newClusterSpec.Cluster.Name)
+	if c.currentSpecCache != nil {
+		c.currentSpecCache.reset()
+	}
+
+	diff, err := c.eksaClusterSpecDiff(ctx, cluster, newClusterSpec, true)
 	if err != nil {
 		return false, err
 	}
 
+	// GitOps changes alone aren't reported, they're not applied by a cluster upgrade.
+	return diff.Cluster || diff.EksdRelease || diff.OIDCConfig || diff.AWSIamConfig, nil
+}
+
+// SpecDiff describes which sections of a cluster spec differ from the spec currently applied to the cluster.
+type SpecDiff struct {
+	Cluster      bool
+	EksdRelease  bool
+	OIDCConfig   bool
+	AWSIamConfig bool
+	// GitOpsConfig is true when either the GitOpsConfig or the FluxConfig differ.
+	GitOpsConfig bool
+}
+
+// Changed returns true if any section differs.
+func (d *SpecDiff) Changed() bool {
+	return d.Cluster || d.EksdRelease || d.OIDCConfig || d.AWSIamConfig || d.GitOpsConfig
+}
+
+// EKSAClusterSpecDiff compares newClusterSpec with the spec currently applied to the cluster and
+// reports which sections differ. Unlike EKSAClusterSpecChanged, it checks every section even after
+// finding a change.
+func (c *ClusterManager) EKSAClusterSpecDiff(ctx context.Context, cluster *types.Cluster, newClusterSpec *cluster.Spec) (*SpecDiff, error) {
+	return c.eksaClusterSpecDiff(ctx, cluster, newClusterSpec, false)
+}
+
+// eksaClusterSpecDiff builds the SpecDiff for newClusterSpec. If stopAtFirstChange is true, it returns
+// as soon as a section differs, without checking the remaining ones.
+func (c *ClusterManager) eksaClusterSpecDiff(ctx context.Context, cluster *types.Cluster, newClusterSpec *cluster.Spec, stopAtFirstChange bool) (*SpecDiff, error) {
+	diff := &SpecDiff{}
+	cc, err := c.clusterClient.GetEksaCluster(ctx, cluster, newClusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	if !cc.Equal(newClusterSpec.Cluster) {
 		logger.V(3).Info("Existing cluster and new cluster spec differ")
-		return true, nil
+		diff.Cluster = true
+		if stopAtFirstChange {
+			return diff, nil
+		}
 	}
 
 	currentClusterSpec, err := c.buildSpecForCluster(ctx, cluster, cc)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if currentClusterSpec.VersionsBundle.EksD.Name != newClusterSpec.VersionsBundle.EksD.Name {
 		logger.V(3).Info("New eks-d release detected")
-		return true, nil
+		diff.EksdRelease = true
+		if stopAtFirstChange {
+			return diff, nil
+		}
 	}
 
-	if newClusterSpec.OIDCConfig != nil && currentClusterSpec.OIDCConfig != nil {
-		if !newClusterSpec.OIDCConfig.Spec.Equal(&currentClusterSpec.OIDCConfig.Spec) {
-			logger.V(3).Info("OIDC config changes detected")
-			return true, nil
+	if oidcConfigChanged(currentClusterSpec, newClusterSpec) {
+		logger.V(3).Info("OIDC config changes detected")
+		diff.OIDCConfig = true
+		if stopAtFirstChange {
+			return diff, nil
 		}
 	}
 
-	if newClusterSpec.AWSIamConfig != nil && currentClusterSpec.AWSIamConfig != nil {
-		if !reflect.DeepEqual(newClusterSpec.AWSIamConfig.Spec.MapRoles, currentClusterSpec.AWSIamConfig.Spec.MapRoles) ||
-			!reflect.DeepEqual(newClusterSpec.AWSIamConfig.Spec.MapUsers, currentClusterSpec.AWSIamConfig.Spec.MapUsers) {
-			logger.V(3).Info("AWSIamConfig changes detected")
-			return true, nil
+	if awsIamConfigChanged(currentClusterSpec, newClusterSpec) {
+		logger.V(3).Info("AWSIamConfig changes detected")
+		diff.AWSIamConfig = true
+		if stopAtFirstChange {
+			return diff, nil
 		}
 	}
 
-	logger.V(3).Info("Clusters are the same")
-	return false, nil
+	if gitOpsConfigChanged(currentClusterSpec, newClusterSpec) {
+		logger.V(3).Info("GitOpsConfig changes detected")
+		diff.GitOpsConfig = true
+	}
+
+	if !diff.Changed() {
+		logger.V(3).Info("Clusters are the same")
+	}
+	return diff, nil
 }
 
-func (c *ClusterManager) InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error {
-	err := c.clusterClient.InitInfrastructure(ctx, clusterSpec, cluster, provider)
-	if err != nil {
-		return fmt.Errorf("initializing capi resources in cluster: %v", err)
+func gitOpsConfigChanged(currentSpec, newSpec *cluster.Spec) bool {
+	if newSpec.GitOpsConfig != nil && currentSpec.GitOpsConfig != nil &&
+		!newSpec.GitOpsConfig.Spec.Equal(&currentSpec.GitOpsConfig.Spec) {
+		return true
 	}
 
-	return c.waitForCAPI(ctx, cluster, provider, clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil)
+	return newSpec.FluxConfig != nil && currentSpec.FluxConfig != nil &&
+		!newSpec.FluxConfig.Spec.Equal(&currentSpec.FluxConfig.Spec)
 }
 
-func (c *ClusterManager) waitForCAPI(ctx context.Context, cluster *types.Cluster, provider providers.Provider, externalEtcdTopology bool) error {
-	err := c.clusterClient.waitForDeployments(ctx, internal.CAPIDeployments, cluster, c.deploymentWaitTimeout.String())
+func oidcConfigChanged(currentSpec, newSpec *cluster.Spec) bool {
+	return newSpec.OIDCConfig != nil && currentSpec.OIDCConfig != nil &&
+		!newSpec.OIDCConfig.Spec.Equal(&currentSpec.OIDCConfig.Spec)
+}
+
+func awsIamConfigChanged(currentSpec, newSpec *cluster.Spec) bool {
+	return newSpec.AWSIamConfig != nil && currentSpec.AWSIamConfig != nil &&
+		(!reflect.DeepEqual(newSpec.AWSIamConfig.Spec.MapRoles, currentSpec.AWSIamConfig.Spec.MapRoles) ||
+			!reflect.DeepEqual(newSpec.AWSIamConfig.Spec.MapUsers, currentSpec.AWSIamConfig.Spec.MapUsers))
+}
+
+// ReconcileIdentityProviders applies only the identity provider changes (OIDCConfig and AWSIamConfig) in newClusterSpec,
+// without regenerating or applying any CAPI objects, so no machines are rolled. AWSIamConfig changes are also rolled out
+// to aws-iam-authenticator in the workload cluster. OIDC settings rendered into the API server flags only take effect
+// on the next UpgradeCluster. It's a no-op when no identity provider changes are detected, and it returns an error
+// when an identity provider is added to a cluster that didn't have one, since that requires a full UpgradeCluster.
+func (c *ClusterManager) ReconcileIdentityProviders(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec) error {
+	eksaMgmtCluster := eksaManagementCluster(managementCluster, workloadCluster)
+
+	currentSpec, err := c.GetCurrentClusterSpec(ctx, eksaMgmtCluster, newClusterSpec.Cluster.Name)
 	if err != nil {
-		return err
+		return fmt.Errorf("getting current cluster spec: %v", err)
 	}
 
-	if externalEtcdTopology {
-		err := c.clusterClient.waitForDeployments(ctx, internal.ExternalEtcdDeployments, cluster, c.deploymentWaitTimeout.String())
+	if currentSpec.AWSIamConfig == nil && newClusterSpec.AWSIamConfig != nil {
+		return fmt.Errorf("adding AWSIamConfig to cluster %s requires a full UpgradeCluster", newClusterSpec.Cluster.Name)
+	}
+	if currentSpec.OIDCConfig == nil && newClusterSpec.OIDCConfig != nil {
+		return fmt.Errorf("adding OIDCConfig to cluster %s requires a full UpgradeCluster", newClusterSpec.Cluster.Name)
+	}
+
+	oidcChanged := oidcConfigChanged(currentSpec, newClusterSpec)
+	awsIamChanged := awsIamConfigChanged(currentSpec, newClusterSpec)
+	if !oidcChanged && !awsIamChanged {
+		logger.V(3).Info("No identity provider changes detected")
+		return nil
+	}
+
+	var marshallables []v1alpha1.Marshallable
+	if oidcChanged {
+		marshallables = append(marshallables, newClusterSpec.OIDCConfig.ConvertConfigToConfigGenerateStruct())
+	}
+	if awsIamChanged {
+		marshallables = append(marshallables, newClusterSpec.AWSIamConfig.ConvertConfigToConfigGenerateStruct())
+	}
+
+	resources := make([][]byte, 0, len(marshallables))
+	for _, marshallable := range marshallables {
+		resource, err := yaml.Marshal(marshallable)
 		if err != nil {
-			return err
+			return fmt.Errorf("marshalling identity provider config: %v", err)
 		}
+		resources = append(resources, resource)
 	}
 
-	err = c.clusterClient.waitForDeployments(ctx, provider.GetDeployments(), cluster, c.deploymentWaitTimeout.String())
-	if err != nil {
+	logger.V(3).Info("Applying identity provider configs")
+	if err = c.applyResource(ctx, eksaMgmtCluster, templater.AppendYamlResources(resources...)); err != nil {
 		return err
 	}
 
+	if awsIamChanged {
+		logger.V(3).Info("Run aws-iam-authenticator upgrade operations")
+		if err = c.awsIamAuth.UpgradeAWSIAMAuth(ctx, workloadCluster, newClusterSpec); err != nil {
+			return fmt.Errorf("running aws-iam-authenticator upgrade operations: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error {
+	err := c.clusterClient.InitInfrastructure(ctx, clusterSpec, cluster, provider)
+	if err != nil {
+		return fmt.Errorf("initializing capi resources in cluster: %v", err)
+	}
+
+	return c.waitForCAPI(ctx, cluster, provider, clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil, c.deploymentWaitTimeout)
+}
+
+// waitForCAPI waits for the CAPI, external etcd and provider deployments to be available, all at the same time.
+func (c *ClusterManager) waitForCAPI(ctx context.Context, cluster *types.Cluster, provider providers.Provider, externalEtcdTopology bool, timeout time.Duration) error {
+	deployments := map[string][]string{}
+	addDeployments(deployments, internal.CAPIDeployments)
+	if externalEtcdTopology {
+		addDeployments(deployments, internal.ExternalEtcdDeployments)
+	}
+	addDeployments(deployments, provider.GetDeployments())
+
+	return c.clusterClient.waitForDeployments(ctx, deployments, cluster, timeout.String())
+}
+
+func addDeployments(deploymentsByNamespace, deployments map[string][]string) {
+	for namespace, names := range deployments {
+		deploymentsByNamespace[namespace] = append(deploymentsByNamespace[namespace], names...)
+	}
+}
+
+type capiProviderVersion struct {
+	name         string
+	providerType clusterctlv1.ProviderType
+	namespace    string
+	version      string
+}
+
+// ValidateCAPIProviderVersions checks that the CAPI providers installed in the management cluster
+// run the versions expected by the bundle in the cluster spec. Providers that are not installed are ignored.
+func (c *ClusterManager) ValidateCAPIProviderVersions(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	bundle := clusterSpec.VersionsBundle
+	expected := []capiProviderVersion{
+		{name: "cluster-api", providerType: clusterctlv1.CoreProviderType, namespace: constants.CapiSystemNamespace, version: bundle.ClusterAPI.Version},
+		{name: "kubeadm", providerType: clusterctlv1.BootstrapProviderType, namespace: constants.CapiKubeadmBootstrapSystemNamespace, version: bundle.Bootstrap.Version},
+		{name: "kubeadm", providerType: clusterctlv1.ControlPlaneProviderType, namespace: constants.CapiKubeadmControlPlaneSystemNamespace, version: bundle.ControlPlane.Version},
+		{name: "etcdadm-bootstrap", providerType: clusterctlv1.BootstrapProviderType, namespace: constants.EtcdAdmBootstrapProviderSystemNamespace, version: bundle.ExternalEtcdBootstrap.Version},
+		{name: "etcdadm-controller", providerType: clusterctlv1.BootstrapProviderType, namespace: constants.EtcdAdmControllerSystemNamespace, version: bundle.ExternalEtcdController.Version},
+	}
+
+	for _, e := range expected {
+		providers := &clusterctlv1.ProviderList{}
+		if err := c.clusterClient.ListObjects(ctx, capiProviderResourceType, e.namespace, managementCluster.KubeconfigFile, providers); err != nil {
+			return fmt.Errorf("listing CAPI providers in namespace %s: %v", e.namespace, err)
+		}
+
+		for _, p := range providers.FilterByProviderNameAndType(e.name, e.providerType) {
+			if p.Version != e.version {
+				return fmt.Errorf("CAPI %s %s version mismatch: installed version %s, bundle version %s", e.providerType, e.name, p.Version, e.version)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -750,26 +2236,145 @@ func (c *ClusterManager) InstallStorageClass(ctx context.Context, cluster *types
 	return nil
 }
 
-func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
-	mhc, err := templater.ObjectsToYaml(clusterapi.MachineHealthCheckObjects(clusterSpec, c.unhealthyMachineTimeout, c.nodeStartupTimeout)...)
+// MachineHealthCheckTimeouts holds the timeouts rendered in the MachineHealthChecks for a cluster.
+type MachineHealthCheckTimeouts struct {
+	UnhealthyMachineTimeout time.Duration
+	NodeStartupTimeout      time.Duration
+}
+
+// EffectiveMHCTimeouts returns the MachineHealthCheck timeouts the ClusterManager uses when installing
+// machine health checks, after applying defaults and options.
+func (c *ClusterManager) EffectiveMHCTimeouts() MachineHealthCheckTimeouts {
+	return MachineHealthCheckTimeouts{
+		UnhealthyMachineTimeout: c.unhealthyMachineTimeout,
+		NodeStartupTimeout:      c.nodeStartupTimeout,
+	}
+}
+
+func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	timeouts := c.EffectiveMHCTimeouts()
+	workerNodeGroupConfigs := clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations
+	objects := make([]runtime.Object, 0, len(workerNodeGroupConfigs)+1)
+	for _, workerNodeGroupConfig := range workerNodeGroupConfigs {
+		mhc, err := c.workerMachineHealthCheck(clusterSpec, workerNodeGroupConfig)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, mhc)
+	}
+	cpNodeStartupTimeout := c.machineNodeStartupTimeout(clusterSpec, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef)
+	objects = append(objects, clusterapi.MachineHealthCheckForControlPlane(clusterSpec, timeouts.UnhealthyMachineTimeout, cpNodeStartupTimeout))
+
+	mhc, err := templater.ObjectsToYaml(objects...)
+	if err != nil {
+		return err
+	}
+
+	err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, mhc)
+	if err != nil {
+		return fmt.Errorf("applying machine health checks: %v", err)
+	}
+	return nil
+}
+
+// InstallMachineHealthCheckForWorkerGroup applies only the MachineHealthCheck for the named worker node group,
+// leaving the control plane and other worker node groups MachineHealthChecks untouched.
+func (c *ClusterManager) InstallMachineHealthCheckForWorkerGroup(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, groupName string) error {
+	var workerNodeGroupConfig *v1alpha1.WorkerNodeGroupConfiguration
+	for i := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i].Name == groupName {
+			workerNodeGroupConfig = &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+			break
+		}
+	}
+	if workerNodeGroupConfig == nil {
+		return fmt.Errorf("worker node group %s not found in cluster spec", groupName)
+	}
+
+	mhc, err := c.workerMachineHealthCheck(clusterSpec, *workerNodeGroupConfig)
 	if err != nil {
 		return err
 	}
 
-	err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, mhc)
+	mhcYaml, err := templater.ObjectsToYaml(mhc)
 	if err != nil {
-		return fmt.Errorf("applying machine health checks: %v", err)
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, mhcYaml); err != nil {
+		return fmt.Errorf("applying machine health check for worker node group %s: %v", groupName, err)
 	}
 	return nil
 }
 
+// workerMachineHealthCheck renders the MachineHealthCheck for a worker node group, applying the
+// configured timeouts and maxUnhealthy override.
+func (c *ClusterManager) workerMachineHealthCheck(clusterSpec *cluster.Spec, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) (*clusterv1.MachineHealthCheck, error) {
+	timeouts := c.EffectiveMHCTimeouts()
+	nodeStartupTimeout := c.machineNodeStartupTimeout(clusterSpec, workerNodeGroupConfig.MachineGroupRef)
+	mhc := clusterapi.MachineHealthCheckForWorker(clusterSpec, workerNodeGroupConfig, timeouts.UnhealthyMachineTimeout, nodeStartupTimeout)
+	if maxUnhealthy, ok := c.workerMaxUnhealthy[workerNodeGroupConfig.Name]; ok {
+		if _, err := intstr.GetScaledValueFromIntOrPercent(&maxUnhealthy, 100, false); err != nil {
+			return nil, fmt.Errorf("invalid maxUnhealthy %s for worker node group %s: %v", maxUnhealthy.String(), workerNodeGroupConfig.Name, err)
+		}
+		mhc.Spec.MaxUnhealthy = &maxUnhealthy
+	}
+	return mhc, nil
+}
+
+// machineNodeStartupTimeout returns the node startup timeout set in the machine config referenced by machineGroupRef,
+// falling back to the ClusterManager node startup timeout when the machine config doesn't set one.
+// Only TinkerbellMachineConfigs support overriding it.
+func (c *ClusterManager) machineNodeStartupTimeout(clusterSpec *cluster.Spec, machineGroupRef *v1alpha1.Ref) time.Duration {
+	if machineGroupRef == nil || machineGroupRef.Kind != v1alpha1.TinkerbellMachineConfigKind {
+		return c.nodeStartupTimeout
+	}
+
+	machineConfig, ok := clusterSpec.TinkerbellMachineConfigs[machineGroupRef.Name]
+	if !ok || machineConfig.Spec.NodeStartupTimeout == nil {
+		return c.nodeStartupTimeout
+	}
+
+	return machineConfig.Spec.NodeStartupTimeout.Duration
+}
+
 // InstallAwsIamAuth applies the aws-iam-authenticator manifest based on cluster spec inputs.
 // Generates a kubeconfig for interacting with the cluster with aws-iam-authenticator client.
 func (c *ClusterManager) InstallAwsIamAuth(ctx context.Context, management, workload *types.Cluster, spec *cluster.Spec) error {
 	return c.awsIamAuth.InstallAWSIAMAuth(ctx, management, workload, spec)
 }
 
+// ReconcileAWSIAMAuth rolls out the AWSIamConfig in clusterSpec to aws-iam-authenticator in the workload cluster,
+// without regenerating or applying any CAPI objects. Use it to apply mapRoles/mapUsers changes without a cluster upgrade.
+func (c *ClusterManager) ReconcileAWSIAMAuth(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.AWSIamConfig == nil {
+		return errors.New("no AWSIamConfig in cluster spec to reconcile")
+	}
+
+	if err := clusterSpec.AWSIamConfig.Validate(); err != nil {
+		return fmt.Errorf("validating AWSIamConfig: %v", err)
+	}
+
+	logger.V(3).Info("Run aws-iam-authenticator upgrade operations")
+	if err := c.awsIamAuth.UpgradeAWSIAMAuth(ctx, workloadCluster, clusterSpec); err != nil {
+		return fmt.Errorf("running aws-iam-authenticator upgrade operations: %v", err)
+	}
+
+	return nil
+}
+
+// CreateAwsIamAuthCaSecret creates the AWS IAM Authenticator CA secret for the workload cluster in the
+// management cluster. It's a no-op if the secret already exists, so re-runs don't replace the CA.
 func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, managementCluster *types.Cluster, workloadClusterName string) error {
+	exists, err := c.awsIamAuth.CASecretExists(ctx, managementCluster, workloadClusterName)
+	if err != nil {
+		return fmt.Errorf("checking aws-iam-authenticator ca secret: %v", err)
+	}
+	if exists {
+		logger.V(3).Info("aws-iam-authenticator CA secret already exists, skipping creation", "cluster", workloadClusterName)
+		return nil
+	}
+
 	return c.awsIamAuth.CreateAndInstallAWSIAMAuthCASecret(ctx, managementCluster, workloadClusterName)
 }
 
@@ -787,7 +2392,7 @@ func (c *ClusterManager) SaveLogsManagementCluster(ctx context.Context, spec *cl
 		logger.V(5).Info("Error generating support bundle for management cluster", "error", err)
 		return nil
 	}
-	return collectDiagnosticBundle(ctx, bundle)
+	return c.collectDiagnosticBundle(ctx, bundle)
 }
 
 func (c *ClusterManager) SaveLogsWorkloadCluster(ctx context.Context, provider providers.Provider, spec *cluster.Spec, cluster *types.Cluster) error {
@@ -805,10 +2410,10 @@ func (c *ClusterManager) SaveLogsWorkloadCluster(ctx context.Context, provider p
 		return nil
 	}
 
-	return collectDiagnosticBundle(ctx, bundle)
+	return c.collectDiagnosticBundle(ctx, bundle)
 }
 
-func collectDiagnosticBundle(ctx context.Context, bundle diagnostics.DiagnosticBundle) error {
+func (c *ClusterManager) collectDiagnosticBundle(ctx context.Context, bundle diagnostics.DiagnosticBundle) error {
 	var sinceTimeValue *time.Time
 	threeHours := "3h"
 	sinceTimeValue, err := diagnostics.ParseTimeFromDuration(threeHours)
@@ -817,11 +2422,34 @@ func collectDiagnosticBundle(ctx context.Context, bundle diagnostics.DiagnosticB
 		return nil
 	}
 
-	err = bundle.CollectAndAnalyze(ctx, sinceTimeValue)
-	if err != nil {
-		logger.V(5).Info("Error collecting and saving logs", "error", err)
+	if c.diagnosticsCollectionTimeout == 0 {
+		err = bundle.CollectAndAnalyze(ctx, sinceTimeValue)
+		if err != nil {
+			logger.V(5).Info("Error collecting and saving logs", "error", err)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.diagnosticsCollectionTimeout)
+	defer cancel()
+
+	// Run the collection in the background so a bundle that doesn't honor the context
+	// can't block past the deadline.
+	done := make(chan error, 1)
+	go func() {
+		done <- bundle.CollectAndAnalyze(ctx, sinceTimeValue)
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			logger.V(5).Info("Error collecting and saving logs", "error", err)
+		}
+		return nil
+	case <-ctx.Done():
+		logger.Info("Timed out collecting support bundle, logs might be incomplete", "timeout", c.diagnosticsCollectionTimeout)
+		return ErrDiagnosticsCollectionTimedOut
 	}
-	return nil
 }
 
 func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
@@ -840,7 +2468,7 @@ func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, m
 	}
 
 	r := retrier.New(timeout)
-	if err := r.Retry(isCpReady); err != nil {
+	if err := r.RetryWithContext(ctx, isCpReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for controlplane replicas to be ready: %v", err)
 	}
 	return nil
@@ -875,12 +2503,20 @@ func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Conte
 	}
 
 	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
-	if err := r.Retry(areMdReplicasReady); err != nil {
+	if err := r.RetryWithContext(ctx, areMdReplicasReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for machinedeployment replicas to be ready: %v", err)
 	}
 	return nil
 }
 
+// machineReadyCheckers appends a checker for each of the configured required machine conditions to checkers.
+func (c *ClusterManager) machineReadyCheckers(checkers ...types.NodeReadyChecker) []types.NodeReadyChecker {
+	for _, conditionType := range c.requiredMachineConditions {
+		checkers = append(checkers, types.WithConditionTrue(conditionType))
+	}
+	return checkers
+}
+
 func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, checkers ...types.NodeReadyChecker) error {
 	totalNodes, err := c.getNodesCount(ctx, managementCluster, clusterName, labels)
 	if err != nil {
@@ -892,9 +2528,11 @@ func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluste
 		return true, c.machineBackoff * time.Duration(integer.IntMax(1, totalNodes-readyNodes))
 	}
 
+	// healthyObservations tracks, per machine, how many consecutive polls it has passed all checkers.
+	healthyObservations := map[string]int{}
 	areNodesReady := func() error {
 		var err error
-		readyNodes, err = c.countNodesReady(ctx, managementCluster, clusterName, labels, checkers...)
+		readyNodes, err = c.countNodesReady(ctx, managementCluster, clusterName, labels, healthyObservations, checkers...)
 		if err != nil {
 			return err
 		}
@@ -919,7 +2557,7 @@ func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluste
 	}
 
 	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
-	if err := r.Retry(areNodesReady); err != nil {
+	if err := r.RetryWithContext(ctx, areNodesReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for machines to be ready: %v", err)
 	}
 
@@ -955,7 +2593,7 @@ func (c *ClusterManager) getNodesCount(ctx context.Context, managementCluster *t
 	return totalNodes, nil
 }
 
-func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, checkers ...types.NodeReadyChecker) (ready int, err error) {
+func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, healthyObservations map[string]int, checkers ...types.NodeReadyChecker) (ready int, err error) {
 	machines, err := c.clusterClient.GetMachines(ctx, managementCluster, clusterName)
 	if err != nil {
 		return 0, fmt.Errorf("getting machines resources from management cluster: %v", err)
@@ -968,20 +2606,53 @@ func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster
 			continue
 		}
 
-		passed := true
-		for _, checker := range checkers {
-			if !checker(m.Status) {
-				passed = false
-				break
-			}
+		if !machineReady(m, checkers...) {
+			healthyObservations[m.Metadata.Name] = 0
+			continue
 		}
-		if passed {
+
+		healthyObservations[m.Metadata.Name]++
+		if c.machineHealthConfirmations <= 1 || healthyObservations[m.Metadata.Name] >= c.machineHealthConfirmations {
 			ready += 1
 		}
 	}
 	return ready, nil
 }
 
+// CountReadyControlPlaneNodes returns the number of control plane machines of clusterName that have a NodeRef
+// and are NodeHealthy, along with the desired number of control plane replicas. Any conditions configured with
+// WithRequiredMachineConditions must be "True" too. It checks the machines once without waiting.
+func (c *ClusterManager) CountReadyControlPlaneNodes(ctx context.Context, cluster *types.Cluster, clusterName string) (ready, total int, err error) {
+	labels := []string{clusterv1.MachineControlPlaneLabelName}
+	total, err = c.getNodesCount(ctx, cluster, clusterName, labels)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting the total count of control plane nodes: %v", err)
+	}
+
+	machines, err := c.clusterClient.GetMachines(ctx, cluster, clusterName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting machines resources from management cluster: %v", err)
+	}
+
+	checkers := c.machineReadyCheckers(types.WithNodeRef(), types.WithNodeHealthy())
+	for _, m := range machines {
+		if m.HasAnyLabel(labels) && machineReady(m, checkers...) {
+			ready++
+		}
+	}
+
+	return ready, total, nil
+}
+
+func machineReady(m types.Machine, checkers ...types.NodeReadyChecker) bool {
+	for _, checker := range checkers {
+		if !checker(m.Status) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ClusterManager) waitForAllControlPlanes(ctx context.Context, cluster *types.Cluster, waitForCluster time.Duration) error {
 	clusters, err := c.clusterClient.GetClusters(ctx, cluster)
 	if err != nil {
@@ -998,14 +2669,24 @@ func (c *ClusterManager) waitForAllControlPlanes(ctx context.Context, cluster *t
 	return nil
 }
 
-func (c *ClusterManager) waitForAllClustersReady(ctx context.Context, cluster *types.Cluster, waitStr string) error {
+// WaitForClusterCondition waits for the CAPI cluster clusterName to have the given condition, retrying the wait
+// if it fails.
+func (c *ClusterManager) WaitForClusterCondition(ctx context.Context, cluster *types.Cluster, clusterName, conditionType string, timeout time.Duration) error {
+	return c.retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.WaitForClusterCondition(ctx, cluster, timeout.String(), conditionType, clusterName)
+		},
+	)
+}
+
+func (c *ClusterManager) waitForAllClustersReady(ctx context.Context, cluster *types.Cluster, waitTimeout time.Duration) error {
 	clusters, err := c.clusterClient.GetClusters(ctx, cluster)
 	if err != nil {
 		return fmt.Errorf("getting clusters: %v", err)
 	}
 
 	for _, clu := range clusters {
-		err = c.clusterClient.WaitForClusterReady(ctx, cluster, waitStr, clu.Metadata.Name)
+		err = c.clusterClient.WaitForClusterCondition(ctx, cluster, waitTimeout.String(), "Ready", clu.Metadata.Name)
 		if err != nil {
 			return fmt.Errorf("waiting for cluster %s to be ready: %v", clu.Metadata.Name, err)
 		}
@@ -1024,6 +2705,165 @@ func machineDeploymentsToDelete(currentSpec, newSpec *cluster.Spec) []string {
 	return machineDeployments
 }
 
+// applyWorkerNodeGroups applies the worker node groups capi spec. If a worker group rollout pause is configured,
+// groups are applied one at a time, waiting for each one to finish rolling out and pausing before starting the next one.
+func (c *ClusterManager) applyWorkerNodeGroups(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, mdContent []byte) error {
+	if c.workerGroupRolloutPause == 0 {
+		if err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace); err != nil {
+			return fmt.Errorf("applying capi machine deployment spec: %v", err)
+		}
+		return nil
+	}
+
+	groups, err := splitWorkerNodeGroupsSpec(mdContent)
+	if err != nil {
+		return fmt.Errorf("splitting capi machine deployment spec by worker node group: %v", err)
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			logger.V(3).Info("Pausing before rolling out next worker node group", "pause", c.workerGroupRolloutPause)
+			if err = c.sleep(ctx, c.workerGroupRolloutPause); err != nil {
+				return fmt.Errorf("pausing before rolling out next worker node group: %v", err)
+			}
+		}
+
+		if err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, group.content, constants.EksaSystemNamespace); err != nil {
+			return fmt.Errorf("applying capi machine deployment spec: %v", err)
+		}
+
+		if group.machineDeploymentName == "" {
+			continue
+		}
+
+		logger.V(3).Info("Waiting for worker node group machine deployment to roll out", "machineDeployment", group.machineDeploymentName, "group", i+1, "total", len(groups))
+		if err = c.waitForMachineDeploymentRollout(ctx, managementCluster, clusterSpec, group.machineDeploymentName); err != nil {
+			return fmt.Errorf("waiting for worker node group machinedeployment %s to roll out: %v", group.machineDeploymentName, err)
+		}
+	}
+
+	return nil
+}
+
+// waitForMachineDeploymentRollout waits until the MachineDeployment controller has observed the latest
+// generation of the MachineDeployment and all its replicas are updated and ready.
+func (c *ClusterManager) waitForMachineDeploymentRollout(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, machineDeploymentName string) error {
+	isRolledOut := func() error {
+		md, err := c.clusterClient.GetMachineDeployment(ctx, machineDeploymentName, executables.WithKubeconfig(managementCluster.KubeconfigFile), executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return err
+		}
+		if md.Status.ObservedGeneration < md.Generation {
+			return fmt.Errorf("generation %d has not been observed yet", md.Generation)
+		}
+		replicas := int32(1)
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+		if md.Status.Replicas != replicas || md.Status.UpdatedReplicas != replicas || md.Status.ReadyReplicas != replicas {
+			return fmt.Errorf("%d of %d replicas are updated and %d are ready", md.Status.UpdatedReplicas, replicas, md.Status.ReadyReplicas)
+		}
+		return nil
+	}
+
+	var replicasCount int
+	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if clusterapi.MachineDeploymentName(clusterSpec.Cluster, workerNodeGroupConfiguration) == machineDeploymentName && workerNodeGroupConfiguration.Count != nil {
+			replicasCount = *workerNodeGroupConfiguration.Count
+		}
+	}
+
+	timeout := time.Duration(replicasCount) * c.machineMaxWait
+	if timeout <= c.machinesMinWait {
+		timeout = c.machinesMinWait
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, c.machineBackoff
+	}))
+	if err := r.RetryWithContext(ctx, isRolledOut); err != nil {
+		return fmt.Errorf("retries exhausted waiting for machinedeployment to roll out: %v", err)
+	}
+	return nil
+}
+
+// sleepWithContext pauses for d, returning early with the context error if ctx is done first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// workerNodeGroupSpec is the capi spec of a single worker node group.
+type workerNodeGroupSpec struct {
+	// machineDeploymentName is empty when the spec doesn't contain a MachineDeployment.
+	machineDeploymentName string
+	content               []byte
+}
+
+// splitWorkerNodeGroupsSpec groups the objects in a workers capi spec by the MachineDeployment referencing them.
+// Objects not referenced by any MachineDeployment are included with the first group.
+func splitWorkerNodeGroupsSpec(content []byte) ([]workerNodeGroupSpec, error) {
+	objs, err := unstructuredutil.YamlToUnstructured(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var machineDeployments []unstructured.Unstructured
+	groupForRef := map[string]int{}
+	for _, o := range objs {
+		if o.GetKind() != "MachineDeployment" {
+			continue
+		}
+		for _, path := range [][]string{
+			{"spec", "template", "spec", "bootstrap", "configRef"},
+			{"spec", "template", "spec", "infrastructureRef"},
+		} {
+			ref, found, err := unstructured.NestedStringMap(o.Object, path...)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s from MachineDeployment %s: %v", strings.Join(path, "."), o.GetName(), err)
+			}
+			if found {
+				groupForRef[ref["kind"]+"/"+ref["name"]] = len(machineDeployments)
+			}
+		}
+		machineDeployments = append(machineDeployments, o)
+	}
+
+	if len(machineDeployments) == 0 {
+		return []workerNodeGroupSpec{{content: content}}, nil
+	}
+
+	groups := make([][]unstructured.Unstructured, len(machineDeployments))
+	for i, md := range machineDeployments {
+		groups[i] = append(groups[i], md)
+	}
+	for _, o := range objs {
+		if o.GetKind() == "MachineDeployment" {
+			continue
+		}
+		i := groupForRef[o.GetKind()+"/"+o.GetName()]
+		groups[i] = append(groups[i], o)
+	}
+
+	specs := make([]workerNodeGroupSpec, 0, len(groups))
+	for i, g := range groups {
+		spec, err := unstructuredutil.UnstructuredToYaml(g)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, workerNodeGroupSpec{machineDeploymentName: machineDeployments[i].GetName(), content: spec})
+	}
+
+	return specs, nil
+}
+
 func (c *ClusterManager) removeOldWorkerNodeGroups(ctx context.Context, workloadCluster *types.Cluster, provider providers.Provider, currentSpec, newSpec *cluster.Spec) error {
 	machineDeployments := machineDeploymentsToDelete(currentSpec, newSpec)
 	for _, machineDeploymentName := range machineDeployments {
@@ -1078,12 +2918,78 @@ func (c *ClusterManager) CreateEKSAResources(ctx context.Context, cluster *types
 	}
 	logger.V(4).Info("Applying eksa yaml resources to cluster")
 	logger.V(6).Info(string(resourcesSpec))
+	if c.serverSideApply {
+		return c.serverSideApplyEKSAResources(ctx, cluster, clusterSpec, resourcesSpec)
+	}
 	if err = c.applyResource(ctx, cluster, resourcesSpec); err != nil {
 		return err
 	}
 	return c.ApplyBundles(ctx, clusterSpec, cluster)
 }
 
+// WaitForEKSAClusterReady waits until the CAPI Cluster backing the EKS-A Cluster name in cluster has its Ready
+// condition set to True, checking every machine backoff period for up to timeout. The EKS-A Cluster status is
+// checked as well so a failure reported by the EKS-A controller is surfaced in the error. Callers can use it
+// after CreateEKSAResources to make sure the cluster is up before moving on.
+func (c *ClusterManager) WaitForEKSAClusterReady(ctx context.Context, cluster *types.Cluster, name string, timeout time.Duration) error {
+	policy := func(_ int, _ error) (bool, time.Duration) {
+		return true, c.machineBackoff
+	}
+
+	isReady := func() error {
+		status, err := c.clusterClient.GetEksaClusterStatus(ctx, cluster, name)
+		if err != nil {
+			return err
+		}
+
+		if status.FailureMessage != nil {
+			return fmt.Errorf("eks-a cluster %s is not ready: %s", name, *status.FailureMessage)
+		}
+
+		capiClusters, err := c.clusterClient.GetClusters(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		for _, capiCluster := range capiClusters {
+			if capiCluster.Metadata.Name != name {
+				continue
+			}
+
+			for _, condition := range capiCluster.Status.Conditions {
+				if condition.Type == "Ready" && condition.Status == "True" {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("eks-a cluster %s is not ready", name)
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
+	if err := r.RetryWithContext(ctx, isReady); err != nil {
+		return fmt.Errorf("waiting for eks-a cluster to be ready: %v", err)
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) serverSideApplyEKSAResources(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, resourcesSpec []byte) error {
+	if err := c.clusterClient.ServerSideApplyKubeSpecFromBytes(ctx, cluster, resourcesSpec, "", cliFieldManager); err != nil {
+		return fmt.Errorf("applying eks-a spec: %v", err)
+	}
+
+	bundleObj, err := yaml.Marshal(clusterSpec.Bundles)
+	if err != nil {
+		return fmt.Errorf("outputting bundle yaml: %v", err)
+	}
+	logger.V(1).Info("Applying Bundles to cluster")
+	if err = c.clusterClient.ServerSideApplyKubeSpecFromBytes(ctx, cluster, bundleObj, "", cliFieldManager); err != nil {
+		return fmt.Errorf("applying bundle spec: %v", err)
+	}
+	return nil
+}
+
 func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	bundleObj, err := yaml.Marshal(clusterSpec.Bundles)
 	if err != nil {
@@ -1097,6 +3003,54 @@ func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.
 	return nil
 }
 
+// SetNodeGroupMaintenance excludes the machines of a worker node group from MachineHealthCheck remediation
+// when enabled, by annotating them with the CAPI skip-remediation annotation. When disabled, the annotation
+// is removed so the machines are remediated again.
+func (c *ClusterManager) SetNodeGroupMaintenance(ctx context.Context, cluster *types.Cluster, clusterName, groupName string, enabled bool) error {
+	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, cluster, clusterName)
+	if err != nil {
+		return fmt.Errorf("getting eks-a cluster to set node group maintenance: %v", err)
+	}
+
+	var group *v1alpha1.WorkerNodeGroupConfiguration
+	for i := range eksaCluster.Spec.WorkerNodeGroupConfigurations {
+		if eksaCluster.Spec.WorkerNodeGroupConfigurations[i].Name == groupName {
+			group = &eksaCluster.Spec.WorkerNodeGroupConfigurations[i]
+			break
+		}
+	}
+	if group == nil {
+		return fmt.Errorf("worker node group %s not found in cluster %s", groupName, clusterName)
+	}
+
+	machineDeploymentName := clusterapi.MachineDeploymentName(eksaCluster, *group)
+	machines, err := c.clusterClient.GetMachinesForMachineDeployment(ctx, cluster, machineDeploymentName)
+	if err != nil {
+		return fmt.Errorf("getting machines for node group %s: %v", groupName, err)
+	}
+
+	for _, m := range machines {
+		if enabled {
+			err = c.clusterClient.UpdateAnnotationInNamespace(ctx, capiMachineResourceType, m.Metadata.Name,
+				map[string]string{clusterv1.MachineSkipRemediationAnnotation: ""},
+				cluster,
+				constants.EksaSystemNamespace,
+			)
+		} else {
+			err = c.clusterClient.RemoveAnnotationInNamespace(ctx, capiMachineResourceType, m.Metadata.Name,
+				clusterv1.MachineSkipRemediationAnnotation,
+				cluster,
+				constants.EksaSystemNamespace,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("updating maintenance annotation for machine %s: %v", m.Metadata.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
 	if clusterSpec.Cluster.IsSelfManaged() {
 		return c.pauseEksaReconcileForManagementAndWorkloadClusters(ctx, cluster, clusterSpec, provider)
@@ -1105,7 +3059,29 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 	return c.pauseReconcileForCluster(ctx, cluster, clusterSpec.Cluster, provider)
 }
 
+// PauseEKSAControllerReconcileForClusters pauses the EKS-A controller reconciliation for the management cluster in
+// clusterSpec and only the workload clusters it manages with the given names, leaving the other workload clusters untouched.
+func (c *ClusterManager) PauseEKSAControllerReconcileForClusters(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider, names []string) error {
+	selected := make(map[string]bool, len(names)+1)
+	selected[clusterSpec.Cluster.Name] = true
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	return c.pauseEksaReconcileForManagedClusters(ctx, managementCluster, clusterSpec, provider, func(cluster *v1alpha1.Cluster) bool {
+		return selected[cluster.Name]
+	})
+}
+
 func (c *ClusterManager) pauseEksaReconcileForManagementAndWorkloadClusters(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	return c.pauseEksaReconcileForManagedClusters(ctx, managementCluster, clusterSpec, provider, func(*v1alpha1.Cluster) bool {
+		return true
+	})
+}
+
+// pauseEksaReconcileForManagedClusters pauses the reconciliation for the clusters managed by the management
+// cluster in clusterSpec, including itself, for which include returns true.
+func (c *ClusterManager) pauseEksaReconcileForManagedClusters(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider, include func(*v1alpha1.Cluster) bool) error {
 	clusters := &v1alpha1.ClusterList{}
 	err := c.clusterClient.ListObjects(ctx, eksaClusterResourceType, clusterSpec.Cluster.Namespace, managementCluster.KubeconfigFile, clusters)
 	if err != nil {
@@ -1113,7 +3089,7 @@ func (c *ClusterManager) pauseEksaReconcileForManagementAndWorkloadClusters(ctx
 	}
 
 	for _, w := range clusters.Items {
-		if w.ManagedBy() != clusterSpec.Cluster.Name {
+		if w.ManagedBy() != clusterSpec.Cluster.Name || !include(&w) {
 			continue
 		}
 
@@ -1164,19 +3140,50 @@ func (c *ClusterManager) resumeEksaReconcileForManagementAndWorkloadClusters(ctx
 		return err
 	}
 
+	resumeErr := &ResumeReconcileError{}
 	for _, w := range clusters.Items {
 		if w.ManagedBy() != clusterSpec.Cluster.Name {
 			continue
 		}
 
 		if err := c.resumeReconcileForCluster(ctx, managementCluster, &w, provider); err != nil {
-			return err
+			resumeErr.failed = append(resumeErr.failed, w.Name)
+			resumeErr.errs = append(resumeErr.errs, fmt.Errorf("cluster %s: %v", w.Name, err))
+			continue
 		}
+		resumeErr.resumed = append(resumeErr.resumed, w.Name)
+	}
+
+	if len(resumeErr.failed) > 0 {
+		return resumeErr
 	}
 
 	return nil
 }
 
+// ResumeReconcileError is returned by ResumeEKSAControllerReconcile for a management cluster when
+// resuming the reconciliation of some of its clusters fails. The reconciliation of the rest of the
+// clusters is still resumed, so only the failed ones need to be retried.
+type ResumeReconcileError struct {
+	resumed []string
+	failed  []string
+	errs    []error
+}
+
+func (e *ResumeReconcileError) Error() string {
+	return fmt.Sprintf("resuming reconciliation for clusters %s: %v", strings.Join(e.failed, ", "), utilerrors.NewAggregate(e.errs))
+}
+
+// ResumedClusters returns the names of the clusters whose reconciliation was resumed.
+func (e *ResumeReconcileError) ResumedClusters() []string {
+	return e.resumed
+}
+
+// FailedClusters returns the names of the clusters whose reconciliation couldn't be resumed.
+func (e *ResumeReconcileError) FailedClusters() []string {
+	return e.failed
+}
+
 func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
 	// clear pause annotation
 	clusterSpec.Cluster.ClearPauseAnnotation()
@@ -1223,6 +3230,41 @@ func (c *ClusterManager) resumeReconcileForCluster(ctx context.Context, clusterC
 	return nil
 }
 
+// EnsureClusterPaused makes sure the paused and managed by CLI annotations of the EKS-A Cluster name are set
+// when paused is true and removed otherwise. Unlike PauseEKSAControllerReconcile and ResumeEKSAControllerReconcile,
+// it doesn't touch the provider datacenter and machine configs, and it's a no-op if the annotations are already
+// in the desired state.
+func (c *ClusterManager) EnsureClusterPaused(ctx context.Context, cluster *types.Cluster, name string, paused bool) error {
+	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, cluster, name)
+	if err != nil {
+		return fmt.Errorf("getting eks-a cluster to ensure reconciliation is paused: %v", err)
+	}
+
+	_, managedByCLI := eksaCluster.Annotations[v1alpha1.ManagedByCLIAnnotation]
+	if eksaCluster.IsReconcilePaused() == paused && managedByCLI == paused {
+		return nil
+	}
+
+	if paused {
+		annotations := map[string]string{
+			eksaCluster.PausedAnnotation():  "true",
+			v1alpha1.ManagedByCLIAnnotation: "true",
+		}
+		if err = c.clusterClient.UpdateAnnotationInNamespace(ctx, eksaCluster.ResourceType(), name, annotations, cluster, eksaCluster.Namespace); err != nil {
+			return fmt.Errorf("updating annotations when pausing cluster reconciliation: %v", err)
+		}
+		return nil
+	}
+
+	for _, key := range []string{eksaCluster.PausedAnnotation(), v1alpha1.ManagedByCLIAnnotation} {
+		if err = c.clusterClient.RemoveAnnotationInNamespace(ctx, eksaCluster.ResourceType(), name, key, cluster, eksaCluster.Namespace); err != nil {
+			return fmt.Errorf("removing annotation %s when resuming cluster reconciliation: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) applyResource(ctx context.Context, cluster *types.Cluster, resourcesSpec []byte) error {
 	err := c.clusterClient.ApplyKubeSpecFromBytesForce(ctx, cluster, resourcesSpec)
 	if err != nil {
@@ -1233,6 +3275,9 @@ func (c *ClusterManager) applyResource(ctx context.Context, cluster *types.Clust
 
 func (c *ClusterManager) GetCurrentClusterSpec(ctx context.Context, clus *types.Cluster, clusterName string) (*cluster.Spec, error) {
 	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, clus, clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("EKS-A cluster %s not found; it may need to be adopted or recreated: %v", clusterName, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed getting EKS-A cluster to build current cluster Spec: %v", err)
 	}
@@ -1246,13 +3291,25 @@ func (c *ClusterManager) buildSpecForCluster(ctx context.Context, clus *types.Cl
 
 func (c *ClusterManager) bundlesFetcher(cluster *types.Cluster) cluster.BundlesFetch {
 	return func(ctx context.Context, name, namespace string) (*releasev1alpha1.Bundles, error) {
-		return c.clusterClient.GetBundles(ctx, cluster.KubeconfigFile, name, namespace)
+		fetch := func(ctx context.Context) (*releasev1alpha1.Bundles, error) {
+			return c.clusterClient.GetBundles(ctx, cluster.KubeconfigFile, name, namespace)
+		}
+		if c.currentSpecCache == nil {
+			return fetch(ctx)
+		}
+		return c.currentSpecCache.getBundles(ctx, cluster.Name, name, namespace, fetch)
 	}
 }
 
 func (c *ClusterManager) eksdReleaseFetcher(cluster *types.Cluster) cluster.EksdReleaseFetch {
 	return func(ctx context.Context, name, namespace string) (*eksdv1alpha1.Release, error) {
-		return c.clusterClient.GetEksdRelease(ctx, name, namespace, cluster.KubeconfigFile)
+		fetch := func(ctx context.Context) (*eksdv1alpha1.Release, error) {
+			return c.clusterClient.GetEksdRelease(ctx, name, namespace, cluster.KubeconfigFile)
+		}
+		if c.currentSpecCache == nil {
+			return fetch(ctx)
+		}
+		return c.currentSpecCache.getEksdRelease(ctx, cluster.Name, name, namespace, fetch)
 	}
 }
 
@@ -2,17 +2,28 @@ package clustermanager_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -32,10 +43,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 var (
 	eksaClusterResourceType           = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	capiProviderResourceType          = fmt.Sprintf("providers.%s", clusterctlv1.GroupVersion.Group)
 	eksaVSphereDatacenterResourceType = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaVSphereMachineResourceType    = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	expectedPauseAnnotation           = map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}
@@ -72,6 +86,62 @@ func TestClusterManagerInstallNetworkingNetworkingError(t *testing.T) {
 	}
 }
 
+func TestClusterManagerUpgradeNetworkingCNIVersionBump(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "kubeconfig"}
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.Cilium.Version = "v1.9.10-eksa.1"
+	})
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.Cilium.Version = "v1.9.11-eksa.1"
+	})
+	wantDiff := types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: "cilium",
+		OldVersion:    "v1.9.10-eksa.1",
+		NewVersion:    "v1.9.11-eksa.1",
+	})
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GetDeployments().Return(map[string][]string{"capt-system": {"capt-controller-manager"}})
+	m.networking.EXPECT().Upgrade(ctx, workloadCluster, currentSpec, newSpec, []string{"capt-system"}).Return(wantDiff, nil)
+
+	diff, err := c.UpgradeNetworking(ctx, workloadCluster, currentSpec, newSpec, m.provider)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal(wantDiff))
+}
+
+func TestClusterManagerUpgradeNetworkingNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	currentSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec()
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GetDeployments()
+	m.networking.EXPECT().Upgrade(ctx, workloadCluster, currentSpec, newSpec, []string{}).Return(nil, nil)
+
+	diff, err := c.UpgradeNetworking(ctx, workloadCluster, currentSpec, newSpec, m.provider)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeNil())
+}
+
+func TestClusterManagerUpgradeNetworkingError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	currentSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec()
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GetDeployments()
+	m.networking.EXPECT().Upgrade(ctx, workloadCluster, currentSpec, newSpec, []string{}).Return(nil, errors.New("error in networking"))
+
+	_, err := c.UpgradeNetworking(ctx, workloadCluster, currentSpec, newSpec, m.provider)
+	g.Expect(err).To(MatchError(ContainSubstring("error in networking")))
+}
+
 type storageClassProviderMock struct {
 	providers.Provider
 	Called bool
@@ -126,6 +196,15 @@ func TestClusterManagerInstallStorageClass(t *testing.T) {
 	}
 }
 
+func TestClusterManagerInstallStorageClassError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, m := newClusterManager(t)
+	provider := &storageClassProviderMock{Provider: m.provider, Return: errors.New("connection refused")}
+
+	g.Expect(c.InstallStorageClass(ctx, &types.Cluster{}, provider)).To(MatchError("installing storage class: connection refused"))
+}
+
 func TestClusterManagerCAPIWaitForDeploymentStackedEtcd(t *testing.T) {
 	ctx := context.Background()
 	clusterObj := &types.Cluster{}
@@ -180,6 +259,180 @@ func TestClusterManagerCAPIWaitForDeploymentExternalEtcd(t *testing.T) {
 	}
 }
 
+func TestClusterManagerCAPIWaitForDeploymentCustomTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{}
+	c, m := newClusterManager(t, clustermanager.WithCAPIDeploymentWaitTimeout(45*time.Minute))
+	clusterSpecExternalEtcd := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	})
+	m.client.EXPECT().InitInfrastructure(ctx, clusterSpecExternalEtcd, clusterObj, m.provider)
+	for namespace, deployments := range internal.CAPIDeployments {
+		for _, deployment := range deployments {
+			m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "45m0s", "Available", deployment, namespace)
+		}
+	}
+	for namespace, deployments := range internal.ExternalEtcdDeployments {
+		for _, deployment := range deployments {
+			m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "45m0s", "Available", deployment, namespace)
+		}
+	}
+	providerDeployments := map[string][]string{"capt-system": {"capt-controller-manager"}}
+	m.provider.EXPECT().GetDeployments().Return(providerDeployments)
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "45m0s", "Available", "capt-controller-manager", "capt-system")
+
+	if err := c.InstallCAPI(ctx, clusterSpecExternalEtcd, clusterObj, m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerCAPIWaitForDeploymentNoTimeoutsOverridesCustomTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{}
+	c, m := newClusterManager(t, clustermanager.WithCAPIDeploymentWaitTimeout(45*time.Minute), clustermanager.WithNoTimeouts())
+	clusterSpecStackedEtcd := test.NewClusterSpec()
+	maxTime := time.Duration(math.MaxInt64).String()
+
+	m.client.EXPECT().InitInfrastructure(ctx, clusterSpecStackedEtcd, clusterObj, m.provider)
+	for namespace, deployments := range internal.CAPIDeployments {
+		for _, deployment := range deployments {
+			m.client.EXPECT().WaitForDeployment(ctx, clusterObj, maxTime, "Available", deployment, namespace)
+		}
+	}
+	m.provider.EXPECT().GetDeployments().Return(map[string][]string{})
+
+	if err := c.InstallCAPI(ctx, clusterSpecStackedEtcd, clusterObj, m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func deploymentsCount(deploymentsByNamespace map[string][]string) int {
+	count := 0
+	for _, deployments := range deploymentsByNamespace {
+		count += len(deployments)
+	}
+	return count
+}
+
+func TestClusterManagerCAPIWaitForDeploymentWaitsAllAndReturnsFirstFailure(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	clusterObj := &types.Cluster{}
+	c, m := newClusterManager(t)
+	clusterSpec := test.NewClusterSpec()
+
+	m.client.EXPECT().InitInfrastructure(ctx, clusterSpec, clusterObj, m.provider)
+	for namespace, deployments := range internal.CAPIDeployments {
+		for _, deployment := range deployments {
+			m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", deployment, namespace)
+		}
+	}
+	providerDeployments := map[string][]string{
+		"capt-system":   {"capt-controller-manager"},
+		"rufio-system":  {"rufio-controller-manager"},
+		"tink-system":   {"tink-controller-manager"},
+		"boots-system":  {"boots"},
+		"capt-system-2": {"capt-webhook"},
+	}
+	m.provider.EXPECT().GetDeployments().Return(providerDeployments)
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", "capt-controller-manager", "capt-system")
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", "boots", "boots-system")
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", "capt-webhook", "capt-system-2")
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", "rufio-controller-manager", "rufio-system").Return(errors.New("rufio time out"))
+	m.client.EXPECT().WaitForDeployment(ctx, clusterObj, "30m0s", "Available", "tink-controller-manager", "tink-system").Return(errors.New("tink time out"))
+
+	g.Expect(c.InstallCAPI(ctx, clusterSpec, clusterObj, m.provider)).To(
+		MatchError("waiting for rufio-controller-manager in namespace rufio-system: rufio time out"),
+	)
+}
+
+func expectCAPIProviders(ctx context.Context, m *clusterManagerMocks, kubeconfig string, installed map[string]clusterctlv1.Provider) {
+	for _, namespace := range []string{
+		constants.CapiSystemNamespace,
+		constants.CapiKubeadmBootstrapSystemNamespace,
+		constants.CapiKubeadmControlPlaneSystemNamespace,
+		constants.EtcdAdmBootstrapProviderSystemNamespace,
+		constants.EtcdAdmControllerSystemNamespace,
+	} {
+		provider, ok := installed[namespace]
+		m.client.EXPECT().
+			ListObjects(ctx, capiProviderResourceType, namespace, kubeconfig, &clusterctlv1.ProviderList{}).
+			DoAndReturn(func(_ context.Context, _, _, _ string, list *clusterctlv1.ProviderList) error {
+				if ok {
+					list.Items = append(list.Items, provider)
+				}
+				return nil
+			})
+	}
+}
+
+func capiProvidersSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.ClusterAPI.Version = "v1.3.5"
+		s.VersionsBundle.Bootstrap.Version = "v1.3.5"
+		s.VersionsBundle.ControlPlane.Version = "v1.3.5"
+		s.VersionsBundle.ExternalEtcdBootstrap.Version = "v1.0.6"
+		s.VersionsBundle.ExternalEtcdController.Version = "v1.0.5"
+	})
+}
+
+func TestClusterManagerValidateCAPIProviderVersionsSuccess(t *testing.T) {
+	ctx := context.Background()
+	managementCluster := &types.Cluster{KubeconfigFile: "mgmt.kubeconfig"}
+	c, m := newClusterManager(t)
+	clusterSpec := capiProvidersSpec()
+
+	expectCAPIProviders(ctx, m, managementCluster.KubeconfigFile, map[string]clusterctlv1.Provider{
+		constants.CapiSystemNamespace:                    {ProviderName: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v1.3.5"},
+		constants.CapiKubeadmBootstrapSystemNamespace:    {ProviderName: "kubeadm", Type: string(clusterctlv1.BootstrapProviderType), Version: "v1.3.5"},
+		constants.CapiKubeadmControlPlaneSystemNamespace: {ProviderName: "kubeadm", Type: string(clusterctlv1.ControlPlaneProviderType), Version: "v1.3.5"},
+	})
+
+	if err := c.ValidateCAPIProviderVersions(ctx, managementCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.ValidateCAPIProviderVersions() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerValidateCAPIProviderVersionsMismatch(t *testing.T) {
+	ctx := context.Background()
+	managementCluster := &types.Cluster{KubeconfigFile: "mgmt.kubeconfig"}
+	c, m := newClusterManager(t)
+	clusterSpec := capiProvidersSpec()
+
+	m.client.EXPECT().
+		ListObjects(ctx, capiProviderResourceType, constants.CapiSystemNamespace, managementCluster.KubeconfigFile, &clusterctlv1.ProviderList{}).
+		Return(nil)
+	m.client.EXPECT().
+		ListObjects(ctx, capiProviderResourceType, constants.CapiKubeadmBootstrapSystemNamespace, managementCluster.KubeconfigFile, &clusterctlv1.ProviderList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list *clusterctlv1.ProviderList) error {
+			list.Items = []clusterctlv1.Provider{
+				{ProviderName: "kubeadm", Type: string(clusterctlv1.BootstrapProviderType), Version: "v1.2.0"},
+			}
+			return nil
+		})
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateCAPIProviderVersions(ctx, managementCluster, clusterSpec)).To(
+		MatchError(ContainSubstring("CAPI BootstrapProvider kubeadm version mismatch: installed version v1.2.0, bundle version v1.3.5")),
+	)
+}
+
+func TestClusterManagerValidateCAPIProviderVersionsListError(t *testing.T) {
+	ctx := context.Background()
+	managementCluster := &types.Cluster{KubeconfigFile: "mgmt.kubeconfig"}
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	clusterSpec := capiProvidersSpec()
+
+	m.client.EXPECT().
+		ListObjects(ctx, capiProviderResourceType, constants.CapiSystemNamespace, managementCluster.KubeconfigFile, &clusterctlv1.ProviderList{}).
+		Return(errors.New("error listing"))
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateCAPIProviderVersions(ctx, managementCluster, clusterSpec)).To(
+		MatchError(ContainSubstring("listing CAPI providers in namespace capi-system: error listing")),
+	)
+}
+
 func TestClusterManagerSaveLogsSuccess(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -217,6 +470,53 @@ func TestClusterManagerSaveLogsSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerSaveLogsWorkloadClusterTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec()
+	workloadCluster := &types.Cluster{
+		Name:           "workload",
+		KubeconfigFile: "workload.kubeconfig",
+	}
+	timeout := 50 * time.Millisecond
+
+	c, m := newClusterManager(t, clustermanager.WithDiagnosticsCollectionTimeout(timeout))
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	b := m.diagnosticsBundle
+	m.diagnosticsFactory.EXPECT().DiagnosticBundleWorkloadCluster(clusterSpec, m.provider, workloadCluster.KubeconfigFile).Return(b, nil)
+	b.EXPECT().CollectAndAnalyze(gomock.Any(), gomock.AssignableToTypeOf(&time.Time{})).DoAndReturn(
+		func(_ context.Context, _ *time.Time) error {
+			<-unblock
+			return nil
+		},
+	)
+
+	start := time.Now()
+	err := c.SaveLogsWorkloadCluster(ctx, m.provider, clusterSpec, workloadCluster)
+	g.Expect(err).To(MatchError(clustermanager.ErrDiagnosticsCollectionTimedOut))
+	g.Expect(time.Since(start)).To(BeNumerically("~", timeout, time.Second))
+}
+
+func TestClusterManagerSaveLogsManagementClusterWithinTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec()
+	bootstrapCluster := &types.Cluster{
+		Name:           "bootstrap",
+		KubeconfigFile: "bootstrap.kubeconfig",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithDiagnosticsCollectionTimeout(time.Minute))
+
+	b := m.diagnosticsBundle
+	m.diagnosticsFactory.EXPECT().DiagnosticBundleManagementCluster(clusterSpec, bootstrapCluster.KubeconfigFile).Return(b, nil)
+	b.EXPECT().CollectAndAnalyze(gomock.Any(), gomock.AssignableToTypeOf(&time.Time{})).Return(errors.New("error collecting"))
+
+	g.Expect(c.SaveLogsManagementCluster(ctx, clusterSpec, bootstrapCluster)).To(Succeed())
+}
+
 func TestClusterManagerCreateWorkloadClusterSuccess(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -246,28 +546,12 @@ func TestClusterManagerCreateWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
-func TestClusterManagerCreateWorkloadClusterErrorGetKubeconfig(t *testing.T) {
-	tt := newTest(t)
-	tt.clusterSpec.Cluster.Name = tt.clusterName
-	gomock.InOrder(
-		tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec),
-		tt.mocks.writer.EXPECT().Write(tt.clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil())),
-		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
-		tt.mocks.client.EXPECT().WaitForControlPlaneAvailable(tt.ctx, tt.cluster, "1h0m0s", tt.clusterName),
-		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return(nil, errors.New("get kubeconfig error")),
-	)
-
-	_, err := tt.clusterManager.CreateWorkloadCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
-	tt.Expect(err).To(MatchError(ContainSubstring("get kubeconfig error")))
-}
-
-func TestClusterManagerCreateWorkloadClusterTimeoutOverrideSuccess(t *testing.T) {
+func TestClusterManagerCreateWorkloadClusterChunkedApply(t *testing.T) {
+	g := NewWithT(t)
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = clusterName
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
 	})
 
 	mgmtCluster := &types.Cluster{
@@ -275,22 +559,40 @@ func TestClusterManagerCreateWorkloadClusterTimeoutOverrideSuccess(t *testing.T)
 		KubeconfigFile: "mgmt-kubeconfig",
 	}
 
-	c, m := newClusterManager(t, clustermanager.WithControlPlaneWaitTimeout(20*time.Minute))
-	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
-	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "20m0s", clusterName)
+	// Each document is 100 bytes, so only two fit in a 250 bytes chunk.
+	doc := func(name string) string {
+		d := fmt.Sprintf("kind: ConfigMap\nmetadata:\n  name: %s\ndata:\n  padding: ", name)
+		return d + strings.Repeat("x", 100-len(d))
+	}
+	cpContent := []byte("---\n" + doc("cp-1") + "\n---\n" + doc("cp-2") + "\n---\n" + doc("cp-3") + "\n---\n" + doc("cp-4") + "\n")
+	mdContent := []byte(doc("md-1") + "\n---\n" + doc("md-2") + "\n")
+
+	c, m := newClusterManager(t, clustermanager.WithChunkedApply(250))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec).Return(cpContent, mdContent, nil)
+	var applied []string
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, gomock.Any(), constants.EksaSystemNamespace).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte, _ string) error {
+			applied = append(applied, string(data))
+			return nil
+		},
+	).Times(3)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
 	kubeconfig := []byte("content")
 	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
 	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
 	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
 	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 
-	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
-		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
-	}
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(applied).To(Equal([]string{
+		doc("cp-1") + "\n---\n" + doc("cp-2"),
+		doc("cp-3") + "\n---\n" + doc("cp-4"),
+		doc("md-1") + "\n---\n" + doc("md-2"),
+	}))
 }
 
-func TestClusterManagerRunPostCreateWorkloadClusterSuccess(t *testing.T) {
+func TestClusterManagerCreateWorkloadClusterWithArtifacts(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
@@ -303,41 +605,33 @@ func TestClusterManagerRunPostCreateWorkloadClusterSuccess(t *testing.T) {
 		Name:           clusterName,
 		KubeconfigFile: "mgmt-kubeconfig",
 	}
-	workloadCluster := &types.Cluster{
-		Name:           clusterName,
-		KubeconfigFile: "workload-kubeconfig",
-	}
-
-	kcp, mds := getKcpAndMdsForNodeCount(0)
 
 	c, m := newClusterManager(t)
-	m.client.EXPECT().GetKubeadmControlPlane(ctx,
-		mgmtCluster,
-		mgmtCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-
-	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		mgmtCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).AnyTimes().Return([]types.Machine{}, nil)
-
-	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
-		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
-	}
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil())).Return("/abs/cluster-name/cluster-name-eks-a-cluster.kubeconfig", nil)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil())).Return("/abs/cluster-name/generated/cluster-name-eks-a-cluster.yaml", nil)
+
+	workloadCluster, artifacts, err := c.CreateWorkloadClusterWithArtifacts(ctx, mgmtCluster, clusterSpec, m.provider)
+	g := NewWithT(t)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workloadCluster.KubeconfigFile).To(Equal("/abs/cluster-name/cluster-name-eks-a-cluster.kubeconfig"))
+	g.Expect(artifacts).To(Equal(&clustermanager.WorkloadClusterArtifacts{
+		KubeconfigFile:  "/abs/cluster-name/cluster-name-eks-a-cluster.kubeconfig",
+		ClusterYAMLFile: "/abs/cluster-name/generated/cluster-name-eks-a-cluster.yaml",
+	}))
 }
 
-func TestClusterManagerCreateWorkloadClusterWithExternalEtcdSuccess(t *testing.T) {
+func TestClusterManagerCreateWorkloadClusterRetriesTransientKubeconfigWriteError(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = clusterName
-		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
 		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
 	})
 
@@ -346,30 +640,31 @@ func TestClusterManagerCreateWorkloadClusterWithExternalEtcdSuccess(t *testing.T
 		KubeconfigFile: "mgmt-kubeconfig",
 	}
 
-	c, m := newClusterManager(t)
+	c, m := newClusterManager(t, clustermanager.WithFileWriteRetries(3, 0))
 	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	m.client.EXPECT().WaitForManagedExternalEtcdReady(ctx, mgmtCluster, "1h0m0s", clusterName)
 	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
 	kubeconfig := []byte("content")
 	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
 	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
-	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
-	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	transientErr := &fs.PathError{Op: "open", Path: clusterName + "-eks-a-cluster.kubeconfig", Err: syscall.EAGAIN}
+	gomock.InOrder(
+		m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil())).Return("", transientErr),
+		m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil())).Return("cluster-name-eks-a-cluster.kubeconfig", nil),
+	)
 
-	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
-		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
-	}
+	workloadCluster, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	g := NewWithT(t)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workloadCluster.KubeconfigFile).To(Equal("cluster-name-eks-a-cluster.kubeconfig"))
 }
 
-func TestClusterManagerCreateWorkloadClusterWithExternalEtcdTimeoutOverrideSuccess(t *testing.T) {
+func TestClusterManagerCreateWorkloadClusterDoesNotRetryPermanentKubeconfigWriteError(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = clusterName
-		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 2
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
 	})
 
 	mgmtCluster := &types.Cluster{
@@ -377,43 +672,469 @@ func TestClusterManagerCreateWorkloadClusterWithExternalEtcdTimeoutOverrideSucce
 		KubeconfigFile: "mgmt-kubeconfig",
 	}
 
-	c, m := newClusterManager(t, clustermanager.WithExternalEtcdWaitTimeout(30*time.Minute))
+	c, m := newClusterManager(t, clustermanager.WithFileWriteRetries(3, 0))
 	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	m.client.EXPECT().WaitForManagedExternalEtcdReady(ctx, mgmtCluster, "30m0s", clusterName)
 	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
 	kubeconfig := []byte("content")
 	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
 	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
-	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
-	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	permissionErr := &fs.PathError{Op: "open", Path: clusterName + "-eks-a-cluster.kubeconfig", Err: syscall.EACCES}
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil())).Return("", permissionErr).Times(1)
 
-	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
-		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	g := NewWithT(t)
+	g.Expect(err).To(MatchError(ContainSubstring("writing workload kubeconfig")))
+}
+
+func TestClusterManagerCreateWorkloadClusterWithArtifactsError(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+	})
+	mgmtCluster := &types.Cluster{
+		Name:           "mgmt",
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec).Return(nil, nil, errors.New("error generating spec"))
+
+	workloadCluster, artifacts, err := c.CreateWorkloadClusterWithArtifacts(ctx, mgmtCluster, clusterSpec, m.provider)
+	g := NewWithT(t)
+	g.Expect(err).To(MatchError("generating capi spec: error generating spec"))
+	g.Expect(workloadCluster).To(BeNil())
+	g.Expect(artifacts).To(BeNil())
 }
 
-func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
+func TestClusterManagerCreateWorkloadClusterWritesCAPIManifestsFile(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = clusterName
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
 	})
 
 	mgmtCluster := &types.Cluster{
 		Name:           clusterName,
 		KubeconfigFile: "mgmt-kubeconfig",
 	}
-	workloadCluster := &types.Cluster{
-		Name:           clusterName,
-		KubeconfigFile: "workload-kubeconfig",
+	cpContent := []byte("kind: KubeadmControlPlane")
+	mdContent := []byte("kind: MachineDeployment")
+	wantContent := []byte("kind: KubeadmControlPlane\n---\nkind: MachineDeployment\n---\n")
+
+	c, m := newClusterManager(t, clustermanager.WithCAPIManifestsFile("cluster-name-capi-manifests.yaml"))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec).Return(cpContent, mdContent, nil)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", wantContent)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, wantContent, constants.EksaSystemNamespace)
+	m.writer.EXPECT().Write("cluster-name-capi-manifests.yaml", wantContent, gomock.Any())
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
+}
 
-	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+func TestClusterManagerCreateWorkloadClusterErrorWritingCAPIManifestsFile(t *testing.T) {
+	tt := newTest(t, clustermanager.WithCAPIManifestsFile("capi-manifests.yaml"))
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.mocks.writer.EXPECT().Write(tt.clusterName+"-eks-a-cluster.yaml", gomock.Any())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, gomock.Any(), constants.EksaSystemNamespace)
+	tt.mocks.writer.EXPECT().Write("capi-manifests.yaml", gomock.Any(), gomock.Any()).Return("", errors.New("disk full"))
 
-	kcp, mds := getKcpAndMdsForNodeCount(1)
+	_, err := tt.clusterManager.CreateWorkloadCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError("writing applied capi manifests file: disk full"))
+}
+
+func TestClusterManagerCreateWorkloadClusterErrorGetKubeconfig(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	gomock.InOrder(
+		tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec),
+		tt.mocks.writer.EXPECT().Write(tt.clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil())),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().WaitForControlPlaneAvailable(tt.ctx, tt.cluster, "1h0m0s", tt.clusterName),
+		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return(nil, errors.New("get kubeconfig error")),
+	)
+
+	_, err := tt.clusterManager.CreateWorkloadCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("get kubeconfig error")))
+}
+
+func TestClusterManagerCreateWorkloadClusterEmptyKubeconfig(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	gomock.InOrder(
+		tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec),
+		tt.mocks.writer.EXPECT().Write(tt.clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil())),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().WaitForControlPlaneAvailable(tt.ctx, tt.cluster, "1h0m0s", tt.clusterName),
+		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return([]byte{}, nil),
+	)
+	tt.mocks.provider.EXPECT().UpdateKubeConfig(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := tt.clusterManager.CreateWorkloadCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(errors.Is(err, clustermanager.ErrEmptyWorkloadKubeconfig)).To(BeTrue(), "error should be ErrEmptyWorkloadKubeconfig")
+	tt.Expect(err).To(MatchError(ContainSubstring(tt.clusterName)))
+}
+
+func TestClusterManagerGetWorkloadClusterKubeconfigSuccess(t *testing.T) {
+	tt := newTest(t)
+	kubeconfig := []byte("content")
+
+	tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return(kubeconfig, nil)
+
+	got, err := tt.clusterManager.GetWorkloadClusterKubeconfig(tt.ctx, tt.clusterName, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal(kubeconfig))
+}
+
+func TestClusterManagerGetWorkloadClusterKubeconfigTransientError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(3, 0)))
+	kubeconfig := []byte("content")
+
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return(nil, errors.New("connection refused")),
+		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return([]byte{}, nil),
+		tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return(kubeconfig, nil),
+	)
+
+	got, err := tt.clusterManager.GetWorkloadClusterKubeconfig(tt.ctx, tt.clusterName, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal(kubeconfig))
+}
+
+func TestClusterManagerGetWorkloadClusterKubeconfigEmpty(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+
+	tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, tt.cluster).Return([]byte{}, nil).Times(2)
+
+	_, err := tt.clusterManager.GetWorkloadClusterKubeconfig(tt.ctx, tt.clusterName, tt.cluster)
+	tt.Expect(errors.Is(err, clustermanager.ErrEmptyWorkloadKubeconfig)).To(BeTrue(), "error should be ErrEmptyWorkloadKubeconfig")
+	tt.Expect(err).To(MatchError(ContainSubstring(tt.clusterName)))
+}
+
+func TestClusterManagerCreateWorkloadClusterOverallTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithCreateClusterTimeout(10*time.Millisecond))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(gomock.Any(), mgmtCluster, clusterSpec)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForControlPlaneAvailable(gomock.Any(), mgmtCluster, "1h0m0s", clusterName).DoAndReturn(
+		func(ctx context.Context, _ *types.Cluster, _, _ string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	g := NewWithT(t)
+	g.Expect(err).To(MatchError(ContainSubstring("creating workload cluster timed out after 10ms while waiting for control plane to be available")))
+}
+
+func TestClusterManagerCreateWorkloadClusterTimeoutOverrideSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithControlPlaneWaitTimeout(20*time.Minute))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "20m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerValidateClusterName(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterName string
+		workerName  string
+		wantErr     string
+	}{
+		{
+			name:        "valid name",
+			clusterName: "cluster-name",
+			workerName:  "md-0",
+		},
+		{
+			name:        "not a DNS subdomain",
+			clusterName: "Cluster_Name",
+			workerName:  "md-0",
+			wantErr:     "cluster name Cluster_Name is invalid: a lowercase RFC 1123 subdomain",
+		},
+		{
+			name:        "cluster name too long",
+			clusterName: strings.Repeat("a", 64),
+			workerName:  "md-0",
+			wantErr:     fmt.Sprintf("cluster name %[1]s is too long, derived KubeadmControlPlane name %[1]s must be no more than 63 characters", strings.Repeat("a", 64)),
+		},
+		{
+			name:        "derived machine deployment name too long",
+			clusterName: strings.Repeat("a", 55),
+			workerName:  "workers-large",
+			wantErr: fmt.Sprintf("cluster name %[1]s is too long, derived MachineDeployment workers-large name %[1]s-workers-large must be no more than 63 characters",
+				strings.Repeat("a", 55)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = tt.clusterName
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = tt.workerName
+			})
+			c, _ := newClusterManager(t)
+
+			err := c.ValidateClusterName(clusterSpec)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterManagerCreateWorkloadClusterInvalidName(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = strings.Repeat("a", 64)
+	})
+	mgmtCluster := &types.Cluster{
+		Name:           "mgmt",
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+
+	c, m := newClusterManager(t)
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	NewWithT(t).Expect(err).To(MatchError(ContainSubstring("is too long")))
+}
+
+func TestClusterManagerValidateBundleConsistency(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  releasev1alpha1.VersionsBundle
+		wantErr string
+	}{
+		{
+			name: "consistent",
+			bundle: releasev1alpha1.VersionsBundle{
+				KubeVersion: "1.24",
+				EksD: releasev1alpha1.EksDRelease{
+					Name:           "kubernetes-1-24-eks-5",
+					ReleaseChannel: "1-24",
+					KubeVersion:    "v1.24.7",
+				},
+			},
+		},
+		{
+			name: "bundle for other kube version",
+			bundle: releasev1alpha1.VersionsBundle{
+				KubeVersion: "1.23",
+			},
+			wantErr: "versions bundle is for Kubernetes version 1.23, but cluster Kubernetes version is 1.24",
+		},
+		{
+			name: "eks-d release from other channel",
+			bundle: releasev1alpha1.VersionsBundle{
+				KubeVersion: "1.24",
+				EksD: releasev1alpha1.EksDRelease{
+					Name:           "kubernetes-1-23-eks-7",
+					ReleaseChannel: "1-23",
+					KubeVersion:    "v1.23.13",
+				},
+			},
+			wantErr: "EKS-D release kubernetes-1-23-eks-7 is from channel 1-23, expected channel 1-24 for Kubernetes version 1.24",
+		},
+		{
+			name: "eks-d release with other kube version",
+			bundle: releasev1alpha1.VersionsBundle{
+				KubeVersion: "1.24",
+				EksD: releasev1alpha1.EksDRelease{
+					Name:           "kubernetes-1-24-eks-5",
+					ReleaseChannel: "1-24",
+					KubeVersion:    "v1.23.13",
+				},
+			},
+			wantErr: "EKS-D release kubernetes-1-24-eks-5 has Kubernetes version v1.23.13, which doesn't match cluster Kubernetes version 1.24",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			bundle := tt.bundle
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+				s.VersionsBundle.VersionsBundle = &bundle
+			})
+			c, _ := newClusterManager(t)
+
+			err := c.ValidateBundleConsistency(clusterSpec)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterManagerValidateBundleConsistencyNoVersionsBundle(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+		s.VersionsBundle = nil
+	})
+	c, _ := newClusterManager(t)
+
+	NewWithT(t).Expect(c.ValidateBundleConsistency(clusterSpec)).To(MatchError("no versions bundle found for Kubernetes version 1.24"))
+}
+
+func TestClusterManagerCreateWorkloadClusterControlPlaneEndpointInUse(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	})
+	mgmtCluster := &types.Cluster{
+		Name:               "mgmt",
+		KubeconfigFile:     "mgmt-kubeconfig",
+		ExistingManagement: true,
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().
+		ListObjectsInAllNamespaces(ctx, eksaClusterResourceType, mgmtCluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing-cluster", Namespace: "team-a"},
+					Spec: v1alpha1.ClusterSpec{
+						ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+							Endpoint: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+						},
+					},
+				},
+			}
+			return nil
+		})
+	m.provider.EXPECT().GenerateCAPISpecForCreate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	NewWithT(t).Expect(err).To(MatchError("control plane endpoint 1.2.3.4 is already used by cluster team-a/existing-cluster"))
+}
+
+func TestClusterManagerCreateWorkloadClusterControlPlaneEndpointNotInUse(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	})
+	mgmtCluster := &types.Cluster{
+		Name:               "mgmt",
+		KubeconfigFile:     "mgmt-kubeconfig",
+		ExistingManagement: true,
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().
+		ListObjectsInAllNamespaces(ctx, eksaClusterResourceType, mgmtCluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				// The cluster being created is skipped.
+				*clusterSpec.Cluster.DeepCopy(),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing-cluster", Namespace: "default"},
+					Spec: v1alpha1.ClusterSpec{
+						ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+							Endpoint: &v1alpha1.Endpoint{Host: "1.2.3.5"},
+						},
+					},
+				},
+			}
+			return nil
+		})
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec).Return(nil, nil, errors.New("error generating spec"))
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	NewWithT(t).Expect(err).To(MatchError("generating capi spec: error generating spec"))
+}
+
+func TestClusterManagerCreateWorkloadClusterControlPlaneEndpointListError(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	})
+	mgmtCluster := &types.Cluster{
+		Name:               "mgmt",
+		KubeconfigFile:     "mgmt-kubeconfig",
+		ExistingManagement: true,
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().
+		ListObjectsInAllNamespaces(ctx, eksaClusterResourceType, mgmtCluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		Return(errors.New("error listing"))
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	NewWithT(t).Expect(err).To(MatchError("listing clusters to validate control plane endpoint: error listing"))
+}
+
+func TestClusterManagerRunPostCreateWorkloadClusterSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+
+	c, m := newClusterManager(t)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		mgmtCluster,
 		mgmtCluster.Name,
@@ -427,19 +1148,14 @@ func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesTimeout(t *tes
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
 
-	// Fail once
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(nil, errors.New("error get machines"))
-	// Return a machine with no nodeRef the rest of the retries
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).MinTimes(1).Return([]types.Machine{{Metadata: types.MachineMetadata{
-		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
-	}}}, nil)
-	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err == nil {
-		t.Error("ClusterManager.RunPostCreateWorkloadCluster() error = nil, wantErr not nil", err)
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).AnyTimes().Return([]types.Machine{}, nil)
+
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesSuccessAfterRetries(t *testing.T) {
-	retries := 10
+func TestClusterManagerRunPostCreateWorkloadClusterSkipMachineWait(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
@@ -457,9 +1173,9 @@ func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesSuccessAfterRe
 		KubeconfigFile: "workload-kubeconfig",
 	}
 
-	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(1*time.Minute), clustermanager.WithMachineMinWait(2*time.Minute))
+	kcp, mds := getKcpAndMdsForNodeCount(3)
 
-	kcp, mds := getKcpAndMdsForNodeCount(1)
+	c, m := newClusterManager(t, clustermanager.WithSkipPostCreateMachineWait())
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		mgmtCluster,
 		mgmtCluster.Name,
@@ -473,503 +1189,600 @@ func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesSuccessAfterRe
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
 
-	// Fail a bunch of times
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(retries-5).Return(nil, errors.New("error get machines"))
-	// Return a machine with no nodeRef  times
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(3).Return([]types.Machine{{Metadata: types.MachineMetadata{
-		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
-	}}}, nil)
-	//// Return a machine with nodeRef + NodeHealthy condition and another with it
-	status := types.MachineStatus{
-		NodeRef: &types.ResourceRef{},
-		Conditions: types.Conditions{
-			{
-				Type:   "NodeHealthy",
-				Status: "True",
-			},
-		},
-	}
-	machines := []types.Machine{
-		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
-		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
-	}
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(machines, nil)
-	// Finally return two machines with node ref
-	machines = []types.Machine{
-		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
-		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
-	}
-	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(machines, nil)
-	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
-		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(0)
+
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeSelfManagedClusterSuccess(t *testing.T) {
+func TestClusterManagerRunPostCreateWorkloadClusterPriorityClasses(t *testing.T) {
+	ctx := context.Background()
 	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.PriorityClasses = []v1alpha1.PriorityClass{
+			{Name: "critical-batch", Value: 100000, PreemptionPolicy: "Never", Description: "batch jobs"},
+			{Name: "default-workloads", Value: 1000, GlobalDefault: true},
+		}
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
 	}
 
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt := newSpecChangedTest(t)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).AnyTimes().Return([]types.Machine{}, nil)
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
-	}
-}
+	wantPriorityClasses := `apiVersion: scheduling.k8s.io/v1
+description: batch jobs
+kind: PriorityClass
+metadata:
+  creationTimestamp: null
+  name: critical-batch
+preemptionPolicy: Never
+value: 100000
 
-func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdSuccess(t *testing.T) {
-	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
-	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
-	}
+---
+apiVersion: scheduling.k8s.io/v1
+globalDefault: true
+kind: PriorityClass
+metadata:
+  creationTimestamp: null
+  name: default-workloads
+value: 1000
 
-	tt := newSpecChangedTest(t)
+---
+`
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			if string(data) != wantPriorityClasses {
+				t.Errorf("ApplyKubeSpecFromBytes() data = %s, want %s", data, wantPriorityClasses)
+			}
+			return nil
+		},
+	)
 
-	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
-	}
-	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
+}
 
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+func TestClusterManagerInstallPriorityClassesApplyError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.clusterSpec.Cluster.Spec.PriorityClasses = []v1alpha1.PriorityClass{{Name: "critical-batch", Value: 100000}}
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
-	}
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("error"))
+
+	tt.Expect(tt.clusterManager.InstallPriorityClasses(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError("applying priority classes: error"))
 }
 
-func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdTimeoutNotReadySuccess(t *testing.T) {
-	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
-	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
+func externalEtcdKCP(endpoints ...string) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-name"},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{
+						External: &bootstrapv1.ExternalEtcd{Endpoints: endpoints},
+					},
+				},
+			},
+		},
 	}
+}
 
-	tt := newSpecChangedTest(t)
-
+func TestClusterManagerInstallEtcdBackup(t *testing.T) {
+	tt := newTest(t)
+	mgmtCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	tt.clusterSpec.Cluster.Name = tt.clusterName
 	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
 		Count: 3,
+		Backup: &v1alpha1.EtcdBackupConfiguration{
+			Schedule:    "0 */6 * * *",
+			Destination: "/var/lib/etcd-backups",
+		},
 	}
-	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
-	}
+	tt.clusterSpec.VersionsBundle.KubeDistro.EtcdImage = releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.6-eks-1-25-5"}
+	tt.clusterSpec.VersionsBundle.Eksa.CliTools = releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.14.0"}
 
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("timed out waiting for the condition on clusters"))
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		mgmtCluster,
+		tt.clusterName,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+	).Return(externalEtcdKCP("https://10.0.0.1:2379", "https://10.0.0.2:2379"), nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			cronJob := string(data)
+			tt.Expect(cronJob).To(ContainSubstring("kind: CronJob"))
+			tt.Expect(cronJob).To(ContainSubstring("name: eksa-etcd-backup"))
+			tt.Expect(cronJob).To(ContainSubstring("schedule: 0 */6 * * *"))
+			tt.Expect(cronJob).To(ContainSubstring("path: /var/lib/etcd-backups"))
+			tt.Expect(cronJob).To(ContainSubstring("image: public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.6-eks-1-25-5"))
+			tt.Expect(cronJob).To(ContainSubstring("image: public.ecr.aws/eks-anywhere/cli-tools:v0.14.0"))
+			tt.Expect(cronJob).To(ContainSubstring("- --endpoints=https://10.0.0.1:2379\n"))
+			tt.Expect(cronJob).To(ContainSubstring("- --cert=/pki/apiserver-etcd-client.crt"))
+			tt.Expect(cronJob).To(ContainSubstring("path: /etc/kubernetes/pki\n"))
+			return nil
+		},
+	)
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
-	}
+	tt.Expect(tt.clusterManager.InstallEtcdBackup(tt.ctx, tt.clusterSpec, mgmtCluster, tt.cluster)).To(Succeed())
 }
 
-func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdNotReadyError(t *testing.T) {
-	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
+func TestClusterManagerInstallEtcdBackupBottlerocket(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{
+		Kind: v1alpha1.VSphereMachineConfigKind,
+		Name: "cp-machine",
 	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
+	tt.clusterSpec.VSphereMachineConfigs = map[string]*v1alpha1.VSphereMachineConfig{
+		"cp-machine": {Spec: v1alpha1.VSphereMachineConfigSpec{OSFamily: v1alpha1.Bottlerocket}},
 	}
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:  3,
+		Backup: &v1alpha1.EtcdBackupConfiguration{Schedule: "@daily", Destination: "/var/lib/etcd-backups"},
+	}
+
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.cluster, tt.clusterName, gomock.Any(), gomock.Any()).
+		Return(externalEtcdKCP("https://10.0.0.1:2379"), nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			cronJob := string(data)
+			tt.Expect(cronJob).To(ContainSubstring("- --cert=/pki/server-etcd-client.crt"))
+			tt.Expect(cronJob).To(ContainSubstring("- --key=/pki/apiserver-etcd-client.key"))
+			tt.Expect(cronJob).To(ContainSubstring("path: /var/lib/kubeadm/pki\n"))
+			return nil
+		},
+	)
 
-	tt := newSpecChangedTest(t)
+	tt.Expect(tt.clusterManager.InstallEtcdBackup(tt.ctx, tt.clusterSpec, tt.cluster, tt.cluster)).To(Succeed())
+}
 
-	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
-	}
-	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
-	}
+func TestClusterManagerInstallEtcdBackupNotConfigured(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
 
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("etcd not ready"))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(0)
 
-	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("etcd not ready")))
+	tt.Expect(tt.clusterManager.InstallEtcdBackup(tt.ctx, tt.clusterSpec, tt.cluster, tt.cluster)).To(Succeed())
 }
 
-func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdErrorRemovingAnnotation(t *testing.T) {
-	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
-	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
+func TestClusterManagerInstallEtcdBackupNoEndpoints(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:  3,
+		Backup: &v1alpha1.EtcdBackupConfiguration{Schedule: "@daily", Destination: "/var/lib/etcd-backups"},
 	}
 
-	tt := newSpecChangedTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.cluster, tt.clusterName, gomock.Any(), gomock.Any()).
+		Return(externalEtcdKCP(), nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.InstallEtcdBackup(tt.ctx, tt.clusterSpec, tt.cluster, tt.cluster)).To(MatchError("kubeadm control plane cluster-name has no external etcd endpoints"))
+}
 
+func TestClusterManagerInstallEtcdBackupApplyError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.clusterSpec.Cluster.Name = tt.clusterName
 	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
-	}
-	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
-		Count: 3,
+		Count:  3,
+		Backup: &v1alpha1.EtcdBackupConfiguration{Schedule: "@daily", Destination: "/var/lib/etcd-backups"},
 	}
 
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName).Return(errors.New("timed out"))
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), mCluster, constants.EksaSystemNamespace).Return(errors.New("removing annotation"))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.cluster, tt.clusterName, gomock.Any(), gomock.Any()).
+		Return(externalEtcdKCP("https://10.0.0.1:2379"), nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("error"))
 
-	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("removing annotation")))
+	tt.Expect(tt.clusterManager.InstallEtcdBackup(tt.ctx, tt.clusterSpec, tt.cluster, tt.cluster)).To(MatchError("applying etcd backup cronjob: error"))
 }
 
-func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
-
-	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
-	}
-	wCluster := &types.Cluster{
-		Name: workClusterName,
+func TestClusterManagerCreateWorkloadClusterWithExternalEtcdSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
 
-	tt := newSpecChangedTest(t)
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForManagedExternalEtcdReady(ctx, mgmtCluster, "1h0m0s", clusterName)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterInstallStorageClassSuccess(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
+func TestClusterManagerCreateWorkloadClusterWithExternalEtcdTimeoutOverrideSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
 
-	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
-	}
-	wCluster := &types.Cluster{
-		Name: workClusterName,
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
 
-	tt := newSpecChangedTest(t)
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
-
-	provider := &storageClassProviderMock{Provider: tt.mocks.provider}
-
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
-	}
+	c, m := newClusterManager(t, clustermanager.WithExternalEtcdWaitTimeout(30*time.Minute))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForManagedExternalEtcdReady(ctx, mgmtCluster, "30m0s", clusterName)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 
-	if !provider.Called {
-		t.Error("Missing call to provider's InstallStorageClass method")
+	if _, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterInstallStorageClassError(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
+func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
 
-	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
-	wCluster := &types.Cluster{
-		Name: workClusterName,
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
 	}
 
-	tt := newSpecChangedTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+
+	kcp, mds := getKcpAndMdsForNodeCount(1)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
-
-	provider := &storageClassProviderMock{Provider: tt.mocks.provider, Return: errors.New("error")}
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err == nil {
-		t.Error("ClusterManager.UpgradeCluster() error is nil, wantErr not nil")
+	// Fail once
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(nil, errors.New("error get machines"))
+	// Return a machine with no nodeRef the rest of the retries
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).MinTimes(1).Return([]types.Machine{{Metadata: types.MachineMetadata{
+		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+	}}}, nil)
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err == nil {
+		t.Error("ClusterManager.RunPostCreateWorkloadCluster() error = nil, wantErr not nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterAWSIamConfigSuccess(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
+func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesSuccessAfterRetries(t *testing.T) {
+	retries := 10
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
 
-	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
 	}
-	wCluster := &types.Cluster{
-		Name: workClusterName,
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
 	}
 
-	tt := newSpecChangedTest(t)
-	// Adding AWSIamConfig to the cluster spec.
-	oldIamConfig := &v1alpha1.AWSIamConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: tt.clusterName,
-		},
-	}
-	tt.oldClusterConfig.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: oldIamConfig.Name}}
-	tt.newClusterConfig = tt.oldClusterConfig.DeepCopy()
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(1*time.Minute), clustermanager.WithMachineMinWait(2*time.Minute))
 
-	tt.clusterSpec = test.NewClusterSpecForConfig(t,
-		&cluster.Config{
-			Cluster: tt.newClusterConfig,
-			AWSIAMConfigs: map[string]*v1alpha1.AWSIamConfig{
-				oldIamConfig.Name: oldIamConfig,
-			},
-		},
-	)
+	kcp, mds := getKcpAndMdsForNodeCount(1)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
 
-	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+
+	// Fail a bunch of times
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(retries-5).Return(nil, errors.New("error get machines"))
+	// Return a machine with no nodeRef  times
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(3).Return([]types.Machine{{Metadata: types.MachineMetadata{
+		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+	}}}, nil)
+	//// Return a machine with nodeRef + NodeHealthy condition and another with it
+	status := types.MachineStatus{
+		NodeRef: &types.ResourceRef{},
+		Conditions: types.Conditions{
+			{
+				Type:   "NodeHealthy",
+				Status: "True",
+			},
+		},
+	}
+	machines := []types.Machine{
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+	}
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(machines, nil)
+	// Finally return two machines with node ref
+	machines = []types.Machine{
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+	}
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(machines, nil)
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesHealthConfirmations(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	c, m := newClusterManager(t,
+		clustermanager.WithMachineBackoff(1*time.Nanosecond),
+		clustermanager.WithMachineMaxWait(1*time.Minute),
+		clustermanager.WithMachineMinWait(2*time.Minute),
+		clustermanager.WithMachineHealthConfirmations(3),
+	)
+
+	kcp, mds := getKcpAndMdsForNodeCount(1)
+	mds[0].Spec.Replicas = ptr.Int32(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
-	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(tt.ctx, wCluster, tt.clusterSpec).Return(nil)
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
-		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	metadata := types.MachineMetadata{
+		Name:   "cp-machine",
+		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+	}
+	healthy := []types.Machine{{Metadata: metadata, Status: types.MachineStatus{NodeRef: &types.ResourceRef{}}}}
+	unhealthy := []types.Machine{{Metadata: metadata}}
+
+	// The machine flaps, so it's only counted after the third consecutive healthy observation.
+	gomock.InOrder(
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(2).Return(healthy, nil),
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(unhealthy, nil),
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(3).Return(healthy, nil),
+	)
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeCloudStackWorkloadClusterSuccess(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
+func TestClusterManagerRunPostCreateWorkloadClusterWaitForMachinesRequiredConditions(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	c, m := newClusterManager(t,
+		clustermanager.WithMachineBackoff(1*time.Nanosecond),
+		clustermanager.WithMachineMaxWait(1*time.Minute),
+		clustermanager.WithMachineMinWait(2*time.Minute),
+		clustermanager.WithRequiredMachineConditions("EtcdMemberHealthy"),
+	)
+
+	kcp, mds := getKcpAndMdsForNodeCount(1)
+	mds[0].Spec.Replicas = ptr.Int32(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+
+	metadata := types.MachineMetadata{
+		Name:   "cp-machine",
+		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+	}
+	nodeHealthy := types.Condition{Type: "NodeHealthy", Status: "True"}
+	missingEtcdCondition := []types.Machine{{Metadata: metadata, Status: types.MachineStatus{
+		NodeRef:    &types.ResourceRef{},
+		Conditions: types.Conditions{nodeHealthy},
+	}}}
+	etcdNotHealthy := []types.Machine{{Metadata: metadata, Status: types.MachineStatus{
+		NodeRef:    &types.ResourceRef{},
+		Conditions: types.Conditions{nodeHealthy, {Type: "EtcdMemberHealthy", Status: "False"}},
+	}}}
+	ready := []types.Machine{{Metadata: metadata, Status: types.MachineStatus{
+		NodeRef:    &types.ResourceRef{},
+		Conditions: types.Conditions{nodeHealthy, {Type: "EtcdMemberHealthy", Status: "True"}},
+	}}}
+
+	// The NodeHealthy machine isn't counted as ready until EtcdMemberHealthy is "True" too.
+	gomock.InOrder(
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(2).Return(missingEtcdCondition, nil),
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(etcdNotHealthy, nil),
+		m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Times(1).Return(ready, nil),
+	)
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerRunPostCreateWorkloadClusterRetryAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+
+	kcp, mds := getKcpAndMdsForNodeCount(1)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil).Times(2)
+
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil).Times(2)
+
+	status := types.MachineStatus{
+		NodeRef: &types.ResourceRef{},
+		Conditions: types.Conditions{
+			{
+				Type:   "NodeHealthy",
+				Status: "True",
+			},
+		},
+	}
+	notReadyMachines := []types.Machine{
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineDeploymentLabelName: ""}}},
+	}
+	readyMachines := []types.Machine{
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineDeploymentLabelName: ""}}, Status: status},
+	}
+
+	machinesReady := false
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).MinTimes(2).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, _ string) ([]types.Machine, error) {
+			if machinesReady {
+				return readyMachines, nil
+			}
+			return notReadyMachines, nil
+		},
+	)
+
+	g := NewWithT(t)
+	g.Expect(c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec)).To(
+		MatchError(ContainSubstring("retries exhausted waiting for machines to be ready")),
+	)
+
+	machinesReady = true
+	g.Expect(c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec)).To(Succeed())
+}
 
+func TestClusterManagerUpgradeSelfManagedClusterSuccess(t *testing.T) {
+	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+		Name: clusterName,
 	}
 	wCluster := &types.Cluster{
-		Name: workClusterName,
+		Name: clusterName,
 	}
 
-	tt := newSpecChangedTest(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt := newSpecChangedTest(t)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
@@ -984,41 +1797,40 @@ func TestClusterManagerUpgradeCloudStackWorkloadClusterSuccess(t *testing.T) {
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
 	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
 	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
 
 	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
 		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyErrorOnce(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
-
+func TestClusterManagerUpgradeSelfManagedClusterPriorityClassesSuccess(t *testing.T) {
+	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+		Name: clusterName,
 	}
 	wCluster := &types.Cluster{
-		Name: workClusterName,
+		Name: clusterName,
 	}
 
-	tt := newSpecChangedTest(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.PriorityClasses = []v1alpha1.PriorityClass{{Name: "critical-batch", Value: 100000}}
+	tt.oldClusterConfig.Spec.PriorityClasses = tt.clusterSpec.Cluster.Spec.PriorityClasses
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
@@ -1033,44 +1845,47 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyErrorOnce(t *testing.
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
 	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	// Fail once
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(0, 0, errors.New("error counting MD replicas"))
-	// Return 1 and 1 for ready and total replicas
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(1, 1, nil)
-	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, wCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("kind: PriorityClass"))
+			tt.Expect(string(data)).To(ContainSubstring("name: critical-batch"))
+			return nil
+		},
+	)
 
 	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
 		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyUnreadyOnce(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
-
+func TestClusterManagerUpgradeSelfManagedClusterNoIdentityProvidersSuccess(t *testing.T) {
+	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+		Name: clusterName,
 	}
 	wCluster := &types.Cluster{
-		Name: workClusterName,
+		Name: clusterName,
 	}
 
-	tt := newSpecChangedTest(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt := newSpecChangedTest(t)
+	tt.oldClusterConfig.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
@@ -1085,143 +1900,184 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyUnreadyOnce(t *testin
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
 	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	// Return 0 and 1 for ready and total replicas once
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(0, 1, nil)
-	// Return 1 and 1 for ready and total replicas
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(1, 1, nil)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
 	tt.mocks.provider.EXPECT().GetDeployments()
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
 
 	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
 		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
-	ctx := context.Background()
+func TestClusterManagerUpgradeClusterWithCurrentClusterSpecCache(t *testing.T) {
 	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
 		Name: clusterName,
 	}
-
 	wCluster := &types.Cluster{
 		Name: clusterName,
 	}
 
-	tt := newSpecChangedTest(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
-	kcp, _ := getKcpAndMdsForNodeCount(1)
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt := newSpecChangedTest(t, clustermanager.WithCurrentClusterSpecCache())
+	tt.oldClusterConfig.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil).Times(3)
 	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	// Fail once
-	tt.mocks.client.EXPECT().GetMachines(ctx, mCluster, mCluster.Name).Times(1).Return(nil, errors.New("error get machines"))
-	// Return a machine with no nodeRef the rest of the retries
-	tt.mocks.client.EXPECT().GetMachines(ctx, mCluster, mCluster.Name).MinTimes(1).Return([]types.Machine{{Metadata: types.MachineMetadata{
-		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
-	}}}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
 
-	if err := tt.clusterManager.UpgradeCluster(ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
-		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	// Bundles and EKS-D release are only retrieved once for the spec check and the upgrade.
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	if err != nil {
+		t.Fatalf("ClusterManager.EKSAClusterSpecChanged() error = %v, wantErr nil", err)
+	}
+	if diff {
+		t.Fatal("ClusterManager.EKSAClusterSpecChanged() = true, want false")
 	}
-}
 
-func TestClusterManagerUpgradeWorkloadClusterGetMachineDeploymentError(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+
+	// The next spec check starts with a fresh cache.
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	if _, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec); err != nil {
+		t.Errorf("ClusterManager.EKSAClusterSpecChanged() error = %v, wantErr nil", err)
+	}
+}
 
+func TestClusterManagerUpgradeClusterWorkerDeploymentWaitTimeout(t *testing.T) {
+	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+		Name: clusterName,
 	}
 	wCluster := &types.Cluster{
-		Name: workClusterName,
+		Name: clusterName,
 	}
 
-	tt := newSpecChangedTest(t)
-	kcp, _ := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt := newSpecChangedTest(t, clustermanager.WithWorkerDeploymentWaitTimeout(45*time.Minute))
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(nil, errors.New("get md err"))
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "45m0s", "Available", gomock.Any(), gomock.Any()).MinTimes(1)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
 
-	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("md err")))
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterRemoveOldWorkerNodeGroupsError(t *testing.T) {
-	mgmtClusterName := "cluster-name"
-	workClusterName := "cluster-name-w"
-
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdSuccess(t *testing.T) {
+	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
-		Name:               mgmtClusterName,
-		ExistingManagement: true,
+		Name: clusterName,
 	}
 	wCluster := &types.Cluster{
-		Name: workClusterName,
+		Name: clusterName,
 	}
 
 	tt := newSpecChangedTest(t)
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
 	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
 		mCluster,
 		mCluster.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile).Return(errors.New("delete wng error"))
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
-	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
 
-	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("wng err")))
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesFailedWithUnhealthyNode(t *testing.T) {
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdBackupSuccess(t *testing.T) {
 	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
 		Name: clusterName,
@@ -1230,120 +2086,2032 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesFailedWithUnhealthyN
 		Name: clusterName,
 	}
 
-	status := types.MachineStatus{
-		NodeRef: &types.ResourceRef{},
-		Conditions: types.Conditions{
-			{
-				Type:   "NodeHealthy",
-				Status: "False",
+	tt := newSpecChangedTest(t)
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:  3,
+		Backup: &v1alpha1.EtcdBackupConfiguration{Schedule: "@daily", Destination: "/var/lib/etcd-backups"},
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.DeepCopy()
+
+	_, mds := getKcpAndMdsForNodeCount(0)
+	kcp := externalEtcdKCP("https://10.0.0.1:2379")
+	kcp.Spec.Replicas = ptr.Int32(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, wCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: eksa-etcd-backup"))
+			tt.Expect(string(data)).To(ContainSubstring("- --endpoints=https://10.0.0.1:2379\n"))
+			return nil
+		},
+	)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeClusterProgressHook(t *testing.T) {
+	tests := []struct {
+		name         string
+		externalEtcd bool
+		wantPhases   []clustermanager.UpgradePhase
+	}{
+		{
+			name:         "stacked etcd",
+			externalEtcd: false,
+			wantPhases: []clustermanager.UpgradePhase{
+				clustermanager.UpgradePhaseGenerateSpec,
+				clustermanager.UpgradePhaseApplyControlPlane,
+				clustermanager.UpgradePhaseWaitControlPlaneNotReady,
+				clustermanager.UpgradePhaseWaitControlPlaneReady,
+				clustermanager.UpgradePhaseWaitControlPlaneMachines,
+				clustermanager.UpgradePhaseApplyWorkers,
+				clustermanager.UpgradePhaseDeleteOldWorkerGroups,
+				clustermanager.UpgradePhaseWaitMachineDeployments,
+				clustermanager.UpgradePhaseWaitCAPI,
+				clustermanager.UpgradePhaseFinalize,
 			},
 		},
+		{
+			name:         "external etcd",
+			externalEtcd: true,
+			wantPhases: []clustermanager.UpgradePhase{
+				clustermanager.UpgradePhaseGenerateSpec,
+				clustermanager.UpgradePhaseApplyControlPlane,
+				clustermanager.UpgradePhaseWaitExternalEtcd,
+				clustermanager.UpgradePhaseWaitControlPlaneNotReady,
+				clustermanager.UpgradePhaseWaitControlPlaneReady,
+				clustermanager.UpgradePhaseWaitControlPlaneMachines,
+				clustermanager.UpgradePhaseApplyWorkers,
+				clustermanager.UpgradePhaseDeleteOldWorkerGroups,
+				clustermanager.UpgradePhaseWaitMachineDeployments,
+				clustermanager.UpgradePhaseWaitCAPI,
+				clustermanager.UpgradePhaseFinalize,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterName := "cluster-name"
+			mCluster := &types.Cluster{
+				Name: clusterName,
+			}
+			wCluster := &types.Cluster{
+				Name: clusterName,
+			}
+
+			var phases []clustermanager.UpgradePhase
+			tt := newSpecChangedTest(t, clustermanager.WithUpgradeProgressHook(func(phase clustermanager.UpgradePhase) {
+				phases = append(phases, phase)
+			}))
+
+			if tc.externalEtcd {
+				tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+					Count: 3,
+				}
+				tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+					Count: 3,
+				}
+				tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
+				tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
+			}
+
+			kcp, mds := getKcpAndMdsForNodeCount(0)
+			tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+			tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+			tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+			tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+			tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+			tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+			tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+			tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+			tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+				mCluster,
+				mCluster.Name,
+				gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+				gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+			).Return(kcp, nil)
+			tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+				mCluster.Name,
+				gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+				gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+			).Return(mds, nil)
+			tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+			tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+			tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+			tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+			tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+			tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+			tt.mocks.provider.EXPECT().GetDeployments()
+			tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+			tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+			tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+
+			tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+			tt.Expect(phases).To(Equal(tc.wantPhases))
+		})
+	}
+}
+
+func TestUpgradePhaseString(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clustermanager.UpgradePhaseWaitExternalEtcd.String()).To(Equal("WaitExternalEtcd"))
+	g.Expect(clustermanager.UpgradePhase(100).String()).To(Equal("UpgradePhase(100)"))
+}
+
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdTimeoutNotReadySuccess(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("timed out waiting for the condition on clusters"))
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdWaitDeadlineNotReadySuccess(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(
+		errors.New("executing wait: error: timed out waiting for condition ManagedEtcdReady=false on clusters.cluster.x-k8s.io/cluster-name"),
+	)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName).Return(
+		errors.New("executing wait: error: timed out waiting for condition ControlPlaneReady=false on clusters.cluster.x-k8s.io/cluster-name"),
+	)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(8)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, tt.cluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdNotReadyError(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("etcd not ready"))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("etcd not ready")))
+}
+
+func TestClusterManagerUpgradeSelfManagedClusterControlPlaneNotReadyError(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("connection refused"))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(
+		MatchError("error waiting for control plane not ready: connection refused"),
+	)
+}
+
+func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdErrorRemovingAnnotation(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+	tt.oldClusterConfig.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count: 3,
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName).Return(errors.New("timed out"))
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), mCluster, constants.EksaSystemNamespace).Return(errors.New("removing annotation"))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("removing annotation")))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterControlPlaneScope(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithUpgradeScope(clustermanager.ScopeControlPlane))
+	kcp, _ := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).Times(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().GetMachineDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWorkersScope(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithUpgradeScope(clustermanager.ScopeWorkers))
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	kcp.Spec.Version = tt.clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerUpgradeWorkloadClusterControlPlaneScopeThenWorkersScope(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	newVersion := "v1.20.15-eks-1-20-22"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: "cluster-name-w",
+	}
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	kcp.Spec.Version = "v1.19.8-eks-1-19-4"
+
+	cpUpgrade := newSpecChangedTest(t, clustermanager.WithUpgradeScope(clustermanager.ScopeControlPlane))
+	cpUpgrade.clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+	cpUpgrade.clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = newVersion
+	cpUpgrade.mocks.client.EXPECT().GetEksaCluster(cpUpgrade.ctx, mCluster, mgmtClusterName).Return(cpUpgrade.oldClusterConfig, nil)
+	cpUpgrade.mocks.client.EXPECT().GetBundles(cpUpgrade.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	cpUpgrade.mocks.client.EXPECT().GetEksdRelease(cpUpgrade.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	cpUpgrade.mocks.client.EXPECT().GetEksaOIDCConfig(cpUpgrade.ctx, cpUpgrade.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, cpUpgrade.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	cpUpgrade.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(cpUpgrade.ctx, mCluster, mCluster, gomock.Any(), cpUpgrade.clusterSpec)
+	cpUpgrade.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	cpUpgrade.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(cpUpgrade.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Do(
+		func(_ context.Context, _ *types.Cluster, _ []byte, _ string) { kcp.Spec.Version = newVersion },
+	)
+	cpUpgrade.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(cpUpgrade.ctx, gomock.Any(), cpUpgrade.clusterSpec, wCluster, mCluster)
+	cpUpgrade.mocks.client.EXPECT().WaitForControlPlaneReady(cpUpgrade.ctx, mCluster, "1h0m0s", mgmtClusterName).Times(2)
+	cpUpgrade.mocks.client.EXPECT().WaitForControlPlaneNotReady(cpUpgrade.ctx, mCluster, "1m", mgmtClusterName)
+	cpUpgrade.mocks.client.EXPECT().GetKubeadmControlPlane(cpUpgrade.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	cpUpgrade.mocks.client.EXPECT().GetMachines(cpUpgrade.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	cpUpgrade.mocks.client.EXPECT().ValidateControlPlaneNodes(cpUpgrade.ctx, mCluster, mCluster.Name).Return(nil)
+	cpUpgrade.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(cpUpgrade.ctx, wCluster).Return(nil)
+	cpUpgrade.mocks.client.EXPECT().WaitForDeployment(cpUpgrade.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	cpUpgrade.mocks.provider.EXPECT().GetDeployments()
+
+	cpUpgrade.Expect(cpUpgrade.clusterManager.UpgradeCluster(cpUpgrade.ctx, mCluster, wCluster, cpUpgrade.clusterSpec, cpUpgrade.mocks.provider)).To(Succeed())
+
+	// The EKS-A cluster object is still on the old version, only the KubeadmControlPlane reflects the control plane upgrade.
+	workersUpgrade := newSpecChangedTest(t, clustermanager.WithUpgradeScope(clustermanager.ScopeWorkers))
+	workersUpgrade.clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+	workersUpgrade.clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = newVersion
+	workersUpgrade.mocks.client.EXPECT().GetEksaCluster(workersUpgrade.ctx, mCluster, mgmtClusterName).Return(workersUpgrade.oldClusterConfig, nil)
+	workersUpgrade.mocks.client.EXPECT().GetBundles(workersUpgrade.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	workersUpgrade.mocks.client.EXPECT().GetEksdRelease(workersUpgrade.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	workersUpgrade.mocks.client.EXPECT().GetEksaOIDCConfig(workersUpgrade.ctx, workersUpgrade.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, workersUpgrade.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	workersUpgrade.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(workersUpgrade.ctx, mCluster, mCluster, gomock.Any(), workersUpgrade.clusterSpec)
+	workersUpgrade.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	workersUpgrade.mocks.client.EXPECT().GetKubeadmControlPlane(workersUpgrade.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	workersUpgrade.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(workersUpgrade.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	workersUpgrade.mocks.client.EXPECT().GetMachineDeploymentsForCluster(workersUpgrade.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	workersUpgrade.mocks.client.EXPECT().GetMachines(workersUpgrade.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	workersUpgrade.mocks.client.EXPECT().GetMachineDeployment(workersUpgrade.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	workersUpgrade.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(workersUpgrade.ctx, &mds[0], mCluster.KubeconfigFile)
+	workersUpgrade.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(workersUpgrade.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	workersUpgrade.mocks.client.EXPECT().WaitForDeployment(workersUpgrade.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	workersUpgrade.mocks.provider.EXPECT().GetDeployments()
+
+	workersUpgrade.Expect(workersUpgrade.clusterManager.UpgradeCluster(workersUpgrade.ctx, mCluster, wCluster, workersUpgrade.clusterSpec, workersUpgrade.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWorkersScopeControlPlaneOnOlderVersion(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: "cluster-name-w",
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithUpgradeScope(clustermanager.ScopeWorkers))
+	tt.clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+	tt.clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.20.15-eks-1-20-22"
+	kcp, _ := getKcpAndMdsForNodeCount(0)
+	kcp.Spec.Version = "v1.19.8-eks-1-19-4"
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, gomock.Any(), tt.clusterSpec)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(
+		MatchError("can't upgrade only the workers to kubernetes version v1.20.15-eks-1-20-22, the control plane is on version v1.19.8-eks-1-19-4: upgrade the control plane first"),
+	)
+}
+
+func TestClusterManagerUpgradeClusterDryRunSuccess(t *testing.T) {
+	mCluster := &types.Cluster{
+		Name:               "cluster-name",
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: "cluster-name-w",
+	}
+	wantCP := []byte("control plane")
+	wantMD := []byte("workers")
+
+	tt := newSpecChangedTest(t)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, tt.clusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy()).Return(wantCP, wantMD, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.writer.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	cp, md, err := tt.clusterManager.UpgradeClusterDryRun(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(cp).To(Equal(wantCP))
+	tt.Expect(md).To(Equal(wantMD))
+}
+
+func TestClusterManagerUpgradeClusterDryRunErrorGeneratingCAPISpec(t *testing.T) {
+	mCluster := &types.Cluster{
+		Name:               "cluster-name",
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: "cluster-name-w",
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, tt.clusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy()).Return(nil, nil, errors.New("error generating"))
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, _, err := tt.clusterManager.UpgradeClusterDryRun(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating capi spec: error generating")))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterControlPlaneRolloutTimeout(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	budget := 20 * time.Minute
+	tt := newSpecChangedTest(t, clustermanager.WithControlPlaneRolloutTimeout(budget))
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m0s", mgmtClusterName),
+		tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, gomock.Any(), mgmtClusterName).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, timeout, _ string) error {
+				d, err := time.ParseDuration(timeout)
+				tt.Expect(err).To(BeNil())
+				tt.Expect(d).To(BeNumerically(">", 0))
+				tt.Expect(d).To(BeNumerically("<=", budget))
+				return nil
+			},
+		),
+		tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster),
+		tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName),
+	)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerWaitForControlPlaneRolloutNoRolloutInProgress(t *testing.T) {
+	tt := newTest(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+	notReadyTimeoutErr := errors.New("error: timed out waiting for the condition on clusters/cluster-name")
+
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, cluster, "1m0s", tt.clusterName).Return(notReadyTimeoutErr)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, cluster, gomock.Any(), tt.clusterName)
+
+	tt.Expect(tt.clusterManager.WaitForControlPlaneRollout(tt.ctx, cluster, tt.clusterName, 20*time.Minute)).To(Succeed())
+}
+
+func TestClusterManagerWaitForControlPlaneRolloutShortBudget(t *testing.T) {
+	tt := newTest(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, cluster, "30s", tt.clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, cluster, gomock.Any(), tt.clusterName)
+
+	tt.Expect(tt.clusterManager.WaitForControlPlaneRollout(tt.ctx, cluster, tt.clusterName, 30*time.Second)).To(Succeed())
+}
+
+func TestClusterManagerWaitForControlPlaneRolloutNotReadyError(t *testing.T) {
+	tt := newTest(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, cluster, "1m0s", tt.clusterName).Return(errors.New("error in client"))
+
+	tt.Expect(tt.clusterManager.WaitForControlPlaneRollout(tt.ctx, cluster, tt.clusterName, 20*time.Minute)).To(MatchError(ContainSubstring("error waiting for control plane not ready: error in client")))
+}
+
+func TestClusterManagerWaitForControlPlaneRolloutReadyError(t *testing.T) {
+	tt := newTest(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, cluster, "1m0s", tt.clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, cluster, gomock.Any(), tt.clusterName).Return(errors.New("error in client"))
+
+	tt.Expect(tt.clusterManager.WaitForControlPlaneRollout(tt.ctx, cluster, tt.clusterName, 20*time.Minute)).To(MatchError(ContainSubstring("waiting for workload cluster control plane to be ready: error in client")))
+}
+
+var workerNodeGroupsSpec = []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-0-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-0-1
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: cluster-name-md-0-1
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-md-0-1
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-1
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-1-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-1-1
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: cluster-name-md-1-1
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-md-1-1
+`)
+
+func TestClusterManagerUpgradeWorkloadClusterWorkerGroupRolloutPause(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	var events []string
+	fakeSleep := func(_ context.Context, d time.Duration) error {
+		events = append(events, fmt.Sprintf("sleep %s", d))
+		return nil
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithWorkerGroupRolloutPause(3*time.Minute), clustermanager.WithSleep(fakeSleep), clustermanager.WithMachineBackoff(0))
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	cpContent := []byte("cp")
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy()).Return(cpContent, workerNodeGroupsSpec, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, cpContent, constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, gomock.Not(cpContent), constants.EksaSystemNamespace).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte, _ string) error {
+			objs, err := unstructuredutil.YamlToUnstructured(data)
+			if err != nil {
+				return err
+			}
+			for _, o := range objs {
+				if o.GetKind() == "MachineDeployment" {
+					events = append(events, "apply "+o.GetName())
+				}
+			}
+			tt.Expect(objs).To(HaveLen(3))
+			return nil
+		},
+	).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	rollout := func(name string, observedGeneration int64) func(context.Context, string, ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error) {
+		return func(_ context.Context, _ string, _ ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error) {
+			events = append(events, "rollout "+name)
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 2},
+				Spec:       clusterv1.MachineDeploymentSpec{Replicas: ptr.Int32(1)},
+				Status: clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: observedGeneration,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					ReadyReplicas:      1,
+				},
+			}
+			return md, nil
+		}
+	}
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.Any(), gomock.Any()).DoAndReturn(rollout("cluster-name-md-0", 1))
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.Any(), gomock.Any()).DoAndReturn(rollout("cluster-name-md-0", 2))
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-1", gomock.Any(), gomock.Any()).DoAndReturn(rollout("cluster-name-md-1", 2))
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).DoAndReturn(
+		func(_ context.Context, _, _ string) (int, int, error) {
+			events = append(events, "wait")
+			return 0, 0, nil
+		},
+	)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+	tt.Expect(events).To(Equal([]string{
+		"apply cluster-name-md-0",
+		"rollout cluster-name-md-0",
+		"rollout cluster-name-md-0",
+		"sleep 3m0s",
+		"apply cluster-name-md-1",
+		"rollout cluster-name-md-1",
+		"wait",
+	}))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterInstallStorageClassSuccess(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	provider := &storageClassProviderMock{Provider: tt.mocks.provider}
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+
+	if !provider.Called {
+		t.Error("Missing call to provider's InstallStorageClass method")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterInstallStorageClassError(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	provider := &storageClassProviderMock{Provider: tt.mocks.provider, Return: errors.New("error")}
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error is nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterPostUpgradeSmokeTestSuccess(t *testing.T) {
+	mCluster := &types.Cluster{
+		Name:               "cluster-name",
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name:           "cluster-name-w",
+		KubeconfigFile: "cluster-name-w.kubeconfig",
+	}
+
+	var smokeTestKubeconfig string
+	tt := newSpecChangedTest(t, clustermanager.WithPostUpgradeSmokeTest(func(_ context.Context, kubeconfig string) error {
+		smokeTestKubeconfig = kubeconfig
+		return nil
+	}))
+	expectUpgradeWorkloadCluster(t, tt, mCluster, wCluster)
+
+	g := NewWithT(t)
+	g.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+	g.Expect(smokeTestKubeconfig).To(Equal(wCluster.KubeconfigFile))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterPostUpgradeSmokeTestError(t *testing.T) {
+	mCluster := &types.Cluster{
+		Name:               "cluster-name",
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name:           "cluster-name-w",
+		KubeconfigFile: "cluster-name-w.kubeconfig",
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithPostUpgradeSmokeTest(func(_ context.Context, _ string) error {
+		return errors.New("canary pod not running")
+	}))
+	expectUpgradeWorkloadCluster(t, tt, mCluster, wCluster)
+
+	g := NewWithT(t)
+	g.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(
+		MatchError("running post upgrade smoke test: canary pod not running"),
+	)
+}
+
+// expectUpgradeWorkloadCluster sets the expectations for a successful UpgradeCluster of wCluster,
+// managed by mCluster, with no nodes.
+func expectUpgradeWorkloadCluster(t *testing.T, tt *specChangedTest, mCluster, wCluster *types.Cluster) {
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mCluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mCluster.Name).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mCluster.Name)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mCluster.Name+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+}
+
+func TestClusterManagerUpgradeWorkloadClusterAWSIamConfigSuccess(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	// Adding AWSIamConfig to the cluster spec.
+	oldIamConfig := &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+		},
+	}
+	tt.oldClusterConfig.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: oldIamConfig.Name}}
+	tt.newClusterConfig = tt.oldClusterConfig.DeepCopy()
+
+	tt.clusterSpec = test.NewClusterSpecForConfig(t,
+		&cluster.Config{
+			Cluster: tt.newClusterConfig,
+			AWSIAMConfigs: map[string]*v1alpha1.AWSIamConfig{
+				oldIamConfig.Name: oldIamConfig,
+			},
+		},
+	)
+
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(tt.ctx, wCluster, tt.clusterSpec).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeCloudStackWorkloadClusterSuccess(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyErrorOnce(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	// Fail once
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(0, 0, errors.New("error counting MD replicas"))
+	// Return 1 and 1 for ready and total replicas
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(1, 1, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyUnreadyOnce(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	// Return 0 and 1 for ready and total replicas once
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(0, 1, nil)
+	// Return 1 and 1 for ready and total replicas
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Times(1).Return(1, 1, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+	kcp, _ := getKcpAndMdsForNodeCount(1)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(ctx, mCluster, "1h0m0s", clusterName)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	// Fail once
+	tt.mocks.client.EXPECT().GetMachines(ctx, mCluster, mCluster.Name).Times(1).Return(nil, errors.New("error get machines"))
+	// Return a machine with no nodeRef the rest of the retries
+	tt.mocks.client.EXPECT().GetMachines(ctx, mCluster, mCluster.Name).MinTimes(1).Return([]types.Machine{{Metadata: types.MachineMetadata{
+		Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+	}}}, nil)
+
+	if err := tt.clusterManager.UpgradeCluster(ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterGetMachineDeploymentError(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, _ := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(nil, errors.New("get md err"))
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("md err")))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterRemoveOldWorkerNodeGroupsError(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile).Return(errors.New("delete wng error"))
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("wng err")))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesFailedWithUnhealthyNode(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	status := types.MachineStatus{
+		NodeRef: &types.ResourceRef{},
+		Conditions: types.Conditions{
+			{
+				Type:   "NodeHealthy",
+				Status: "False",
+			},
+		},
+	}
+	machines := []types.Machine{
+		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+	kcp, _ := getKcpAndMdsForNodeCount(1)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(5)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	// Return a machine with no nodeRef the rest of the retries
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).MinTimes(1).Return(machines, nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForCAPITimeout(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.provider.EXPECT().GetDeployments().Return(map[string][]string{})
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).Return(errors.New("time out")).Times(deploymentsCount(internal.CAPIDeployments))
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterWaitForCAPICustomTimeout(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t, clustermanager.WithCAPIDeploymentWaitTimeout(45*time.Minute))
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
+	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
+		mCluster,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
+		mCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
+	tt.mocks.provider.EXPECT().GetDeployments().Return(map[string][]string{})
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "45m0s", "Available", gomock.Any(), gomock.Any()).Return(errors.New("time out")).Times(deploymentsCount(internal.CAPIDeployments))
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerBackupCAPISuccess(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, managementStatePath)
+
+	if err := c.BackupCAPI(ctx, from, managementStatePath); err != nil {
+		t.Errorf("ClusterManager.BackupCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerBackupCAPIError(t *testing.T) {
+	from := &types.Cluster{}
+
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, managementStatePath).Return(errors.New("backing up CAPI resources"))
+
+	if err := c.BackupCAPI(ctx, from, managementStatePath); err == nil {
+		t.Errorf("ClusterManager.BackupCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerBackupCAPIToDirSuccess(t *testing.T) {
+	g := NewWithT(t)
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, dir).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			g.Expect(os.WriteFile(filepath.Join(dir, "Cluster_eksa-system_from-cluster.yaml"), []byte("cluster"), 0o644)).To(Succeed())
+			g.Expect(os.WriteFile(filepath.Join(dir, "KubeadmControlPlane_eksa-system_from-cluster.yaml"), []byte("kcp"), 0o644)).To(Succeed())
+			return nil
+		},
+	)
+
+	manifests, err := c.BackupCAPIToDir(ctx, from, dir)
+	g.Expect(err).To(Succeed())
+	g.Expect(manifests).To(ConsistOf(
+		filepath.Join(dir, "Cluster_eksa-system_from-cluster.yaml"),
+		filepath.Join(dir, "KubeadmControlPlane_eksa-system_from-cluster.yaml"),
+	))
+}
+
+func TestClusterManagerBackupCAPIToDirPartialFailure(t *testing.T) {
+	g := NewWithT(t)
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, dir).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			g.Expect(os.WriteFile(filepath.Join(dir, "Cluster_eksa-system_from-cluster.yaml"), []byte("cluster"), 0o644)).To(Succeed())
+			return errors.New("failed taking backup of CAPI objects")
+		},
+	)
+
+	manifests, err := c.BackupCAPIToDir(ctx, from, dir)
+	g.Expect(err).To(MatchError(ContainSubstring("failed taking backup of CAPI objects")))
+	g.Expect(manifests).To(ConsistOf(filepath.Join(dir, "Cluster_eksa-system_from-cluster.yaml")))
+}
+
+func TestClusterManagerBackupCAPIToDirSkipsExistingFiles(t *testing.T) {
+	g := NewWithT(t)
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	stale := filepath.Join(dir, "Machine_eksa-system_old-machine.yaml")
+	rewritten := filepath.Join(dir, "Cluster_eksa-system_from-cluster.yaml")
+	past := time.Now().Add(-time.Hour)
+	for _, f := range []string{stale, rewritten} {
+		g.Expect(os.WriteFile(f, []byte("old"), 0o644)).To(Succeed())
+		g.Expect(os.Chtimes(f, past, past)).To(Succeed())
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, dir).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			g.Expect(os.WriteFile(rewritten, []byte("cluster"), 0o644)).To(Succeed())
+			g.Expect(os.WriteFile(filepath.Join(dir, "KubeadmControlPlane_eksa-system_from-cluster.yaml"), []byte("kcp"), 0o644)).To(Succeed())
+			return nil
+		},
+	)
+
+	manifests, err := c.BackupCAPIToDir(ctx, from, dir)
+	g.Expect(err).To(Succeed())
+	g.Expect(manifests).To(ConsistOf(
+		rewritten,
+		filepath.Join(dir, "KubeadmControlPlane_eksa-system_from-cluster.yaml"),
+	))
+}
+
+func TestClusterManagerMoveCAPISuccess(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	capiClusterName := "capi-cluster"
+	clustersNotReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "False",
+		}},
+	}}}
+	clustersReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "True",
+		}},
+	}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, from, to.Name)
+	m.client.EXPECT().GetClusters(ctx, from).Return(clustersNotReady, nil)
+	m.client.EXPECT().WaitForClusterCondition(ctx, from, "1h0m0s", "Ready", capiClusterName)
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.client.EXPECT().GetClusters(ctx, to).Return(clustersReady, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", capiClusterName)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		to,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, to, to.Name)
+
+	if err := c.MoveCAPI(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerMoveCAPIWithMoveTimeouts(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	capiClusterName := "capi-cluster"
+	clustersNotReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "False",
+		}},
+	}}}
+	clustersReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "True",
+		}},
+	}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t,
+		clustermanager.WithMoveClusterWaitTimeout(30*time.Minute),
+		clustermanager.WithMoveControlPlaneWaitTimeout(5*time.Minute),
+	)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, from, to.Name)
+	m.client.EXPECT().GetClusters(ctx, from).Return(clustersNotReady, nil)
+	m.client.EXPECT().WaitForClusterCondition(ctx, from, "30m0s", "Ready", capiClusterName)
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.client.EXPECT().GetClusters(ctx, to).Return(clustersReady, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "5m0s", capiClusterName)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		to,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, to, to.Name)
+
+	if err := c.MoveCAPI(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerMoveCAPIWithNoTimeouts(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	capiClusterName := "capi-cluster"
+	clustersNotReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "False",
+		}},
+	}}}
+	clustersReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
+		Conditions: []types.Condition{{
+			Type:   "Ready",
+			Status: "True",
+		}},
+	}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t,
+		clustermanager.WithMoveClusterWaitTimeout(30*time.Minute),
+		clustermanager.WithMoveControlPlaneWaitTimeout(5*time.Minute),
+		clustermanager.WithNoTimeouts(),
+	)
+	maxTime := time.Duration(math.MaxInt64).String()
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, from, to.Name)
+	m.client.EXPECT().GetClusters(ctx, from).Return(clustersNotReady, nil)
+	m.client.EXPECT().WaitForClusterCondition(ctx, from, maxTime, "Ready", capiClusterName)
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.client.EXPECT().GetClusters(ctx, to).Return(clustersReady, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, maxTime, capiClusterName)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		to,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, to, to.Name)
+
+	if err := c.MoveCAPI(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, wantErr nil", err)
 	}
-	machines := []types.Machine{
-		{Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}, Status: status},
+}
+
+func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
 	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
+	ctx := context.Background()
 
-	tt := newSpecChangedTest(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
-	kcp, _ := getKcpAndMdsForNodeCount(1)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(5)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	c, m := newClusterManager(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	// Return a machine with no nodeRef the rest of the retries
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).MinTimes(1).Return(machines, nil)
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, from, from.Name)
+	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving"))
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
-		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
+		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterWaitForCAPITimeout(t *testing.T) {
-	clusterName := "cluster-name"
-	mCluster := &types.Cluster{
-		Name: clusterName,
+func TestClusterManagerMoveCAPIWithBackup(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
 	}
-	wCluster := &types.Cluster{
-		Name: clusterName,
+	to := &types.Cluster{
+		Name: "to-cluster",
 	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+	})
+	ctx := context.Background()
+	backupDir := t.TempDir()
 
-	tt := newSpecChangedTest(t)
+	c, m := newClusterManager(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
-	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
-	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
-	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
-	tt.mocks.client.EXPECT().GetKubeadmControlPlane(tt.ctx,
-		mCluster,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	m.client.EXPECT().BackupManagement(ctx, from, backupDir)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
-	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx,
-		mCluster.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(mCluster)),
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
-	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(&mds[0], nil)
-	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[0], mCluster.KubeconfigFile)
-	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m0s", "Available", gomock.Any(), gomock.Any()).Return(errors.New("time out"))
-	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
-	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
-	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
-	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
-	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	m.client.EXPECT().GetMachines(ctx, from, from.Name)
+	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving"))
 
-	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err == nil {
-		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	err := c.MoveCAPIWithBackup(ctx, from, to, from.Name, clusterSpec, backupDir)
+	if err == nil || !strings.Contains(err.Error(), "error moving") {
+		t.Errorf("ClusterManager.MoveCAPIWithBackup() error = %v, want move error", err)
 	}
 }
 
-func TestClusterManagerBackupCAPISuccess(t *testing.T) {
+func TestClusterManagerMoveCAPIWithBackupSkipped(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
-
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+	})
 	ctx := context.Background()
 
 	c, m := newClusterManager(t)
-	m.client.EXPECT().BackupManagement(ctx, from, managementStatePath)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().BackupManagement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		from,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, from, from.Name)
+	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving"))
 
-	if err := c.BackupCAPI(ctx, from, managementStatePath); err != nil {
-		t.Errorf("ClusterManager.BackupCAPI() error = %v, wantErr nil", err)
+	err := c.MoveCAPIWithBackup(ctx, from, to, from.Name, clusterSpec, "")
+	if err == nil || !strings.Contains(err.Error(), "error moving") {
+		t.Errorf("ClusterManager.MoveCAPIWithBackup() error = %v, want move error", err)
 	}
 }
 
-func TestClusterManagerBackupCAPIError(t *testing.T) {
-	from := &types.Cluster{}
-
+func TestClusterManagerMoveCAPIWithBackupErrorBackup(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec()
 	ctx := context.Background()
+	backupDir := t.TempDir()
 
 	c, m := newClusterManager(t)
-	m.client.EXPECT().BackupManagement(ctx, from, managementStatePath).Return(errors.New("backing up CAPI resources"))
+	m.client.EXPECT().BackupManagement(ctx, from, backupDir).Return(errors.New("backing up CAPI resources"))
+	m.client.EXPECT().MoveManagement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-	if err := c.BackupCAPI(ctx, from, managementStatePath); err == nil {
-		t.Errorf("ClusterManager.BackupCAPI() error = %v, wantErr nil", err)
+	err := c.MoveCAPIWithBackup(ctx, from, to, from.Name, clusterSpec, backupDir)
+	if err == nil || !strings.Contains(err.Error(), "backing up CAPI resources") {
+		t.Errorf("ClusterManager.MoveCAPIWithBackup() error = %v, want backup error", err)
 	}
 }
 
-func TestClusterManagerMoveCAPISuccess(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorGetClustersBeforeMove(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1353,63 +4121,207 @@ func TestClusterManagerMoveCAPISuccess(t *testing.T) {
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
 		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
 	})
-	capiClusterName := "capi-cluster"
-	clustersNotReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
-		Conditions: []types.Condition{{
-			Type:   "Ready",
-			Status: "False",
-		}},
-	}}}
-	clustersReady := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}, Status: types.ClusterStatus{
-		Conditions: []types.Condition{{
-			Type:   "Ready",
-			Status: "True",
-		}},
-	}}}
 	ctx := context.Background()
 
 	c, m := newClusterManager(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
-		to.Name,
+		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
 	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		to.Name,
+		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, from, to.Name)
-	m.client.EXPECT().GetClusters(ctx, from).Return(clustersNotReady, nil)
-	m.client.EXPECT().WaitForClusterReady(ctx, from, "1h0m0s", capiClusterName)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
-	m.client.EXPECT().GetClusters(ctx, to).Return(clustersReady, nil)
-	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", capiClusterName)
-	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
-	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetMachines(ctx, from, from.Name)
+	m.client.EXPECT().GetClusters(ctx, from).Return(nil, errors.New("error getting clusters"))
+
+	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
+		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerMoveCAPIErrorWaitForClusterReady(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	})
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
-		to,
-		to.Name,
+		from,
+		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
 	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		to.Name,
+		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, to, to.Name)
+	m.client.EXPECT().GetMachines(ctx, from, from.Name)
+	capiClusterName := "capi-cluster"
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
+	m.client.EXPECT().GetClusters(ctx, from).Return(clusters, nil)
+	m.client.EXPECT().WaitForClusterCondition(ctx, from, "1h0m0s", "Ready", capiClusterName).Return(errors.New("error waitinf for cluster to be ready"))
+
+	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
+		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerWaitForClusterCondition(t *testing.T) {
+	cluster := &types.Cluster{Name: "mgmt"}
+	errWait := errors.New("error: timed out waiting for the condition on clusters/capi-cluster")
+
+	tests := []struct {
+		name    string
+		results []error
+		wantErr string
+	}{
+		{
+			name:    "success",
+			results: []error{nil},
+		},
+		{
+			name:    "one retryable failure",
+			results: []error{errWait, nil},
+		},
+		{
+			name:    "timeout",
+			results: []error{errWait, errWait},
+			wantErr: errWait.Error(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+			calls := make([]*gomock.Call, 0, len(tc.results))
+			for _, result := range tc.results {
+				calls = append(calls, m.client.EXPECT().WaitForClusterCondition(ctx, cluster, "10m0s", "InfrastructureReady", "capi-cluster").Return(result))
+			}
+			gomock.InOrder(calls...)
+
+			err := c.WaitForClusterCondition(ctx, cluster, "capi-cluster", "InfrastructureReady", 10*time.Minute)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterManagerWaitForClusterConditionContextCancelled(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The default retrier waits between retries, so the wait only returns promptly if the
+	// cancellation interrupts it.
+	c, m := newClusterManager(t)
+	m.client.EXPECT().WaitForClusterCondition(ctx, cluster, "1h0m0s", "Ready", "capi-cluster").
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, _, _, _ string) error {
+			cancel()
+			return errors.New("timed out waiting for the condition")
+		})
+
+	start := time.Now()
+	err := c.WaitForClusterCondition(ctx, cluster, "capi-cluster", "Ready", time.Hour)
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
+
+func TestRetrierClientContextCancelled(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &types.Cluster{Name: "mgmt"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockCtrl := gomock.NewController(t)
+	client := mocksmanager.NewMockClusterClient(mockCtrl)
+	retrierClient := clustermanager.NewRetrierClient(client, clustermanager.DefaultRetrier())
+	client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, []byte("data")).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, _ []byte) error {
+			cancel()
+			return errors.New("connection refused")
+		})
+
+	start := time.Now()
+	err := retrierClient.ApplyKubeSpecFromBytes(ctx, cluster, []byte("data"))
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
+
+func TestRetrierClientStats(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt"}
+
+	mockCtrl := gomock.NewController(t)
+	client := mocksmanager.NewMockClusterClient(mockCtrl)
+	retrierClient := clustermanager.NewRetrierClient(client, retrier.NewWithMaxRetries(3, 0))
+
+	gomock.InOrder(
+		client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, []byte("data")).Return(errors.New("connection refused")).Times(2),
+		client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, []byte("data")).Return(nil),
+	)
+	client.EXPECT().CreateNamespaceIfNotPresent(ctx, cluster.KubeconfigFile, "ns").Return(nil).Times(2)
+
+	g.Expect(retrierClient.ApplyKubeSpecFromBytes(ctx, cluster, []byte("data"))).To(Succeed())
+	g.Expect(retrierClient.CreateNamespaceIfNotPresent(ctx, cluster.KubeconfigFile, "ns")).To(Succeed())
+	g.Expect(retrierClient.CreateNamespaceIfNotPresent(ctx, cluster.KubeconfigFile, "ns")).To(Succeed())
+
+	g.Expect(retrierClient.Stats()).To(Equal(map[string]clustermanager.RetryStats{
+		"ApplyKubeSpecFromBytes":      {Attempts: 3, Retries: 2},
+		"CreateNamespaceIfNotPresent": {Attempts: 2, Retries: 0},
+	}))
+}
+
+func TestRetrierClientRetryMetricsHook(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt"}
+
+	mockCtrl := gomock.NewController(t)
+	client := mocksmanager.NewMockClusterClient(mockCtrl)
+	attempts := map[string][]int{}
+	retrierClient := clustermanager.NewRetrierClient(client, retrier.NewWithMaxRetries(2, 0),
+		clustermanager.WithRetryMetricsHook(func(method string, a int) {
+			attempts[method] = append(attempts[method], a)
+		}),
+	)
+
+	client.EXPECT().DeleteCluster(ctx, cluster, cluster).Return(errors.New("connection refused")).Times(2)
+	client.EXPECT().DeleteCluster(ctx, cluster, cluster).Return(nil)
 
-	if err := c.MoveCAPI(ctx, from, to, to.Name, clusterSpec); err != nil {
-		t.Errorf("ClusterManager.MoveCAPI() error = %v, wantErr nil", err)
-	}
+	g.Expect(retrierClient.DeleteCluster(ctx, cluster, cluster)).To(MatchError(ContainSubstring("connection refused")))
+	g.Expect(retrierClient.DeleteCluster(ctx, cluster, cluster)).To(Succeed())
+
+	g.Expect(attempts).To(Equal(map[string][]int{"DeleteCluster": {2, 1}}))
+	g.Expect(retrierClient.Stats()).To(Equal(map[string]clustermanager.RetryStats{
+		"DeleteCluster": {Attempts: 3, Retries: 1},
+	}))
 }
 
-func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorGetClusters(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1438,14 +4350,15 @@ func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
 	).Return(mds, nil)
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
 	m.client.EXPECT().GetClusters(ctx, from)
-	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving"))
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.client.EXPECT().GetClusters(ctx, to).Return(nil, errors.New("error getting clusters"))
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
 		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorGetClustersBeforeMove(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorWaitForControlPlane(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1460,6 +4373,9 @@ func TestClusterManagerMoveCAPIErrorGetClustersBeforeMove(t *testing.T) {
 	ctx := context.Background()
 
 	c, m := newClusterManager(t)
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	capiClusterName := "capi-cluster"
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
 	kcp, mds := getKcpAndMdsForNodeCount(0)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
@@ -1473,14 +4389,16 @@ func TestClusterManagerMoveCAPIErrorGetClustersBeforeMove(t *testing.T) {
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().GetClusters(ctx, from).Return(nil, errors.New("error getting clusters"))
+	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", capiClusterName).Return(errors.New("error waiting for control plane"))
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
 		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorWaitForClusterReady(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorGetMachines(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1490,11 +4408,11 @@ func TestClusterManagerMoveCAPIErrorWaitForClusterReady(t *testing.T) {
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
 		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
 	})
 	ctx := context.Background()
 
-	c, m := newClusterManager(t)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
 	kcp, mds := getKcpAndMdsForNodeCount(0)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
@@ -1508,17 +4426,30 @@ func TestClusterManagerMoveCAPIErrorWaitForClusterReady(t *testing.T) {
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	capiClusterName := "capi-cluster"
-	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
-	m.client.EXPECT().GetClusters(ctx, from).Return(clusters, nil)
-	m.client.EXPECT().WaitForClusterReady(ctx, from, "1h0m0s", capiClusterName).Return(errors.New("error waitinf for cluster to be ready"))
+	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.client.EXPECT().GetClusters(ctx, to)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		to,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		from.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(nil, errors.New("error getting machines")).AnyTimes()
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
 		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorGetClusters(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorGetKubeadmControlPlane(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1528,34 +4459,23 @@ func TestClusterManagerMoveCAPIErrorGetClusters(t *testing.T) {
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
 		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
 	})
 	ctx := context.Background()
-
-	c, m := newClusterManager(t)
-	kcp, mds := getKcpAndMdsForNodeCount(0)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
 		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().GetClusters(ctx, from)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
-	m.client.EXPECT().GetClusters(ctx, to).Return(nil, errors.New("error getting clusters"))
+	).Return(nil, errors.New("error getting KubeadmControlPlane"))
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
 		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorWaitForControlPlane(t *testing.T) {
+func TestClusterManagerMoveCAPIErrorGetMachineDeploymentsForCluster(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1565,15 +4485,11 @@ func TestClusterManagerMoveCAPIErrorWaitForControlPlane(t *testing.T) {
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
 		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
 	})
 	ctx := context.Background()
-
-	c, m := newClusterManager(t)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
-	capiClusterName := "capi-cluster"
-	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
-	kcp, mds := getKcpAndMdsForNodeCount(0)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
+	kcp, _ := getKcpAndMdsForNodeCount(0)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
 		from.Name,
@@ -1584,18 +4500,14 @@ func TestClusterManagerMoveCAPIErrorWaitForControlPlane(t *testing.T) {
 		from.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().GetClusters(ctx, from)
-	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
-	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", capiClusterName).Return(errors.New("error waiting for control plane"))
+	).Return(nil, errors.New("error getting MachineDeployments"))
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
 		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorGetMachines(t *testing.T) {
+func TestClusterManagerMoveCAPIResumableNotMovedSuccess(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1604,49 +4516,37 @@ func TestClusterManagerMoveCAPIErrorGetMachines(t *testing.T) {
 	}
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
 		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
 	})
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: to.Name}}}
 	ctx := context.Background()
 
-	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
+	c, m := newClusterManager(t)
 	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetClusters(ctx, to).Return(nil, nil)
 	m.client.EXPECT().GetKubeadmControlPlane(ctx,
 		from,
-		from.Name,
+		to.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(kcp, nil)
 	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		from.Name,
+		to.Name,
 		gomock.AssignableToTypeOf(executables.WithCluster(from)),
 		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
 	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().GetClusters(ctx, from)
+	m.client.EXPECT().GetMachines(ctx, from, to.Name)
+	m.client.EXPECT().GetClusters(ctx, from).Return(clusters, nil)
+	m.client.EXPECT().WaitForClusterCondition(ctx, from, "1h0m0s", "Ready", to.Name)
 	m.client.EXPECT().MoveManagement(ctx, from, to)
-	m.client.EXPECT().GetClusters(ctx, to)
-	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
-	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
-	m.client.EXPECT().GetKubeadmControlPlane(ctx,
-		to,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(mds, nil)
-	m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(nil, errors.New("error getting machines")).AnyTimes()
+	expectWaitForCAPIAfterMove(ctx, m, from, to, clusters)
 
-	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
-		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	if err := c.MoveCAPIResumable(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPIResumable() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorGetKubeadmControlPlane(t *testing.T) {
+func TestClusterManagerMoveCAPIResumableAlreadyMoved(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
@@ -1655,78 +4555,263 @@ func TestClusterManagerMoveCAPIErrorGetKubeadmControlPlane(t *testing.T) {
 	}
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = to.Name
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
 		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
 	})
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: to.Name}}}
 	ctx := context.Background()
-	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
-	m.client.EXPECT().GetKubeadmControlPlane(ctx,
-		from,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(nil, errors.New("error getting KubeadmControlPlane"))
 
-	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
-		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	c, m := newClusterManager(t)
+	// The move already happened, so only the post-move waits on the target run.
+	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
+	m.client.EXPECT().GetClusters(ctx, from).Times(0)
+	m.client.EXPECT().GetMachines(ctx, from, gomock.Any()).Times(0)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Times(0)
+	expectWaitForCAPIAfterMove(ctx, m, from, to, clusters)
+
+	if err := c.MoveCAPIResumable(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPIResumable() error = %v, wantErr nil", err)
 	}
 }
 
-func TestClusterManagerMoveCAPIErrorGetMachineDeploymentsForCluster(t *testing.T) {
+func TestClusterManagerMoveCAPIResumableRerunAfterWaitFailure(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: to.Name}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+
+	// First run moves the resources but times out waiting for the control plane.
+	gomock.InOrder(
+		m.client.EXPECT().GetClusters(ctx, to).Return(nil, nil),
+		m.client.EXPECT().GetKubeadmControlPlane(ctx,
+			from,
+			to.Name,
+			gomock.AssignableToTypeOf(executables.WithCluster(from)),
+			gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+		).Return(kcp, nil),
+		m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+			to.Name,
+			gomock.AssignableToTypeOf(executables.WithCluster(from)),
+			gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+		).Return(mds, nil),
+		m.client.EXPECT().GetMachines(ctx, from, to.Name),
+		m.client.EXPECT().GetClusters(ctx, from).Return(clusters, nil),
+		m.client.EXPECT().WaitForClusterCondition(ctx, from, "1h0m0s", "Ready", to.Name),
+		m.client.EXPECT().MoveManagement(ctx, from, to).Times(1),
+		m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil),
+		m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", to.Name).Return(errors.New("timed out waiting for control plane")),
+	)
+
+	err := c.MoveCAPIResumable(ctx, from, to, to.Name, clusterSpec)
+	if err == nil {
+		t.Fatal("ClusterManager.MoveCAPIResumable() error = nil, wantErr not nil")
+	}
+
+	// The rerun finds the clusters in the target and doesn't move them again.
+	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
+	expectWaitForCAPIAfterMove(ctx, m, from, to, clusters)
+
+	if err := c.MoveCAPIResumable(ctx, from, to, to.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPIResumable() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerMoveCAPIResumableErrorGetTargetClusters(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
 	}
 	to := &types.Cluster{
 		Name: "to-cluster",
 	}
-	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
-		s.Cluster.Name = to.Name
-		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
-		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
-	})
-	ctx := context.Background()
-	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(0), clustermanager.WithMachineMaxWait(10*time.Microsecond), clustermanager.WithMachineMinWait(20*time.Microsecond))
-	kcp, _ := getKcpAndMdsForNodeCount(0)
-	m.client.EXPECT().GetKubeadmControlPlane(ctx,
-		from,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(kcp, nil)
-	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
-		from.Name,
-		gomock.AssignableToTypeOf(executables.WithCluster(from)),
-		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
-	).Return(nil, errors.New("error getting MachineDeployments"))
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = to.Name
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetClusters(ctx, to).Return(nil, errors.New("error getting clusters"))
+	m.client.EXPECT().MoveManagement(ctx, from, to).Times(0)
+
+	err := c.MoveCAPIResumable(ctx, from, to, to.Name, clusterSpec)
+	if err == nil || !strings.Contains(err.Error(), "checking if CAPI management was already moved to target") {
+		t.Errorf("ClusterManager.MoveCAPIResumable() error = %v, want error checking target clusters", err)
+	}
+}
+
+func expectWaitForCAPIAfterMove(ctx context.Context, m *clusterManagerMocks, from, to *types.Cluster, clusters []types.CAPICluster) {
+	kcp, mds := getKcpAndMdsForNodeCount(0)
+	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", to.Name)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, to.Name, to.KubeconfigFile)
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		to,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(kcp, nil)
+	m.client.EXPECT().GetMachineDeploymentsForCluster(ctx,
+		to.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(from)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(mds, nil)
+	m.client.EXPECT().GetMachines(ctx, to, to.Name)
+}
+
+func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{}
+
+	c, m := newClusterManager(t)
+
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, tt.cluster, gomock.Any(), gomock.Any()).MaxTimes(2)
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
+	_, ok := datacenterConfig.GetAnnotations()["anywhere.eks.amazonaws.com/paused"]
+	tt.Expect(ok).To(BeTrue())
+	_, ok = tt.clusterSpec.Cluster.GetAnnotations()["anywhere.eks.amazonaws.com/paused"]
+	tt.Expect(ok).To(BeTrue())
+}
+
+func TestClusterManagerCreateEKSAResourcesServerSideApply(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{}
+
+	c, m := newClusterManager(t, clustermanager.WithServerSideApply())
+
+	m.client.EXPECT().ServerSideApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any(), "", "eks-a-cli").Times(2)
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any()).Times(0)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any()).Times(0)
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
+}
+
+func TestClusterManagerCreateEKSAResourcesServerSideApplyError(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{}
+
+	c, m := newClusterManager(t, clustermanager.WithServerSideApply(), clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	m.client.EXPECT().ServerSideApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any(), "", "eks-a-cli").Return(errors.New("apply error"))
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(MatchError("applying eks-a spec: apply error"))
+}
+
+func TestClusterManagerWaitForEKSAClusterReady(t *testing.T) {
+	ctx := context.Background()
+	tt := newTest(t)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond))
+
+	m.client.EXPECT().GetEksaClusterStatus(ctx, tt.cluster, tt.clusterName).Return(&v1alpha1.ClusterStatus{}, nil)
+	m.client.EXPECT().GetClusters(ctx, tt.cluster).Return(capiClustersFromFile(t, "testdata/capi_clusters_ready.json"), nil)
+
+	tt.Expect(c.WaitForEKSAClusterReady(ctx, tt.cluster, tt.clusterName, time.Minute)).To(Succeed())
+}
+
+func TestClusterManagerWaitForEKSAClusterReadyNotReadyThenReady(t *testing.T) {
+	ctx := context.Background()
+	tt := newTest(t)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Nanosecond))
+
+	m.client.EXPECT().GetEksaClusterStatus(ctx, tt.cluster, tt.clusterName).Return(&v1alpha1.ClusterStatus{}, nil).Times(2)
+	gomock.InOrder(
+		m.client.EXPECT().GetClusters(ctx, tt.cluster).Return(capiClustersFromFile(t, "testdata/capi_clusters_provisioning.json"), nil),
+		m.client.EXPECT().GetClusters(ctx, tt.cluster).Return(capiClustersFromFile(t, "testdata/capi_clusters_ready.json"), nil),
+	)
+
+	tt.Expect(c.WaitForEKSAClusterReady(ctx, tt.cluster, tt.clusterName, time.Minute)).To(Succeed())
+}
+
+func TestClusterManagerWaitForEKSAClusterReadyTimeout(t *testing.T) {
+	ctx := context.Background()
+	tt := newTest(t)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Millisecond))
+
+	m.client.EXPECT().GetEksaClusterStatus(ctx, tt.cluster, tt.clusterName).Return(&v1alpha1.ClusterStatus{}, nil).AnyTimes()
+	m.client.EXPECT().GetClusters(ctx, tt.cluster).Return(capiClustersFromFile(t, "testdata/capi_clusters_provisioning.json"), nil).AnyTimes()
+
+	tt.Expect(c.WaitForEKSAClusterReady(ctx, tt.cluster, tt.clusterName, 10*time.Millisecond)).To(
+		MatchError(ContainSubstring("waiting for eks-a cluster to be ready: eks-a cluster cluster-name is not ready")),
+	)
+}
+
+func TestClusterManagerWaitForEKSAClusterReadyFailureMessage(t *testing.T) {
+	ctx := context.Background()
+	tt := newTest(t)
+	c, m := newClusterManager(t, clustermanager.WithMachineBackoff(1*time.Millisecond))
+
+	eksaCluster := &v1alpha1.Cluster{}
+	content, err := os.ReadFile("testdata/eksa_cluster_failure.json")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(json.Unmarshal(content, eksaCluster)).To(Succeed())
+	m.client.EXPECT().GetEksaClusterStatus(ctx, tt.cluster, tt.clusterName).Return(&eksaCluster.Status, nil).AnyTimes()
+
+	tt.Expect(c.WaitForEKSAClusterReady(ctx, tt.cluster, tt.clusterName, 10*time.Millisecond)).To(
+		MatchError(ContainSubstring("eks-a cluster cluster-name is not ready: Dependent cluster objects don't exist: TinkerbellMachineConfig cluster-name-cp")),
+	)
+}
+
+func capiClustersFromFile(t *testing.T, path string) []types.CAPICluster {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
 
-	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
-		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	response := &executables.ClustersResponse{}
+	if err := json.Unmarshal(content, response); err != nil {
+		t.Fatalf("parsing %s: %v", path, err)
 	}
+
+	return response.Items
 }
 
-func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
+func TestClusterManagerCreateEKSAResourcesFailure(t *testing.T) {
 	features.ClearCache()
 	ctx := context.Background()
 	tt := newTest(t)
 	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
 	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+	tt.clusterSpec.Cluster.Namespace = "test_namespace"
 
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	machineConfigs := []providers.MachineConfig{}
 
-	c, m := newClusterManager(t)
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
 
-	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
-	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
-	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, tt.cluster, gomock.Any(), gomock.Any()).MaxTimes(2)
-	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
-	_, ok := datacenterConfig.GetAnnotations()["anywhere.eks.amazonaws.com/paused"]
-	tt.Expect(ok).To(BeTrue())
-	_, ok = tt.clusterSpec.Cluster.GetAnnotations()["anywhere.eks.amazonaws.com/paused"]
-	tt.Expect(ok).To(BeTrue())
+	m.client.EXPECT().CreateNamespaceIfNotPresent(ctx, gomock.Any(), tt.clusterSpec.Cluster.Namespace).Return(errors.New(""))
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).NotTo(Succeed())
 }
 
-func TestClusterManagerCreateEKSAResourcesFailure(t *testing.T) {
+func TestClusterManagerCreateEKSAResourcesRetryNamespaceCreation(t *testing.T) {
 	features.ClearCache()
 	ctx := context.Background()
 	tt := newTest(t)
@@ -1737,13 +4822,26 @@ func TestClusterManagerCreateEKSAResourcesFailure(t *testing.T) {
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	machineConfigs := []providers.MachineConfig{}
 
-	c, m := newClusterManager(t)
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
 
-	m.client.EXPECT().CreateNamespaceIfNotPresent(ctx, gomock.Any(), tt.clusterSpec.Cluster.Namespace).Return(errors.New(""))
-	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).NotTo(Succeed())
+	gomock.InOrder(
+		m.client.EXPECT().CreateNamespaceIfNotPresent(ctx, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(errors.New("api server unavailable")),
+		m.client.EXPECT().CreateNamespaceIfNotPresent(ctx, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil),
+	)
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
 }
 
 func expectedMachineHealthCheck(unhealthyMachineTimeout, nodeStartupTimeout time.Duration) []byte {
+	return expectedMachineHealthCheckWithWorkerMaxUnhealthy(unhealthyMachineTimeout, nodeStartupTimeout, "40%")
+}
+
+func expectedMachineHealthCheckWithWorkerMaxUnhealthy(unhealthyMachineTimeout, nodeStartupTimeout time.Duration, workerMaxUnhealthy string) []byte {
+	return expectedMachineHealthCheckWithNodeStartupTimeouts(unhealthyMachineTimeout, nodeStartupTimeout, nodeStartupTimeout, workerMaxUnhealthy)
+}
+
+func expectedMachineHealthCheckWithNodeStartupTimeouts(unhealthyMachineTimeout, workerNodeStartupTimeout, cpNodeStartupTimeout time.Duration, workerMaxUnhealthy string) []byte {
 	healthCheck := fmt.Sprintf(`apiVersion: cluster.x-k8s.io/v1beta1
 kind: MachineHealthCheck
 metadata:
@@ -1752,7 +4850,7 @@ metadata:
   namespace: eksa-system
 spec:
   clusterName: fluxTestCluster
-  maxUnhealthy: 40%%
+  maxUnhealthy: %[3]s
   nodeStartupTimeout: %[2]s
   selector:
     matchLabels:
@@ -1779,7 +4877,7 @@ metadata:
 spec:
   clusterName: fluxTestCluster
   maxUnhealthy: 100%%
-  nodeStartupTimeout: %[2]s
+  nodeStartupTimeout: %[4]s
   selector:
     matchLabels:
       cluster.x-k8s.io/control-plane: ""
@@ -1796,7 +4894,7 @@ status:
   remediationsAllowed: 0
 
 ---
-`, unhealthyMachineTimeout, nodeStartupTimeout)
+`, unhealthyMachineTimeout, workerNodeStartupTimeout, workerMaxUnhealthy, cpNodeStartupTimeout)
 	return []byte(healthCheck)
 }
 
@@ -1824,6 +4922,33 @@ func TestInstallMachineHealthChecksWithTimeoutOverride(t *testing.T) {
 	}
 }
 
+func TestInstallMachineHealthChecksWithMachineConfigNodeStartupTimeout(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{
+		Kind: v1alpha1.TinkerbellMachineConfigKind,
+		Name: "cp-machine-config",
+	}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef = &v1alpha1.Ref{
+		Kind: v1alpha1.TinkerbellMachineConfigKind,
+		Name: "worker-machine-config",
+	}
+	tt.clusterSpec.TinkerbellMachineConfigs = map[string]*v1alpha1.TinkerbellMachineConfig{
+		"cp-machine-config": {},
+		"worker-machine-config": {
+			Spec: v1alpha1.TinkerbellMachineConfigSpec{
+				NodeStartupTimeout: &metav1.Duration{Duration: 40 * time.Minute},
+			},
+		},
+	}
+	wantMHC := expectedMachineHealthCheckWithNodeStartupTimeouts(clustermanager.DefaultUnhealthyMachineTimeout, 40*time.Minute, clustermanager.DefaultNodeStartupTimeout, "40%")
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, wantMHC)
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+	tt.Expect(string(wantMHC)).To(ContainSubstring("nodeStartupTimeout: 40m0s"))
+	tt.Expect(string(wantMHC)).To(ContainSubstring("nodeStartupTimeout: 10m0s"))
+}
+
 func TestInstallMachineHealthChecksWithNoTimeout(t *testing.T) {
 	tt := newTest(t, clustermanager.WithNoTimeouts())
 	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
@@ -1833,6 +4958,104 @@ func TestInstallMachineHealthChecksWithNoTimeout(t *testing.T) {
 	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
 }
 
+func TestInstallMachineHealthChecksWithWorkerMaxUnhealthy(t *testing.T) {
+	tt := newTest(t, clustermanager.WithWorkerMaxUnhealthy("worker-1", intstr.FromString("50%")))
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+	wantMHC := expectedMachineHealthCheckWithWorkerMaxUnhealthy(clustermanager.DefaultUnhealthyMachineTimeout, clustermanager.DefaultNodeStartupTimeout, "50%")
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, wantMHC)
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallMachineHealthChecksWithWorkerMaxUnhealthyOtherGroup(t *testing.T) {
+	tt := newTest(t, clustermanager.WithWorkerMaxUnhealthy("worker-2", intstr.FromString("50%")))
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+	wantMHC := expectedMachineHealthCheck(clustermanager.DefaultUnhealthyMachineTimeout, clustermanager.DefaultNodeStartupTimeout)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, wantMHC)
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallMachineHealthChecksWithWorkerMaxUnhealthyInvalid(t *testing.T) {
+	tt := newTest(t, clustermanager.WithWorkerMaxUnhealthy("worker-1", intstr.FromString("half")))
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(
+		MatchError(ContainSubstring("invalid maxUnhealthy half for worker node group worker-1")),
+	)
+}
+
+func TestInstallMachineHealthCheckForWorkerGroup(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+	newWorkerNodeGroup := tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	newWorkerNodeGroup.Name = "worker-2"
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newWorkerNodeGroup)
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			docs := strings.Split(strings.TrimSuffix(string(data), "\n---\n"), "\n---\n")
+			tt.Expect(docs).To(HaveLen(1))
+			tt.Expect(docs[0]).To(ContainSubstring("name: fluxTestCluster-worker-2-worker-unhealthy"))
+			tt.Expect(docs[0]).To(ContainSubstring("cluster.x-k8s.io/deployment-name: fluxTestCluster-worker-2"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthCheckForWorkerGroup(tt.ctx, tt.clusterSpec, tt.cluster, "worker-2")).To(Succeed())
+}
+
+func TestInstallMachineHealthCheckForWorkerGroupNotFound(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "worker-1"
+
+	tt.Expect(tt.clusterManager.InstallMachineHealthCheckForWorkerGroup(tt.ctx, tt.clusterSpec, tt.cluster, "worker-2")).To(
+		MatchError("worker node group worker-2 not found in cluster spec"),
+	)
+}
+
+func TestEffectiveMHCTimeouts(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []clustermanager.ClusterManagerOpt
+		want clustermanager.MachineHealthCheckTimeouts
+	}{
+		{
+			name: "defaults",
+			want: clustermanager.MachineHealthCheckTimeouts{
+				UnhealthyMachineTimeout: clustermanager.DefaultUnhealthyMachineTimeout,
+				NodeStartupTimeout:      clustermanager.DefaultNodeStartupTimeout,
+			},
+		},
+		{
+			name: "overrides",
+			opts: []clustermanager.ClusterManagerOpt{
+				clustermanager.WithUnhealthyMachineTimeout(30 * time.Minute),
+				clustermanager.WithNodeStartupTimeout(20 * time.Minute),
+			},
+			want: clustermanager.MachineHealthCheckTimeouts{
+				UnhealthyMachineTimeout: 30 * time.Minute,
+				NodeStartupTimeout:      20 * time.Minute,
+			},
+		},
+		{
+			name: "no timeouts",
+			opts: []clustermanager.ClusterManagerOpt{clustermanager.WithNoTimeouts()},
+			want: clustermanager.MachineHealthCheckTimeouts{
+				UnhealthyMachineTimeout: maxTime,
+				NodeStartupTimeout:      maxTime,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c, _ := newClusterManager(t, tt.opts...)
+			g.Expect(c.EffectiveMHCTimeouts()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestInstallMachineHealthChecksApplyError(t *testing.T) {
 	ctx := context.Background()
 	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
@@ -1974,6 +5197,73 @@ func TestPauseEKSAControllerReconcileManagementCluster(t *testing.T) {
 	tt.Expect(tt.clusterManager.PauseEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
 }
 
+func TestPauseEKSAControllerReconcileForClusters(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster = &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+		},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{
+				Kind: v1alpha1.VSphereDatacenterKind,
+				Name: "data-center-name",
+			},
+			ManagementCluster: v1alpha1.ManagementCluster{
+				Name: tt.clusterName,
+			},
+		},
+	}
+	workloadCluster := func(name string) v1alpha1.Cluster {
+		return v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{
+					Kind: v1alpha1.VSphereDatacenterKind,
+					Name: "data-center-name",
+				},
+				ManagementCluster: v1alpha1.ManagementCluster{
+					Name: tt.clusterName,
+				},
+			},
+		}
+	}
+
+	tt.mocks.client.EXPECT().
+		ListObjects(tt.ctx, eksaClusterResourceType, "", "", &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				*tt.clusterSpec.Cluster,
+				workloadCluster("workload-cluster-1"),
+				workloadCluster("workload-cluster-2"),
+				workloadCluster("workload-cluster-3"),
+			}
+			return nil
+		})
+	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType).Times(3)
+	tt.mocks.provider.EXPECT().MachineResourceType().Return("").Times(3)
+	tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, "data-center-name", expectedPauseAnnotation, tt.cluster, "").Return(nil).Times(3)
+	for _, name := range []string{tt.clusterName, "workload-cluster-1", "workload-cluster-3"} {
+		tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(tt.ctx, eksaClusterResourceType, name, expectedPauseAnnotation, tt.cluster, "").Return(nil)
+		tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(
+			tt.ctx,
+			eksaClusterResourceType,
+			name,
+			map[string]string{
+				v1alpha1.ManagedByCLIAnnotation: "true",
+			},
+			tt.cluster,
+			"",
+		).Return(nil)
+	}
+	tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(gomock.Any(), gomock.Any(), "workload-cluster-2", gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.PauseEKSAControllerReconcileForClusters(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider,
+		[]string{"workload-cluster-1", "workload-cluster-3"},
+	)).To(Succeed())
+}
+
 func TestPauseEKSAControllerReconcileManagementClusterListObjectsError(t *testing.T) {
 	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
 	tt.clusterSpec.Cluster = &v1alpha1.Cluster{
@@ -2096,28 +5386,126 @@ func TestResumeEKSAControllerReconcileWorkloadClusterUpdateAnnotationError(t *te
 			},
 		},
 	}
-
-	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: tt.clusterName,
-		},
-		Spec: v1alpha1.VSphereDatacenterConfigSpec{
-			Insecure: true,
-		},
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+		},
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Insecure: true,
+		},
+	}
+	pauseAnnotation := "anywhere.eks.amazonaws.com/paused"
+
+	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType)
+	tt.mocks.provider.EXPECT().MachineResourceType().Return("")
+	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, tt.clusterSpec.Cluster.Spec.DatacenterRef.Name, pauseAnnotation, tt.cluster, "").Return(nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterSpec.Cluster.Name, pauseAnnotation, tt.cluster, "").Return(errors.New("pause eksa cluster error"))
+
+	tt.Expect(tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).NotTo(Succeed())
+}
+
+func TestResumeEKSAControllerReconcileManagementCluster(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster = &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+		},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{
+				Kind: v1alpha1.VSphereDatacenterKind,
+				Name: "data-center-name",
+			},
+			ManagementCluster: v1alpha1.ManagementCluster{
+				Name: tt.clusterName,
+			},
+		},
+	}
+
+	tt.clusterSpec.Cluster.PauseReconcile()
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+		},
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Insecure: true,
+		},
+	}
+	pauseAnnotation := "anywhere.eks.amazonaws.com/paused"
+
+	tt.mocks.client.EXPECT().
+		ListObjects(tt.ctx, eksaClusterResourceType, "", "", &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				*tt.clusterSpec.Cluster,
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "workload-cluster-1",
+					},
+					Spec: v1alpha1.ClusterSpec{
+						DatacenterRef: v1alpha1.Ref{
+							Kind: v1alpha1.VSphereDatacenterKind,
+							Name: "data-center-name",
+						},
+						ManagementCluster: v1alpha1.ManagementCluster{
+							Name: tt.clusterName,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "workload-cluster-2",
+					},
+					Spec: v1alpha1.ClusterSpec{
+						DatacenterRef: v1alpha1.Ref{
+							Kind: v1alpha1.VSphereDatacenterKind,
+							Name: "data-center-name",
+						},
+						ManagementCluster: v1alpha1.ManagementCluster{
+							Name: "mgmt-cluster-2",
+						},
+					},
+				},
+			}
+			return nil
+		})
+	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType).Times(2)
+	tt.mocks.provider.EXPECT().MachineResourceType().Return("").Times(2)
+	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, tt.clusterSpec.Cluster.Spec.DatacenterRef.Name, pauseAnnotation, tt.cluster, "").Return(nil).Times(2)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterSpec.Cluster.Name, pauseAnnotation, tt.cluster, "").Return(nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(
+		tt.ctx,
+		eksaClusterResourceType,
+		tt.clusterSpec.Cluster.Name,
+		v1alpha1.ManagedByCLIAnnotation,
+		tt.cluster,
+		"",
+	).Return(nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, "workload-cluster-1", pauseAnnotation, tt.cluster, "").Return(nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(
+		tt.ctx,
+		eksaClusterResourceType,
+		"workload-cluster-1",
+		v1alpha1.ManagedByCLIAnnotation,
+		tt.cluster,
+		"",
+	).Return(nil)
+
+	tt.Expect(tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+	annotations := tt.clusterSpec.Cluster.GetAnnotations()
+	if _, ok := annotations[pauseAnnotation]; ok {
+		t.Errorf("%s annotation exists, should be removed", pauseAnnotation)
+	}
+	if _, ok := annotations[v1alpha1.ManagedByCLIAnnotation]; ok {
+		t.Errorf("%s annotation exists, should be removed", v1alpha1.ManagedByCLIAnnotation)
 	}
-	pauseAnnotation := "anywhere.eks.amazonaws.com/paused"
-
-	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType)
-	tt.mocks.provider.EXPECT().MachineResourceType().Return("")
-	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, tt.clusterSpec.Cluster.Spec.DatacenterRef.Name, pauseAnnotation, tt.cluster, "").Return(nil)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterSpec.Cluster.Name, pauseAnnotation, tt.cluster, "").Return(errors.New("pause eksa cluster error"))
-
-	tt.Expect(tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).NotTo(Succeed())
 }
 
-func TestResumeEKSAControllerReconcileManagementCluster(t *testing.T) {
-	tt := newTest(t)
+func TestResumeEKSAControllerReconcileManagementClusterPartialFailure(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
 	tt.clusterSpec.Cluster = &v1alpha1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: tt.clusterName,
@@ -2139,9 +5527,6 @@ func TestResumeEKSAControllerReconcileManagementCluster(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: tt.clusterName,
 		},
-		Spec: v1alpha1.VSphereDatacenterConfigSpec{
-			Insecure: true,
-		},
 	}
 	pauseAnnotation := "anywhere.eks.amazonaws.com/paused"
 
@@ -2171,47 +5556,33 @@ func TestResumeEKSAControllerReconcileManagementCluster(t *testing.T) {
 					Spec: v1alpha1.ClusterSpec{
 						DatacenterRef: v1alpha1.Ref{
 							Kind: v1alpha1.VSphereDatacenterKind,
-							Name: "data-center-name",
+							Name: "data-center-2",
 						},
 						ManagementCluster: v1alpha1.ManagementCluster{
-							Name: "mgmt-cluster-2",
+							Name: tt.clusterName,
 						},
 					},
 				},
 			}
 			return nil
 		})
-	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType).Times(2)
+	tt.mocks.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType).Times(3)
 	tt.mocks.provider.EXPECT().MachineResourceType().Return("").Times(2)
 	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, tt.clusterSpec.Cluster.Spec.DatacenterRef.Name, pauseAnnotation, tt.cluster, "").Return(nil).Times(2)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterSpec.Cluster.Name, pauseAnnotation, tt.cluster, "").Return(nil)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(
-		tt.ctx,
-		eksaClusterResourceType,
-		tt.clusterSpec.Cluster.Name,
-		v1alpha1.ManagedByCLIAnnotation,
-		tt.cluster,
-		"",
-	).Return(nil)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, "workload-cluster-1", pauseAnnotation, tt.cluster, "").Return(nil)
-	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(
-		tt.ctx,
-		eksaClusterResourceType,
-		"workload-cluster-1",
-		v1alpha1.ManagedByCLIAnnotation,
-		tt.cluster,
-		"",
-	).Return(nil)
-
-	tt.Expect(tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
-	annotations := tt.clusterSpec.Cluster.GetAnnotations()
-	if _, ok := annotations[pauseAnnotation]; ok {
-		t.Errorf("%s annotation exists, should be removed", pauseAnnotation)
-	}
-	if _, ok := annotations[v1alpha1.ManagedByCLIAnnotation]; ok {
-		t.Errorf("%s annotation exists, should be removed", v1alpha1.ManagedByCLIAnnotation)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, "data-center-name", pauseAnnotation, tt.cluster, "").Return(nil).Times(2)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaVSphereDatacenterResourceType, "data-center-2", pauseAnnotation, tt.cluster, "").Return(errors.New("connection refused"))
+	for _, name := range []string{tt.clusterName, "workload-cluster-1"} {
+		tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, name, pauseAnnotation, tt.cluster, "").Return(nil)
+		tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, name, v1alpha1.ManagedByCLIAnnotation, tt.cluster, "").Return(nil)
 	}
+
+	err := tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError("resuming reconciliation for clusters workload-cluster-2: cluster workload-cluster-2: removing paused annotation when resuming datacenterconfig reconciliation: connection refused"))
+
+	resumeErr := &clustermanager.ResumeReconcileError{}
+	tt.Expect(errors.As(err, &resumeErr)).To(BeTrue())
+	tt.Expect(resumeErr.ResumedClusters()).To(Equal([]string{tt.clusterName, "workload-cluster-1"}))
+	tt.Expect(resumeErr.FailedClusters()).To(Equal([]string{"workload-cluster-2"}))
 }
 
 func TestResumeEKSAControllerReconcileManagementClusterListObjectsError(t *testing.T) {
@@ -2242,6 +5613,80 @@ func TestResumeEKSAControllerReconcileManagementClusterListObjectsError(t *testi
 	tt.Expect(tt.clusterManager.ResumeEKSAControllerReconcile(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).NotTo(Succeed())
 }
 
+func TestEnsureClusterPausedAlreadyPaused(t *testing.T) {
+	tt := newTest(t)
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tt.clusterName,
+			Namespace: "eksa-system",
+			Annotations: map[string]string{
+				"anywhere.eks.amazonaws.com/paused": "true",
+				v1alpha1.ManagedByCLIAnnotation:     "true",
+			},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterName).Return(eksaCluster, nil)
+
+	tt.Expect(tt.clusterManager.EnsureClusterPaused(tt.ctx, tt.cluster, tt.clusterName, true)).To(Succeed())
+}
+
+func TestEnsureClusterPausedPause(t *testing.T) {
+	tt := newTest(t)
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tt.clusterName,
+			Namespace: "eksa-system",
+		},
+	}
+	annotations := map[string]string{
+		"anywhere.eks.amazonaws.com/paused": "true",
+		v1alpha1.ManagedByCLIAnnotation:     "true",
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterName).Return(eksaCluster, nil)
+	tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterName, annotations, tt.cluster, "eksa-system").Return(nil)
+
+	tt.Expect(tt.clusterManager.EnsureClusterPaused(tt.ctx, tt.cluster, tt.clusterName, true)).To(Succeed())
+}
+
+func TestEnsureClusterPausedUnpause(t *testing.T) {
+	tt := newTest(t)
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tt.clusterName,
+			Namespace: "eksa-system",
+			Annotations: map[string]string{
+				"anywhere.eks.amazonaws.com/paused": "true",
+				v1alpha1.ManagedByCLIAnnotation:     "true",
+			},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterName).Return(eksaCluster, nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterName, "anywhere.eks.amazonaws.com/paused", tt.cluster, "eksa-system").Return(nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterName, v1alpha1.ManagedByCLIAnnotation, tt.cluster, "eksa-system").Return(nil)
+
+	tt.Expect(tt.clusterManager.EnsureClusterPaused(tt.ctx, tt.cluster, tt.clusterName, false)).To(Succeed())
+}
+
+func TestEnsureClusterPausedUnpauseError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tt.clusterName,
+			Annotations: map[string]string{
+				"anywhere.eks.amazonaws.com/paused": "true",
+			},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterName).Return(eksaCluster, nil)
+	tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(tt.ctx, eksaClusterResourceType, tt.clusterName, "anywhere.eks.amazonaws.com/paused", tt.cluster, "").Return(errors.New("remove error"))
+
+	tt.Expect(tt.clusterManager.EnsureClusterPaused(tt.ctx, tt.cluster, tt.clusterName, false)).To(MatchError(ContainSubstring("remove error")))
+}
+
 func TestClusterManagerInstallCustomComponentsSuccess(t *testing.T) {
 	features.ClearCache()
 	tt := newTest(t)
@@ -2370,6 +5815,19 @@ func TestClusterManagerClusterSpecChangedNoChanges(t *testing.T) {
 	assert.False(t, diff, "No changes should have been detected")
 }
 
+func TestClusterManagerClusterSpecChangedNoIdentityProviders(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.oldClusterConfig.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	assert.Nil(t, err, "Error should be nil")
+	assert.False(t, diff, "No changes should have been detected")
+}
+
 func TestClusterManagerClusterSpecChangedClusterChanged(t *testing.T) {
 	tt := newSpecChangedTest(t)
 	tt.newClusterConfig.Spec.KubernetesVersion = "1.20"
@@ -2412,24 +5870,251 @@ func TestClusterManagerClusterSpecChangedGitOpsDefault(t *testing.T) {
 	assert.False(t, diff, "No changes should have been detected")
 }
 
-func TestClusterManagerClusterSpecChangedAWSIamConfigChanged(t *testing.T) {
+func TestClusterManagerClusterSpecChangedAWSIamConfigChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: tt.clusterName}}
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
+	oldIamConfig := tt.clusterSpec.AWSIamConfig.DeepCopy()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{Spec: v1alpha1.AWSIamConfigSpec{
+		MapRoles: []v1alpha1.MapRoles{},
+	}}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, diff, "Changes should have been detected")
+}
+
+func TestClusterManagerClusterSpecDiffKubernetesVersionChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.KubernetesVersion = "1.20"
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.oldClusterConfig.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecDiff(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(Equal(&clustermanager.SpecDiff{Cluster: true}))
+	tt.Expect(diff.Changed()).To(BeTrue())
+}
+
+func TestClusterManagerClusterSpecDiffEksDReleaseChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Name = "kubernetes-1-19-eks-5"
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecDiff(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(Equal(&clustermanager.SpecDiff{EksdRelease: true}))
+}
+
+func TestClusterManagerClusterSpecDiffAWSIamConfigChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: tt.clusterName}}
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
+	oldIamConfig := tt.clusterSpec.AWSIamConfig.DeepCopy()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{Spec: v1alpha1.AWSIamConfigSpec{
+		MapRoles: []v1alpha1.MapRoles{},
+	}}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecDiff(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(Equal(&clustermanager.SpecDiff{AWSIamConfig: true}))
+}
+
+func newFluxConfigChangedTest(t *testing.T) *specChangedTest {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: tt.clusterName}
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
+	oldFluxConfig := &v1alpha1.FluxConfig{Spec: v1alpha1.FluxConfigSpec{Branch: "main"}}
+	tt.clusterSpec.FluxConfig = &v1alpha1.FluxConfig{Spec: v1alpha1.FluxConfigSpec{Branch: "dev"}}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetEksaFluxConfig(tt.ctx, tt.clusterName, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldFluxConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+
+	return tt
+}
+
+func TestClusterManagerClusterSpecDiffFluxConfigChanged(t *testing.T) {
+	tt := newFluxConfigChangedTest(t)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecDiff(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(Equal(&clustermanager.SpecDiff{GitOpsConfig: true}))
+}
+
+func TestClusterManagerClusterSpecChangedFluxConfigOnlyChanged(t *testing.T) {
+	tt := newFluxConfigChangedTest(t)
+
+	changed, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(changed).To(BeFalse(), "GitOps only changes shouldn't be reported")
+}
+
+func TestClusterManagerClusterSpecDiffNoChanges(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.oldClusterConfig.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecDiff(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff.Changed()).To(BeFalse())
+}
+
+func TestClusterManagerReconcileIdentityProvidersAWSIamConfigChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: tt.clusterName}}
+	oldIamConfig := &v1alpha1.AWSIamConfig{ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName}}
+	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			MapRoles: []v1alpha1.MapRoles{{RoleARN: "arn:aws:iam::123456789012:role/admin", Username: "admin"}},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterName, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("roleARN: arn:aws:iam::123456789012:role/admin"))
+			tt.Expect(string(data)).NotTo(ContainSubstring("controlPlaneConfiguration"))
+			return nil
+		},
+	)
+	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec).Return(nil)
+	// An identity only change must not touch any CAPI machine templates.
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.ReconcileIdentityProviders(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerReconcileIdentityProvidersNoChanges(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterName, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+
+	tt.Expect(tt.clusterManager.ReconcileIdentityProviders(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerReconcileIdentityProvidersAWSIamUpgradeError(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: tt.clusterName}}
+	oldIamConfig := &v1alpha1.AWSIamConfig{ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName}}
+	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			MapUsers: []v1alpha1.MapUsers{{UserARN: "arn:aws:iam::123456789012:user/admin", Username: "admin"}},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterName, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec).Return(errors.New("error"))
+
+	tt.Expect(tt.clusterManager.ReconcileIdentityProviders(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec)).To(
+		MatchError("running aws-iam-authenticator upgrade operations: error"),
+	)
+}
+
+func TestClusterManagerReconcileIdentityProvidersAWSIamConfigAdded(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			MapRoles: []v1alpha1.MapRoles{{RoleARN: "arn:aws:iam::123456789012:role/admin", Username: "admin"}},
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterName, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesForce(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.ReconcileIdentityProviders(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec)).To(
+		MatchError("adding AWSIamConfig to cluster " + tt.clusterName + " requires a full UpgradeCluster"),
+	)
+}
+
+func TestClusterManagerReconcileAWSIAMAuthSuccess(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			AWSRegion:   "us-west-2",
+			BackendMode: []string{"EKSConfigMap"},
+			MapRoles:    []v1alpha1.MapRoles{{RoleARN: "arn:aws:iam::123456789012:role/admin", Username: "admin"}},
+		},
+	}
+
+	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec).Return(nil)
+	// Reconciling aws-iam-authenticator must not touch any CAPI objects.
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.clusterManager.ReconcileAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerReconcileAWSIAMAuthNoConfig(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.AWSIamConfig = nil
+
+	tt.Expect(tt.clusterManager.ReconcileAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError("no AWSIamConfig in cluster spec to reconcile"),
+	)
+}
+
+func TestClusterManagerReconcileAWSIAMAuthInvalidConfig(t *testing.T) {
 	tt := newSpecChangedTest(t)
-	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: tt.clusterName}}
-	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
-	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
-	oldIamConfig := tt.clusterSpec.AWSIamConfig.DeepCopy()
-	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{Spec: v1alpha1.AWSIamConfigSpec{
-		MapRoles: []v1alpha1.MapRoles{},
-	}}
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+	}
 
-	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
-	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any()).Return(test.EksdRelease(), nil)
-	tt.mocks.client.EXPECT().GetEksaAWSIamConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldIamConfig, nil)
-	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.mocks.awsIamAuth.EXPECT().UpgradeAWSIAMAuth(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-	assert.Nil(t, err, "Error should be nil")
-	assert.True(t, diff, "Changes should have been detected")
+	tt.Expect(tt.clusterManager.ReconcileAWSIAMAuth(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError("validating AWSIamConfig: AWSIamConfig AWSRegion is a required field"),
+	)
 }
 
 type testSetup struct {
@@ -2519,6 +6204,18 @@ func TestClusterManagerGetCurrentClusterSpecGetClusterError(t *testing.T) {
 	tt.Expect(err).ToNot(BeNil())
 }
 
+func TestClusterManagerGetCurrentClusterSpecClusterNotFound(t *testing.T) {
+	tt := newTest(t)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "clusters"}, tt.clusterName)
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterName).Return(nil, notFound)
+
+	_, err := tt.clusterManager.GetCurrentClusterSpec(tt.ctx, tt.cluster, tt.clusterName)
+	tt.Expect(err).To(MatchError(ContainSubstring(
+		fmt.Sprintf("EKS-A cluster %s not found; it may need to be adopted or recreated", tt.clusterName),
+	)))
+}
+
 func TestClusterManagerGetCurrentClusterSpecGetBundlesError(t *testing.T) {
 	tt := newTest(t)
 
@@ -2541,18 +6238,144 @@ func TestClusterManagerDeletePackageResources(t *testing.T) {
 func TestCreateAwsIamAuthCaSecretSuccess(t *testing.T) {
 	tt := newTest(t)
 
+	tt.mocks.awsIamAuth.EXPECT().CASecretExists(tt.ctx, tt.cluster, tt.clusterName).Return(false, nil)
 	tt.mocks.awsIamAuth.EXPECT().CreateAndInstallAWSIAMAuthCASecret(tt.ctx, tt.cluster, tt.clusterName).Return(nil)
 
 	err := tt.clusterManager.CreateAwsIamAuthCaSecret(tt.ctx, tt.cluster, tt.clusterName)
 	tt.Expect(err).To(BeNil())
 }
 
+func TestCreateAwsIamAuthCaSecretAlreadyExists(t *testing.T) {
+	tt := newTest(t)
+
+	tt.mocks.awsIamAuth.EXPECT().CASecretExists(tt.ctx, tt.cluster, tt.clusterName).Return(true, nil)
+
+	err := tt.clusterManager.CreateAwsIamAuthCaSecret(tt.ctx, tt.cluster, tt.clusterName)
+	tt.Expect(err).To(BeNil())
+}
+
+func TestCreateAwsIamAuthCaSecretExistsError(t *testing.T) {
+	tt := newTest(t)
+
+	tt.mocks.awsIamAuth.EXPECT().CASecretExists(tt.ctx, tt.cluster, tt.clusterName).Return(false, errors.New("error getting secret"))
+
+	err := tt.clusterManager.CreateAwsIamAuthCaSecret(tt.ctx, tt.cluster, tt.clusterName)
+	tt.Expect(err).To(MatchError("checking aws-iam-authenticator ca secret: error getting secret"))
+}
+
 func TestClusterManagerDeleteClusterSelfManagedCluster(t *testing.T) {
 	tt := newTest(t)
 	managementCluster := &types.Cluster{
 		Name: "m-cluster",
 	}
 
+	tt.mocks.client.EXPECT().ListObjectsInAllNamespaces(tt.ctx, eksaClusterResourceType, tt.cluster.KubeconfigFile, &v1alpha1.ClusterList{})
+	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster)
+	tt.mocks.provider.EXPECT().PostClusterDeleteValidate(tt.ctx, managementCluster)
+
+	tt.Expect(
+		tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec),
+	).To(Succeed())
+}
+
+func (tt *testSetup) expectListManagedWorkloadClusters() *gomock.Call {
+	return tt.mocks.client.EXPECT().
+		ListObjectsInAllNamespaces(tt.ctx, eksaClusterResourceType, tt.cluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				*tt.clusterSpec.Cluster.DeepCopy(),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workload-1", Namespace: "default"},
+					Spec: v1alpha1.ClusterSpec{
+						ManagementCluster: v1alpha1.ManagementCluster{Name: tt.clusterSpec.Cluster.Name},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-workload", Namespace: "default"},
+					Spec: v1alpha1.ClusterSpec{
+						ManagementCluster: v1alpha1.ManagementCluster{Name: "other-management"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workload-2", Namespace: "team"},
+					Spec: v1alpha1.ClusterSpec{
+						ManagementCluster: v1alpha1.ManagementCluster{Name: tt.clusterSpec.Cluster.Name},
+					},
+				},
+			}
+			return nil
+		})
+}
+
+func TestClusterManagerDeleteClusterSelfManagedClusterWithWorkloadClusters(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+
+	tt.expectListManagedWorkloadClusters()
+	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster).Times(0)
+
+	tt.Expect(
+		tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec),
+	).To(MatchError(fmt.Sprintf(
+		"management cluster %s still manages workload clusters default/workload-1, team/workload-2, delete them first or force the deletion",
+		tt.clusterSpec.Cluster.Name,
+	)))
+}
+
+func TestClusterManagerDeleteClusterSelfManagedClusterWithWorkloadClusterInOtherNamespace(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+
+	tt.mocks.client.EXPECT().
+		ListObjectsInAllNamespaces(tt.ctx, eksaClusterResourceType, tt.cluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj *v1alpha1.ClusterList) error {
+			obj.Items = []v1alpha1.Cluster{
+				*tt.clusterSpec.Cluster.DeepCopy(),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "team-a"},
+					Spec: v1alpha1.ClusterSpec{
+						ManagementCluster: v1alpha1.ManagementCluster{Name: tt.clusterSpec.Cluster.Name},
+					},
+				},
+			}
+			return nil
+		})
+	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster).Times(0)
+
+	tt.Expect(
+		tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec),
+	).To(MatchError(fmt.Sprintf(
+		"management cluster %s still manages workload clusters team-a/workload, delete them first or force the deletion",
+		tt.clusterSpec.Cluster.Name,
+	)))
+}
+
+func TestClusterManagerDeleteClusterSelfManagedClusterListError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+
+	tt.mocks.client.EXPECT().
+		ListObjectsInAllNamespaces(tt.ctx, eksaClusterResourceType, tt.cluster.KubeconfigFile, &v1alpha1.ClusterList{}).
+		Return(errors.New("list error"))
+
+	tt.Expect(
+		tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec),
+	).To(MatchError(ContainSubstring("list error")))
+}
+
+func TestClusterManagerDeleteClusterSelfManagedClusterWithWorkloadClustersForceDelete(t *testing.T) {
+	tt := newTest(t, clustermanager.WithForceDelete())
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+
+	tt.mocks.client.EXPECT().ListObjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster)
 	tt.mocks.provider.EXPECT().PostClusterDeleteValidate(tt.ctx, managementCluster)
 
@@ -2601,3 +6424,335 @@ func TestClusterManagerDeleteClusterManagedCluster(t *testing.T) {
 		tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec),
 	).To(Succeed())
 }
+
+func TestClusterManagerDeleteClusterWithSummaryManagedCluster(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+	tt.clusterSpec.Cluster.SetManagedBy("m-cluster")
+	tt.clusterSpec.GitOpsConfig = &v1alpha1.GitOpsConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-config-git",
+			Namespace: "my-ns",
+		},
+	}
+	tt.clusterSpec.OIDCConfig = &v1alpha1.OIDCConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-config-oidc",
+			Namespace: "my-ns",
+		},
+	}
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-config-aws",
+			Namespace: "my-ns",
+		},
+	}
+
+	gomock.InOrder(
+		tt.expectPauseClusterReconciliation(),
+		tt.mocks.client.EXPECT().DeleteEKSACluster(tt.ctx, managementCluster, tt.clusterSpec.Cluster.Name, tt.clusterSpec.Cluster.Namespace),
+		tt.mocks.client.EXPECT().DeleteGitOpsConfig(tt.ctx, managementCluster, "my-config-git", "my-ns"),
+		tt.mocks.client.EXPECT().DeleteOIDCConfig(tt.ctx, managementCluster, "my-config-oidc", "my-ns"),
+		tt.mocks.client.EXPECT().DeleteAWSIamConfig(tt.ctx, managementCluster, "my-config-aws", "my-ns"),
+		tt.mocks.provider.EXPECT().DeleteResources(tt.ctx, tt.clusterSpec),
+		tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster),
+		tt.mocks.provider.EXPECT().PostClusterDeleteValidate(tt.ctx, managementCluster),
+	)
+
+	summary, err := tt.clusterManager.DeleteClusterWithSummary(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(summary).To(Equal(&clustermanager.DeleteSummary{
+		Cluster: tt.clusterSpec.Cluster.Name,
+		Managed: true,
+		Steps: []clustermanager.DeleteStep{
+			{Name: clustermanager.DeleteStepPauseReconcile, Object: tt.clusterSpec.Cluster.Name},
+			{Name: clustermanager.DeleteStepEKSACluster, Object: tt.clusterSpec.Cluster.Name},
+			{Name: clustermanager.DeleteStepGitOpsConfig, Object: "my-config-git"},
+			{Name: clustermanager.DeleteStepOIDCConfig, Object: "my-config-oidc"},
+			{Name: clustermanager.DeleteStepAWSIamConfig, Object: "my-config-aws"},
+			{Name: clustermanager.DeleteStepProviderResources},
+			{Name: clustermanager.DeleteStepCAPICluster, Object: tt.cluster.Name},
+			{Name: clustermanager.DeleteStepPostDeleteValidate},
+		},
+	}))
+}
+
+func TestClusterManagerDeleteClusterWithSummaryRecordsFailedStep(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	managementCluster := &types.Cluster{
+		Name: "m-cluster",
+	}
+	tt.clusterSpec.Cluster.SetManagedBy("m-cluster")
+	errDelete := errors.New("deleting eksa cluster")
+
+	gomock.InOrder(
+		tt.expectPauseClusterReconciliation(),
+		tt.mocks.client.EXPECT().DeleteEKSACluster(tt.ctx, managementCluster, tt.clusterSpec.Cluster.Name, tt.clusterSpec.Cluster.Namespace).Return(errDelete),
+	)
+
+	summary, err := tt.clusterManager.DeleteClusterWithSummary(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(errDelete))
+	tt.Expect(summary.Steps).To(Equal([]clustermanager.DeleteStep{
+		{Name: clustermanager.DeleteStepPauseReconcile, Object: tt.clusterSpec.Cluster.Name},
+		{Name: clustermanager.DeleteStepEKSACluster, Object: tt.clusterSpec.Cluster.Name, Err: errDelete},
+	}))
+}
+
+func TestClusterManagerSetNodeGroupMaintenance(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{
+			name:    "enable maintenance",
+			enabled: true,
+		},
+		{
+			name:    "disable maintenance",
+			enabled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+			eksaCluster := &v1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+				Spec: v1alpha1.ClusterSpec{
+					WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+				},
+			}
+			c, m := newClusterManager(t)
+			m.client.EXPECT().GetEksaCluster(ctx, cluster, "workload").Return(eksaCluster, nil)
+			machines := []types.Machine{
+				{Metadata: types.MachineMetadata{Name: "machine-1"}},
+				{Metadata: types.MachineMetadata{Name: "machine-2"}},
+			}
+			m.client.EXPECT().GetMachinesForMachineDeployment(ctx, cluster, "workload-md-0").Return(machines, nil)
+			for _, machine := range machines {
+				if tt.enabled {
+					m.client.EXPECT().UpdateAnnotationInNamespace(ctx, "machines.cluster.x-k8s.io", machine.Metadata.Name,
+						map[string]string{"cluster.x-k8s.io/skip-remediation": ""}, cluster, constants.EksaSystemNamespace,
+					)
+				} else {
+					m.client.EXPECT().RemoveAnnotationInNamespace(ctx, "machines.cluster.x-k8s.io", machine.Metadata.Name,
+						"cluster.x-k8s.io/skip-remediation", cluster, constants.EksaSystemNamespace,
+					)
+				}
+			}
+
+			g.Expect(c.SetNodeGroupMaintenance(ctx, cluster, "workload", "md-0", tt.enabled)).To(Succeed())
+		})
+	}
+}
+
+func TestClusterManagerSetNodeGroupMaintenanceGetMachinesError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+		Spec: v1alpha1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetEksaCluster(ctx, cluster, "workload").Return(eksaCluster, nil)
+	m.client.EXPECT().GetMachinesForMachineDeployment(ctx, cluster, "workload-md-0").Return(nil, errors.New("get machines error"))
+
+	g.Expect(c.SetNodeGroupMaintenance(ctx, cluster, "workload", "md-0", true)).To(MatchError(ContainSubstring("getting machines for node group md-0")))
+}
+
+func TestClusterManagerSetNodeGroupMaintenanceAnnotateError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+		Spec: v1alpha1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetEksaCluster(ctx, cluster, "workload").Return(eksaCluster, nil)
+	m.client.EXPECT().GetMachinesForMachineDeployment(ctx, cluster, "workload-md-0").Return([]types.Machine{{Metadata: types.MachineMetadata{Name: "machine-1"}}}, nil)
+	m.client.EXPECT().UpdateAnnotationInNamespace(ctx, "machines.cluster.x-k8s.io", "machine-1",
+		map[string]string{"cluster.x-k8s.io/skip-remediation": ""}, cluster, constants.EksaSystemNamespace,
+	).Return(errors.New("annotate error"))
+
+	g.Expect(c.SetNodeGroupMaintenance(ctx, cluster, "workload", "md-0", true)).To(MatchError(ContainSubstring("updating maintenance annotation for machine machine-1")))
+}
+
+func TestClusterManagerSetNodeGroupMaintenanceGetClusterError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetEksaCluster(ctx, cluster, "workload").Return(nil, errors.New("get cluster error"))
+
+	g.Expect(c.SetNodeGroupMaintenance(ctx, cluster, "workload", "md-0", true)).To(MatchError(ContainSubstring("getting eks-a cluster to set node group maintenance")))
+}
+
+func TestClusterManagerSetNodeGroupMaintenanceUnknownGroup(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+		Spec: v1alpha1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetEksaCluster(ctx, cluster, "workload").Return(eksaCluster, nil)
+
+	g.Expect(c.SetNodeGroupMaintenance(ctx, cluster, "workload", "md-1", true)).To(MatchError("worker node group md-1 not found in cluster workload"))
+}
+
+func TestClusterManagerValidateClusterConnectionSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "testdata/kubeconfig.yaml"
+	tt.mocks.client.EXPECT().Version(tt.ctx, tt.cluster).Return(&executables.VersionResponse{}, nil)
+
+	tt.Expect(tt.clusterManager.ValidateClusterConnection(tt.ctx, tt.cluster)).To(Succeed())
+}
+
+func TestClusterManagerValidateClusterConnectionMissingKubeconfig(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "testdata/missing.kubeconfig"
+
+	tt.Expect(tt.clusterManager.ValidateClusterConnection(tt.ctx, tt.cluster)).To(
+		MatchError(ContainSubstring("invalid kubeconfig for cluster cluster-name")),
+	)
+}
+
+func TestClusterManagerValidateClusterConnectionUnreachable(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "testdata/kubeconfig.yaml"
+	tt.mocks.client.EXPECT().Version(tt.ctx, tt.cluster).Return(nil, errors.New("connection refused"))
+
+	tt.Expect(tt.clusterManager.ValidateClusterConnection(tt.ctx, tt.cluster)).To(
+		MatchError("cluster cluster-name is not reachable with kubeconfig testdata/kubeconfig.yaml: connection refused"),
+	)
+}
+
+func TestClusterManagerCreateWorkloadClusterUnreachableManagementCluster(t *testing.T) {
+	tt := newTest(t, clustermanager.WithClusterConnectionValidation())
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+	tt.cluster.KubeconfigFile = "testdata/kubeconfig.yaml"
+	tt.mocks.client.EXPECT().Version(tt.ctx, tt.cluster).Return(nil, errors.New("connection refused"))
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := tt.clusterManager.CreateWorkloadCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster cluster-name is not reachable")))
+}
+
+func TestClusterManagerUpgradeClusterUnreachableManagementCluster(t *testing.T) {
+	tt := newTest(t, clustermanager.WithClusterConnectionValidation())
+	tt.cluster.KubeconfigFile = "testdata/missing.kubeconfig"
+	tt.mocks.client.EXPECT().GetEksaCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := tt.clusterManager.UpgradeCluster(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("invalid kubeconfig for cluster cluster-name")))
+}
+
+func TestClusterManagerMoveCAPIUnreachableTargetCluster(t *testing.T) {
+	tt := newTest(t, clustermanager.WithClusterConnectionValidation())
+	from := &types.Cluster{Name: "from-cluster", KubeconfigFile: "testdata/kubeconfig.yaml"}
+	to := &types.Cluster{Name: "to-cluster", KubeconfigFile: "testdata/missing.kubeconfig"}
+	tt.mocks.client.EXPECT().Version(tt.ctx, from).Return(&executables.VersionResponse{}, nil)
+	tt.mocks.client.EXPECT().GetMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := tt.clusterManager.MoveCAPI(tt.ctx, from, to, tt.clusterName, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("invalid kubeconfig for cluster to-cluster")))
+}
+
+func TestClusterManagerCountReadyControlPlaneNodes(t *testing.T) {
+	cpLabels := map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+	healthy := types.MachineStatus{
+		NodeRef:    &types.ResourceRef{},
+		Conditions: types.Conditions{{Type: "NodeHealthy", Status: "True"}},
+	}
+	unhealthy := types.MachineStatus{
+		NodeRef:    &types.ResourceRef{},
+		Conditions: types.Conditions{{Type: "NodeHealthy", Status: "False"}},
+	}
+
+	tests := []struct {
+		name      string
+		machines  []types.Machine
+		wantReady int
+	}{
+		{
+			name:      "zero machines",
+			machines:  []types.Machine{},
+			wantReady: 0,
+		},
+		{
+			name: "machines without node ref",
+			machines: []types.Machine{
+				{Metadata: types.MachineMetadata{Name: "cp-0", Labels: cpLabels}},
+				{Metadata: types.MachineMetadata{Name: "cp-1", Labels: cpLabels}, Status: types.MachineStatus{
+					Conditions: healthy.Conditions,
+				}},
+			},
+			wantReady: 0,
+		},
+		{
+			name: "healthy and unhealthy machines",
+			machines: []types.Machine{
+				{Metadata: types.MachineMetadata{Name: "cp-0", Labels: cpLabels}, Status: healthy},
+				{Metadata: types.MachineMetadata{Name: "cp-1", Labels: cpLabels}, Status: unhealthy},
+				{Metadata: types.MachineMetadata{Name: "cp-2", Labels: cpLabels}, Status: healthy},
+				{Metadata: types.MachineMetadata{
+					Name:   "md-0",
+					Labels: map[string]string{clusterv1.MachineDeploymentLabelName: ""},
+				}, Status: healthy},
+			},
+			wantReady: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mgmtCluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "mgmt-kubeconfig"}
+			c, m := newClusterManager(t)
+
+			kcp, _ := getKcpAndMdsForNodeCount(3)
+			m.client.EXPECT().GetKubeadmControlPlane(ctx,
+				mgmtCluster,
+				mgmtCluster.Name,
+				gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+				gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+			).Return(kcp, nil)
+			m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Return(tt.machines, nil)
+
+			ready, total, err := c.CountReadyControlPlaneNodes(ctx, mgmtCluster, mgmtCluster.Name)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ready).To(Equal(tt.wantReady))
+			g.Expect(total).To(Equal(3))
+		})
+	}
+}
+
+func TestClusterManagerCountReadyControlPlaneNodesErrorGettingKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "mgmt-kubeconfig"}
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	m.client.EXPECT().GetKubeadmControlPlane(ctx,
+		mgmtCluster,
+		mgmtCluster.Name,
+		gomock.AssignableToTypeOf(executables.WithCluster(mgmtCluster)),
+		gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace)),
+	).Return(nil, errors.New("kcp not found"))
+
+	_, _, err := c.CountReadyControlPlaneNodes(ctx, mgmtCluster, mgmtCluster.Name)
+	g.Expect(err).To(MatchError(ContainSubstring("getting the total count of control plane nodes")))
+}
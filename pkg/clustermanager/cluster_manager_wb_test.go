@@ -0,0 +1,23 @@
+package clustermanager
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// WithSleep overrides the function the cluster manager uses to pause between operations.
+func WithSleep(sleep func(context.Context, time.Duration) error) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.sleep = sleep
+	}
+}
+
+func TestSleepWithContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sleepWithContext(ctx, time.Hour); err != context.Canceled {
+		t.Fatalf("sleepWithContext() error = %v, want %v", err, context.Canceled)
+	}
+}
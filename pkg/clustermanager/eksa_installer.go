@@ -33,6 +33,9 @@ type EKSAInstaller struct {
 	client                KubernetesClient
 	reader                manifests.FileReader
 	deploymentWaitTimeout time.Duration
+	// fieldManager is the manager of the fields applied through a server side apply. A client side
+	// apply with kubectl's default manager is used when empty.
+	fieldManager string
 }
 
 // NewEKSAInstaller constructs a new EKSAInstaller.
@@ -57,6 +60,15 @@ func WithEKSAInstallerNoTimeouts() EKSAInstallerOpt {
 	}
 }
 
+// WithEKSAInstallerFieldManager makes the installer apply the eks-a components with a server side
+// apply, recording fieldManager as the manager of the applied fields so they don't conflict with other
+// managers of the same objects, like a GitOps controller.
+func WithEKSAInstallerFieldManager(fieldManager string) EKSAInstallerOpt {
+	return func(i *EKSAInstaller) {
+		i.fieldManager = fieldManager
+	}
+}
+
 // Install configures and applies eks-a components in a cluster accordingly to a spec.
 func (i *EKSAInstaller) Install(ctx context.Context, log logr.Logger, cluster *types.Cluster, spec *cluster.Spec) error {
 	generator := EKSAComponentGenerator{log: log, reader: i.reader}
@@ -72,7 +84,12 @@ func (i *EKSAInstaller) Install(ctx context.Context, log logr.Logger, cluster *t
 	}
 
 	for _, o := range objs {
-		if err = i.client.Apply(ctx, cluster.KubeconfigFile, o); err != nil {
+		if i.fieldManager != "" {
+			err = i.client.ApplyWithFieldManager(ctx, cluster.KubeconfigFile, o, i.fieldManager)
+		} else {
+			err = i.client.Apply(ctx, cluster.KubeconfigFile, o)
+		}
+		if err != nil {
 			return fmt.Errorf("applying eksa components: %v", err)
 		}
 	}
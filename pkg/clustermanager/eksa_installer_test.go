@@ -76,6 +76,16 @@ func TestEKSAInstallerInstallSuccessWithRealManifest(t *testing.T) {
 	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
 }
 
+func TestEKSAInstallerInstallWithFieldManager(t *testing.T) {
+	tt := newInstallerTest(t, clustermanager.WithEKSAInstallerFieldManager("flux"))
+	tt.newSpec.VersionsBundle.Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
+	tt.client.EXPECT().ApplyWithFieldManager(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}), "flux")
+	tt.client.EXPECT().ApplyWithFieldManager(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any(), "flux").Times(33) // there are 33 objects in the manifest
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m0s", "Available", "eksa-controller-manager", "eksa-system")
+
+	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
+}
+
 func TestEKSAInstallerInstallSuccessWithTestManifest(t *testing.T) {
 	tt := newInstallerTest(t)
 	tt.newSpec.VersionsBundle.Eksa.Components.URI = "testdata/eksa_components.yaml"
@@ -190,6 +200,21 @@ func TestInstallerUpgradeSuccess(t *testing.T) {
 	tt.Expect(tt.installer.Upgrade(tt.ctx, tt.log, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
 }
 
+func TestInstallerUpgradeWithFieldManager(t *testing.T) {
+	tt := newInstallerTest(t, clustermanager.WithEKSAInstallerFieldManager("flux"))
+
+	tt.newSpec.VersionsBundle.Eksa.Version = "v0.2.0"
+	tt.newSpec.VersionsBundle.Eksa.Components = v1alpha1.Manifest{
+		URI: "testdata/eksa_components.yaml",
+	}
+
+	tt.client.EXPECT().ApplyWithFieldManager(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}), "flux")
+	tt.client.EXPECT().ApplyWithFieldManager(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&unstructured.Unstructured{}), "flux")
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m0s", "Available", "eksa-controller-manager", "eksa-system")
+	_, err := tt.installer.Upgrade(tt.ctx, tt.log, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(Succeed())
+}
+
 func TestInstallerUpgradeInstallError(t *testing.T) {
 	tt := newInstallerTest(t)
 
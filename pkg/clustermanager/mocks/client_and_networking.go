@@ -103,6 +103,20 @@ func (mr *MockClusterClientMockRecorder) ApplyKubeSpecFromBytesWithNamespace(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesWithNamespace", reflect.TypeOf((*MockClusterClient)(nil).ApplyKubeSpecFromBytesWithNamespace), arg0, arg1, arg2, arg3)
 }
 
+// ApplyWithFieldManager mocks base method.
+func (m *MockClusterClient) ApplyWithFieldManager(arg0 context.Context, arg1 string, arg2 runtime.Object, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyWithFieldManager", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyWithFieldManager indicates an expected call of ApplyWithFieldManager.
+func (mr *MockClusterClientMockRecorder) ApplyWithFieldManager(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyWithFieldManager", reflect.TypeOf((*MockClusterClient)(nil).ApplyWithFieldManager), arg0, arg1, arg2, arg3)
+}
+
 // BackupManagement mocks base method.
 func (m *MockClusterClient) BackupManagement(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -350,6 +364,21 @@ func (mr *MockClusterClientMockRecorder) GetEksaCluster(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockClusterClient)(nil).GetEksaCluster), arg0, arg1, arg2)
 }
 
+// GetEksaClusterStatus mocks base method.
+func (m *MockClusterClient) GetEksaClusterStatus(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.ClusterStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaClusterStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.ClusterStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaClusterStatus indicates an expected call of GetEksaClusterStatus.
+func (mr *MockClusterClientMockRecorder) GetEksaClusterStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaClusterStatus", reflect.TypeOf((*MockClusterClient)(nil).GetEksaClusterStatus), arg0, arg1, arg2)
+}
+
 // GetEksaFluxConfig mocks base method.
 func (m *MockClusterClient) GetEksaFluxConfig(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha1.FluxConfig, error) {
 	m.ctrl.T.Helper()
@@ -515,6 +544,21 @@ func (mr *MockClusterClientMockRecorder) GetMachines(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockClusterClient)(nil).GetMachines), arg0, arg1, arg2)
 }
 
+// GetMachinesForMachineDeployment mocks base method.
+func (m *MockClusterClient) GetMachinesForMachineDeployment(arg0 context.Context, arg1 *types.Cluster, arg2 string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachinesForMachineDeployment", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachinesForMachineDeployment indicates an expected call of GetMachinesForMachineDeployment.
+func (mr *MockClusterClientMockRecorder) GetMachinesForMachineDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachinesForMachineDeployment", reflect.TypeOf((*MockClusterClient)(nil).GetMachinesForMachineDeployment), arg0, arg1, arg2)
+}
+
 // GetWorkloadKubeconfig mocks base method.
 func (m *MockClusterClient) GetWorkloadKubeconfig(arg0 context.Context, arg1 string, arg2 *types.Cluster) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -573,6 +617,20 @@ func (mr *MockClusterClientMockRecorder) ListObjects(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockClusterClient)(nil).ListObjects), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ListObjectsInAllNamespaces mocks base method.
+func (m *MockClusterClient) ListObjectsInAllNamespaces(arg0 context.Context, arg1, arg2 string, arg3 kubernetes.ObjectList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectsInAllNamespaces", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListObjectsInAllNamespaces indicates an expected call of ListObjectsInAllNamespaces.
+func (mr *MockClusterClientMockRecorder) ListObjectsInAllNamespaces(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectsInAllNamespaces", reflect.TypeOf((*MockClusterClient)(nil).ListObjectsInAllNamespaces), arg0, arg1, arg2, arg3)
+}
+
 // MoveManagement mocks base method.
 func (m *MockClusterClient) MoveManagement(arg0 context.Context, arg1, arg2 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -615,6 +673,20 @@ func (mr *MockClusterClientMockRecorder) SaveLog(arg0, arg1, arg2, arg3, arg4 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLog", reflect.TypeOf((*MockClusterClient)(nil).SaveLog), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ServerSideApplyKubeSpecFromBytes mocks base method.
+func (m *MockClusterClient) ServerSideApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerSideApplyKubeSpecFromBytes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServerSideApplyKubeSpecFromBytes indicates an expected call of ServerSideApplyKubeSpecFromBytes.
+func (mr *MockClusterClientMockRecorder) ServerSideApplyKubeSpecFromBytes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerSideApplyKubeSpecFromBytes", reflect.TypeOf((*MockClusterClient)(nil).ServerSideApplyKubeSpecFromBytes), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SetEksaControllerEnvVar mocks base method.
 func (m *MockClusterClient) SetEksaControllerEnvVar(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -685,18 +757,33 @@ func (mr *MockClusterClientMockRecorder) ValidateWorkerNodes(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateWorkerNodes", reflect.TypeOf((*MockClusterClient)(nil).ValidateWorkerNodes), arg0, arg1, arg2)
 }
 
-// WaitForClusterReady mocks base method.
-func (m *MockClusterClient) WaitForClusterReady(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+// Version mocks base method.
+func (m *MockClusterClient) Version(arg0 context.Context, arg1 *types.Cluster) (*executables.VersionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForClusterReady", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "Version", arg0, arg1)
+	ret0, _ := ret[0].(*executables.VersionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockClusterClientMockRecorder) Version(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockClusterClient)(nil).Version), arg0, arg1)
+}
+
+// WaitForClusterCondition mocks base method.
+func (m *MockClusterClient) WaitForClusterCondition(arg0 context.Context, arg1 *types.Cluster, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForClusterCondition", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WaitForClusterReady indicates an expected call of WaitForClusterReady.
-func (mr *MockClusterClientMockRecorder) WaitForClusterReady(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// WaitForClusterCondition indicates an expected call of WaitForClusterCondition.
+func (mr *MockClusterClientMockRecorder) WaitForClusterCondition(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForClusterReady", reflect.TypeOf((*MockClusterClient)(nil).WaitForClusterReady), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForClusterCondition", reflect.TypeOf((*MockClusterClient)(nil).WaitForClusterCondition), arg0, arg1, arg2, arg3, arg4)
 }
 
 // WaitForControlPlaneAvailable mocks base method.
@@ -872,6 +959,21 @@ func (m *MockAwsIamAuth) EXPECT() *MockAwsIamAuthMockRecorder {
 	return m.recorder
 }
 
+// CASecretExists mocks base method.
+func (m *MockAwsIamAuth) CASecretExists(arg0 context.Context, arg1 *types.Cluster, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CASecretExists", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CASecretExists indicates an expected call of CASecretExists.
+func (mr *MockAwsIamAuthMockRecorder) CASecretExists(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CASecretExists", reflect.TypeOf((*MockAwsIamAuth)(nil).CASecretExists), arg0, arg1, arg2)
+}
+
 // CreateAndInstallAWSIAMAuthCASecret mocks base method.
 func (m *MockAwsIamAuth) CreateAndInstallAWSIAMAuthCASecret(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1045,6 +1147,20 @@ func (mr *MockKubernetesClientMockRecorder) ApplyKubeSpecFromBytesWithNamespace(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesWithNamespace", reflect.TypeOf((*MockKubernetesClient)(nil).ApplyKubeSpecFromBytesWithNamespace), arg0, arg1, arg2, arg3)
 }
 
+// ApplyWithFieldManager mocks base method.
+func (m *MockKubernetesClient) ApplyWithFieldManager(arg0 context.Context, arg1 string, arg2 runtime.Object, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyWithFieldManager", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyWithFieldManager indicates an expected call of ApplyWithFieldManager.
+func (mr *MockKubernetesClientMockRecorder) ApplyWithFieldManager(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyWithFieldManager", reflect.TypeOf((*MockKubernetesClient)(nil).ApplyWithFieldManager), arg0, arg1, arg2, arg3)
+}
+
 // RemoveAnnotationInNamespace mocks base method.
 func (m *MockKubernetesClient) RemoveAnnotationInNamespace(arg0 context.Context, arg1, arg2, arg3 string, arg4 *types.Cluster, arg5 string) error {
 	m.ctrl.T.Helper()
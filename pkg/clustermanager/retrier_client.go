@@ -2,6 +2,7 @@ package clustermanager
 
 import (
 	"context"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -13,20 +14,92 @@ import (
 // RetrierClient wraps around a ClusterClient, offering retry functionality for some operations.
 type RetrierClient struct {
 	*client
-	retrier *retrier.Retrier
+	retrier     *retrier.Retrier
+	metricsHook func(method string, attempts int)
+
+	statsLock sync.Mutex
+	stats     map[string]RetryStats
+}
+
+// RetryStats holds the attempts and retries accumulated by a RetrierClient operation.
+type RetryStats struct {
+	// Attempts is the total number of times the operation was tried.
+	Attempts int
+	// Retries is the number of attempts made after a failed one.
+	Retries int
+}
+
+// RetrierClientOpt allows to customize a RetrierClient on construction.
+type RetrierClientOpt func(*RetrierClient)
+
+// WithRetryMetricsHook sets a hook called after every retried operation completes,
+// successfully or not, with the operation's method name and the number of attempts it took.
+func WithRetryMetricsHook(hook func(method string, attempts int)) RetrierClientOpt {
+	return func(c *RetrierClient) {
+		c.metricsHook = hook
+	}
 }
 
 // NewRetrierClient constructs a new RetrierClient.
-func NewRetrierClient(client ClusterClient, retrier *retrier.Retrier) *RetrierClient {
-	return &RetrierClient{
+func NewRetrierClient(client ClusterClient, retrier *retrier.Retrier, opts ...RetrierClientOpt) *RetrierClient {
+	c := &RetrierClient{
 		client:  NewClient(client),
 		retrier: retrier,
+		stats:   map[string]RetryStats{},
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// Stats returns the attempts and retries per method name accumulated over the client's lifetime.
+// Only methods that have been called are included.
+func (c *RetrierClient) Stats() map[string]RetryStats {
+	c.statsLock.Lock()
+	defer c.statsLock.Unlock()
+
+	stats := make(map[string]RetryStats, len(c.stats))
+	for method, s := range c.stats {
+		stats[method] = s
+	}
+
+	return stats
+}
+
+// retry runs fn with the client's retrier, recording the attempts under method.
+func (c *RetrierClient) retry(ctx context.Context, method string, fn func() error) error {
+	attempts := 0
+	err := c.retrier.RetryWithContext(ctx, func() error {
+		attempts++
+		return fn()
+	})
+
+	c.recordAttempts(method, attempts)
+
+	return err
+}
+
+func (c *RetrierClient) recordAttempts(method string, attempts int) {
+	c.statsLock.Lock()
+	s := c.stats[method]
+	s.Attempts += attempts
+	if attempts > 1 {
+		s.Retries += attempts - 1
+	}
+	c.stats[method] = s
+	c.statsLock.Unlock()
+
+	if c.metricsHook != nil {
+		c.metricsHook(method, attempts)
 	}
 }
 
 // ApplyKubeSpecFromBytes creates/updates the objects defined in a yaml manifest against the api server following a client side apply mechanism.
 func (c *RetrierClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "ApplyKubeSpecFromBytes",
 		func() error {
 			return c.ClusterClient.ApplyKubeSpecFromBytes(ctx, cluster, data)
 		},
@@ -35,17 +108,27 @@ func (c *RetrierClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *typ
 
 // Apply creates/updates an object against the api server following a client side apply mechanism.
 func (c *RetrierClient) Apply(ctx context.Context, kubeconfigPath string, obj runtime.Object) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "Apply",
 		func() error {
 			return c.ClusterClient.Apply(ctx, kubeconfigPath, obj)
 		},
 	)
 }
 
+// ApplyWithFieldManager creates/updates an object against the api server following a server side apply mechanism,
+// recording fieldManager as the manager of the applied fields.
+func (c *RetrierClient) ApplyWithFieldManager(ctx context.Context, kubeconfigPath string, obj runtime.Object, fieldManager string) error {
+	return c.retry(ctx, "ApplyWithFieldManager",
+		func() error {
+			return c.ClusterClient.ApplyWithFieldManager(ctx, kubeconfigPath, obj, fieldManager)
+		},
+	)
+}
+
 // ApplyKubeSpecFromBytesForce creates/updates the objects defined in a yaml manifest against the api server following a client side apply mechanism.
 // It forces the operation, so if api validation failed, it will delete and re-create the object.
 func (c *RetrierClient) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "ApplyKubeSpecFromBytesForce",
 		func() error {
 			return c.ClusterClient.ApplyKubeSpecFromBytesForce(ctx, cluster, data)
 		},
@@ -55,7 +138,7 @@ func (c *RetrierClient) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster
 // ApplyKubeSpecFromBytesWithNamespace creates/updates the objects defined in a yaml manifest against the api server following a client side apply mechanism.
 // It applies all objects in the given namespace.
 func (c *RetrierClient) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "ApplyKubeSpecFromBytesWithNamespace",
 		func() error {
 			return c.ClusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, data, namespace)
 		},
@@ -64,7 +147,7 @@ func (c *RetrierClient) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context,
 
 // UpdateAnnotationInNamespace adds/updates an annotation for the given kubernetes resource.
 func (c *RetrierClient) UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "UpdateAnnotationInNamespace",
 		func() error {
 			return c.ClusterClient.UpdateAnnotationInNamespace(ctx, resourceType, objectName, annotations, cluster, namespace)
 		},
@@ -73,7 +156,7 @@ func (c *RetrierClient) UpdateAnnotationInNamespace(ctx context.Context, resourc
 
 // RemoveAnnotationInNamespace deletes an annotation for the given kubernetes resource if present.
 func (c *RetrierClient) RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "RemoveAnnotationInNamespace",
 		func() error {
 			return c.ClusterClient.RemoveAnnotationInNamespace(ctx, resourceType, objectName, key, cluster, namespace)
 		},
@@ -82,16 +165,25 @@ func (c *RetrierClient) RemoveAnnotationInNamespace(ctx context.Context, resourc
 
 // ListObjects reads all Objects of a particular resource type in a namespace.
 func (c *RetrierClient) ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "ListObjects",
 		func() error {
 			return c.ClusterClient.ListObjects(ctx, resourceType, namespace, kubeconfig, list)
 		},
 	)
 }
 
+// ListObjectsInAllNamespaces reads all Objects of a particular resource type across all namespaces.
+func (c *RetrierClient) ListObjectsInAllNamespaces(ctx context.Context, resourceType, kubeconfig string, list kubernetes.ObjectList) error {
+	return c.retry(ctx, "ListObjectsInAllNamespaces",
+		func() error {
+			return c.ClusterClient.ListObjectsInAllNamespaces(ctx, resourceType, kubeconfig, list)
+		},
+	)
+}
+
 // DeleteGitOpsConfig deletes a GitOpsConfigObject from the cluster.
 func (c *RetrierClient) DeleteGitOpsConfig(ctx context.Context, cluster *types.Cluster, name string, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "DeleteGitOpsConfig",
 		func() error {
 			return c.ClusterClient.DeleteGitOpsConfig(ctx, cluster, name, namespace)
 		},
@@ -100,7 +192,7 @@ func (c *RetrierClient) DeleteGitOpsConfig(ctx context.Context, cluster *types.C
 
 // DeleteEKSACluster deletes an EKSA Cluster object from the cluster.
 func (c *RetrierClient) DeleteEKSACluster(ctx context.Context, cluster *types.Cluster, name string, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "DeleteEKSACluster",
 		func() error {
 			return c.ClusterClient.DeleteEKSACluster(ctx, cluster, name, namespace)
 		},
@@ -109,7 +201,7 @@ func (c *RetrierClient) DeleteEKSACluster(ctx context.Context, cluster *types.Cl
 
 // DeleteAWSIamConfig deletes an AWSIamConfig object from the cluster.
 func (c *RetrierClient) DeleteAWSIamConfig(ctx context.Context, cluster *types.Cluster, name string, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "DeleteAWSIamConfig",
 		func() error {
 			return c.ClusterClient.DeleteAWSIamConfig(ctx, cluster, name, namespace)
 		},
@@ -118,7 +210,7 @@ func (c *RetrierClient) DeleteAWSIamConfig(ctx context.Context, cluster *types.C
 
 // DeleteOIDCConfig deletes a OIDCConfig object from the cluster.
 func (c *RetrierClient) DeleteOIDCConfig(ctx context.Context, cluster *types.Cluster, name string, namespace string) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "DeleteOIDCConfig",
 		func() error {
 			return c.ClusterClient.DeleteOIDCConfig(ctx, cluster, name, namespace)
 		},
@@ -127,9 +219,27 @@ func (c *RetrierClient) DeleteOIDCConfig(ctx context.Context, cluster *types.Clu
 
 // DeleteCluster deletes a CAPI Cluster from the cluster.
 func (c *RetrierClient) DeleteCluster(ctx context.Context, cluster, clusterToDelete *types.Cluster) error {
-	return c.retrier.Retry(
+	return c.retry(ctx, "DeleteCluster",
 		func() error {
 			return c.ClusterClient.DeleteCluster(ctx, cluster, clusterToDelete)
 		},
 	)
 }
+
+// CreateNamespaceIfNotPresent creates the namespace on the cluster if it doesn't already exist.
+func (c *RetrierClient) CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error {
+	return c.retry(ctx, "CreateNamespaceIfNotPresent",
+		func() error {
+			return c.ClusterClient.CreateNamespaceIfNotPresent(ctx, kubeconfig, namespace)
+		},
+	)
+}
+
+// ServerSideApplyKubeSpecFromBytes creates/updates the objects defined in a yaml manifest with server-side apply.
+func (c *RetrierClient) ServerSideApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte, namespace, fieldManager string) error {
+	return c.retry(ctx, "ServerSideApplyKubeSpecFromBytes",
+		func() error {
+			return c.ClusterClient.ServerSideApplyKubeSpecFromBytes(ctx, cluster, data, namespace, fieldManager)
+		},
+	)
+}
@@ -34,7 +34,7 @@ func NewCreateClusterShim(
 
 // CreateAsync satisfies the workload.Cluster interface.
 func (s CreateClusterShim) CreateAsync(ctx context.Context, management *types.Cluster) error {
-	if err := s.manager.applyProviderManifests(ctx, s.spec, management, s.provider); err != nil {
+	if _, err := s.manager.applyProviderManifests(ctx, s.spec, management, s.provider); err != nil {
 		return fmt.Errorf("installing cluster creation manifests: %v", err)
 	}
 
@@ -0,0 +1,74 @@
+package clustermanager
+
+import (
+	"context"
+	"sync"
+
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// currentSpecCache caches the Bundles and EKS-D Releases fetched to build the current cluster spec,
+// so building it more than once during the same operation doesn't hit the API server again.
+// Entries are keyed by cluster name and object reference.
+type currentSpecCache struct {
+	mu           sync.Mutex
+	bundles      map[string]*releasev1alpha1.Bundles
+	eksdReleases map[string]*eksdv1alpha1.Release
+}
+
+func newCurrentSpecCache() *currentSpecCache {
+	return &currentSpecCache{
+		bundles:      map[string]*releasev1alpha1.Bundles{},
+		eksdReleases: map[string]*eksdv1alpha1.Release{},
+	}
+}
+
+func currentSpecCacheKey(clusterName, name, namespace string) string {
+	return clusterName + "/" + namespace + "/" + name
+}
+
+func (c *currentSpecCache) getBundles(ctx context.Context, clusterName, name, namespace string, fetch func(ctx context.Context) (*releasev1alpha1.Bundles, error)) (*releasev1alpha1.Bundles, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := currentSpecCacheKey(clusterName, name, namespace)
+	if bundles, ok := c.bundles[key]; ok {
+		return bundles.DeepCopy(), nil
+	}
+
+	bundles, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.bundles[key] = bundles.DeepCopy()
+
+	return bundles, nil
+}
+
+func (c *currentSpecCache) getEksdRelease(ctx context.Context, clusterName, name, namespace string, fetch func(ctx context.Context) (*eksdv1alpha1.Release, error)) (*eksdv1alpha1.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := currentSpecCacheKey(clusterName, name, namespace)
+	if release, ok := c.eksdReleases[key]; ok {
+		return release.DeepCopy(), nil
+	}
+
+	release, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.eksdReleases[key] = release.DeepCopy()
+
+	return release, nil
+}
+
+func (c *currentSpecCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bundles = map[string]*releasev1alpha1.Bundles{}
+	c.eksdReleases = map[string]*eksdv1alpha1.Release{}
+}
@@ -93,7 +93,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 		CACertContent:      "-----BEGIN CERTIFICATE-----\nabc\nefg\n-----END CERTIFICATE-----\n",
 		InsecureSkipVerify: true,
 	}
-	writer, _ := filewriter.NewWriter(clusterName)
+	writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 	clusterSpec := &cluster.Spec{
 		Config: &cluster.Config{
 			Cluster: &v1alpha1.Cluster{
@@ -262,7 +262,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 func TestEnableCuratedPackagesSuccess(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -305,7 +305,7 @@ func TestEnableCuratedPackagesSucceedInWorkloadCluster(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -368,7 +368,7 @@ func TestEnableCuratedPackagesWithProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		httpProxy := fmt.Sprintf("proxy.HTTP_PROXY=%s", tt.httpProxy)
 		httpsProxy := fmt.Sprintf("proxy.HTTPS_PROXY=%s", tt.httpsProxy)
 		noProxy := fmt.Sprintf("proxy.NO_PROXY=%s", strings.Join(tt.noProxy, "\\,"))
@@ -422,7 +422,7 @@ func TestEnableCuratedPackagesWithEmptyProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -461,7 +461,7 @@ func TestEnableCuratedPackagesWithEmptyProxy(t *testing.T) {
 func TestEnableCuratedPackagesFail(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -491,7 +491,7 @@ func TestEnableCuratedPackagesFail(t *testing.T) {
 func TestEnableCuratedPackagesFailNoActiveBundle(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -521,7 +521,7 @@ func TestEnableCuratedPackagesFailNoActiveBundle(t *testing.T) {
 func TestEnableCuratedPackagesSuccessWhenCronJobFails(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -589,7 +589,7 @@ func TestEnableCuratedPackagesActiveBundleCustomTimeout(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -628,7 +628,7 @@ func TestEnableCuratedPackagesActiveBundleCustomTimeout(t *testing.T) {
 func TestEnableCuratedPackagesActiveBundleWaitLoops(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -688,7 +688,7 @@ func TestEnableCuratedPackagesActiveBundleTimesOut(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -734,7 +734,7 @@ func TestEnableCuratedPackagesActiveBundleNamespaceTimesOut(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -786,7 +786,7 @@ func TestCreateHelmOverrideValuesYaml(t *testing.T) {
 		}
 		filePath, content, err := tt.command.CreateHelmOverrideValuesYaml()
 		tt.Expect(err).To(BeNil())
-		tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+		tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, "values.yaml")))
 		test.AssertContentToFile(t, string(content), tt.wantValueFile)
 	}
 }
@@ -801,7 +801,7 @@ func TestCreateHelmOverrideValuesYamlFail(t *testing.T) {
 			tt.Expect(filePath).To(Equal(""))
 		} else {
 			tt.Expect(err).To(BeNil())
-			tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+			tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, "values.yaml")))
 			test.AssertContentToFile(t, string(content), tt.wantValueFile)
 		}
 	}
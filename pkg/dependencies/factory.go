@@ -132,10 +132,17 @@ type Factory struct {
 	proxyConfiguration       map[string]string
 	writerFolder             string
 	diagnosticCollectorImage string
-	buildSteps               []buildStep
-	dependencies             Dependencies
+	// tinkerbellOSImageURLCheck enables checking the Tinkerbell OS image URLs are reachable during create.
+	tinkerbellOSImageURLCheck bool
+	// tinkerbellProviderOpts are passed to the Tinkerbell provider when it is built.
+	tinkerbellProviderOpts []tinkerbell.ProviderOpt
+	buildSteps             []buildStep
+	dependencies           Dependencies
 }
 
+// tinkerbellOSImageURLCheckTimeout bounds each request checking a Tinkerbell OS image URL is reachable.
+const tinkerbellOSImageURLCheckTimeout = 30 * time.Second
+
 type executablesConfig struct {
 	builder            *executables.ExecutablesBuilder
 	image              string
@@ -435,11 +442,16 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				time.Now,
 				force,
 				skipIpCheck,
+				f.tinkerbellProviderOpts...,
 			)
 			if err != nil {
 				return err
 			}
 
+			if f.tinkerbellOSImageURLCheck {
+				provider.EnableOSImageURLCheck(&http.Client{Timeout: tinkerbellOSImageURLCheckTimeout})
+			}
+
 			f.dependencies.Provider = provider
 
 		case v1alpha1.DockerDatacenterKind:
@@ -901,7 +913,7 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, timeoutOpt
 			f.dependencies.DignosticCollectorFactory,
 			f.dependencies.AwsIamAuth,
 			installer,
-			clusterManagerOpts(timeoutOpts)...,
+			append(clusterManagerOpts(timeoutOpts), clustermanager.WithClusterConnectionValidation())...,
 		)
 		return nil
 	})
@@ -1150,6 +1162,36 @@ func (f *Factory) WithAnalyzerFactory() *Factory {
 	return f
 }
 
+// WithTinkerbellOSImageURLCheck makes the Tinkerbell provider check the OS image URLs of the cluster
+// machines are reachable when validating a create.
+func (f *Factory) WithTinkerbellOSImageURLCheck(enabled bool) *Factory {
+	f.tinkerbellOSImageURLCheck = enabled
+	return f
+}
+
+// WithTinkerbellRufioContactableTimeout sets how long the Tinkerbell provider waits for the baseboard
+// management controllers of the hardware to be contactable.
+func (f *Factory) WithTinkerbellRufioContactableTimeout(timeout time.Duration) *Factory {
+	f.tinkerbellProviderOpts = append(f.tinkerbellProviderOpts, tinkerbell.WithRufioContactableTimeout(timeout))
+	return f
+}
+
+// WithTinkerbellDeterministicTemplateNames makes the Tinkerbell provider suffix the machine and kubeadm config
+// template names with generation instead of a timestamp. A generation of 0 keeps the timestamp suffix.
+func (f *Factory) WithTinkerbellDeterministicTemplateNames(generation int) *Factory {
+	if generation > 0 {
+		f.tinkerbellProviderOpts = append(f.tinkerbellProviderOpts, tinkerbell.WithDeterministicTemplateNames(generation))
+	}
+	return f
+}
+
+// WithTinkerbellKeepLocalBootsOnFailure sets whether the Tinkerbell provider keeps the local boots container
+// running when a create fails, so its logs can be inspected.
+func (f *Factory) WithTinkerbellKeepLocalBootsOnFailure(keep bool) *Factory {
+	f.tinkerbellProviderOpts = append(f.tinkerbellProviderOpts, tinkerbell.WithKeepLocalBootsOnFailure(keep))
+	return f
+}
+
 func (f *Factory) WithDiagnosticCollectorImage(diagnosticCollectorImage string) *Factory {
 	f.diagnosticCollectorImage = diagnosticCollectorImage
 	return f
@@ -90,6 +90,54 @@ func TestFactoryBuildWithProviderTinkerbell(t *testing.T) {
 	tt.Expect(deps.DockerClient).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithProviderTinkerbellOSImageURLCheck(t *testing.T) {
+	tt := newTest(t, tinkerbell)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithTinkerbellOSImageURLCheck(true).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Provider).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithProviderTinkerbellRufioContactableTimeout(t *testing.T) {
+	tt := newTest(t, tinkerbell)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithTinkerbellRufioContactableTimeout(15*time.Minute).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Provider).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithProviderTinkerbellDeterministicTemplateNames(t *testing.T) {
+	tt := newTest(t, tinkerbell)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithTinkerbellDeterministicTemplateNames(3).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Provider).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithProviderTinkerbellKeepLocalBootsOnFailure(t *testing.T) {
+	tt := newTest(t, tinkerbell)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithTinkerbellKeepLocalBootsOnFailure(false).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Provider).NotTo(BeNil())
+}
+
 func TestFactoryBuildWithProviderSnow(t *testing.T) {
 	tt := newTest(t, snow)
 	t.Setenv("EKSA_AWS_CREDENTIALS_FILE", "./testdata/snow/valid_credentials")
@@ -176,9 +176,18 @@ func clusterctlMoveRetryPolicy(totalRetries int, err error) (retry bool, wait ti
 	return false, 0
 }
 
+// ManagementBackupDir returns the directory BackupManagement writes the CAPI resources of cluster to.
+// Relative paths are placed under the cluster folder, absolute paths are used as is.
+func ManagementBackupDir(cluster *types.Cluster, managementStatePath string) string {
+	if filepath.IsAbs(managementStatePath) {
+		return managementStatePath
+	}
+	return filepath.Join(".", cluster.Name, managementStatePath)
+}
+
 // BackupManagement save CAPI resources of a workload cluster before moving it to the bootstrap cluster during upgrade.
 func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
-	filePath := filepath.Join(".", cluster.Name, managementStatePath)
+	filePath := ManagementBackupDir(cluster, managementStatePath)
 	err := os.MkdirAll(filePath, os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("could not create backup file for CAPI objects: %v", err)
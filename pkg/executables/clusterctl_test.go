@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -48,7 +49,8 @@ func newClusterctlTest(t *testing.T) *clusterctlTest {
 		WithT: NewWithT(t),
 		ctx:   context.Background(),
 		cluster: &types.Cluster{
-			Name:           "cluster-name",
+			// Upgrade writes the overrides layer under the cluster name folder.
+			Name:           filepath.Join(t.TempDir(), "cluster-name"),
 			KubeconfigFile: "config/c.kubeconfig",
 		},
 		e:              e,
@@ -255,6 +257,24 @@ func TestClusterctlBackupManagement(t *testing.T) {
 	}
 }
 
+func TestClusterctlBackupManagementAbsoluteDir(t *testing.T) {
+	tt := newClusterctlTest(t)
+	dir := filepath.Join(t.TempDir(), "backup")
+	cluster := &types.Cluster{
+		Name:           "cluster",
+		KubeconfigFile: "cluster.kubeconfig",
+	}
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--to-directory", dir, "--kubeconfig", "cluster.kubeconfig", "--namespace", constants.EksaSystemNamespace)
+
+	if err := tt.clusterctl.BackupManagement(tt.ctx, cluster, dir); err != nil {
+		t.Fatalf("Clusterctl.BackupManagement() error = %v, want nil", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("backup dir %s wasn't created: %v", dir, err)
+	}
+}
+
 func TestClusterctlBackupManagementFailed(t *testing.T) {
 	managementClusterState := fmt.Sprintf("cluster-state-backup-%s", time.Now().Format("2006-01-02T15_04_05"))
 	tt := newClusterctlTest(t)
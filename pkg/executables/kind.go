@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -155,6 +156,41 @@ func (k *Kind) WithExtraPortMappings(ports []int) bootstrapper.BootstrapClusterC
 	}
 }
 
+// WithImage overrides the node image used for the kind cluster, which defaults to the
+// EKS-D kind node image from the bundle. The image must include a tag or digest.
+func (k *Kind) WithImage(image string) bootstrapper.BootstrapClusterClientOption {
+	return func() error {
+		if k.execConfig == nil {
+			return errors.New("kind exec config is not ready")
+		}
+
+		if err := validateKindImage(image); err != nil {
+			return err
+		}
+
+		k.execConfig.KindImage = image
+
+		return nil
+	}
+}
+
+func validateKindImage(image string) error {
+	if image == "" {
+		return errors.New("bootstrap cluster image can't be empty")
+	}
+
+	if strings.ContainsAny(image, " \t\n") {
+		return fmt.Errorf("bootstrap cluster image %s is invalid, it can't contain whitespaces", image)
+	}
+
+	name := image[strings.LastIndex(image, "/")+1:]
+	if !strings.Contains(name, ":") && !strings.Contains(name, "@") {
+		return fmt.Errorf("bootstrap cluster image %s is invalid, it must include a tag or digest", image)
+	}
+
+	return nil
+}
+
 func (k *Kind) WithEnv(env map[string]string) bootstrapper.BootstrapClusterClientOption {
 	return func() error {
 		if k.execConfig == nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -46,6 +47,7 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 		env            map[string]string
 		options        []testKindOption
 		wantKindConfig string
+		wantImage      string
 	}{
 		{
 			name:           "No options",
@@ -101,6 +103,18 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 			env:            map[string]string{"ENV_VAR1": "VALUE1", "ENV_VAR2": "VALUE2"},
 			wantKindConfig: "testdata/kind_config_docker_mount_networking.yaml",
 		},
+		{
+			name:           "With image option",
+			wantKubeconfig: kubeConfigFile,
+			options: []testKindOption{
+				func(k *executables.Kind) bootstrapper.BootstrapClusterClientOption {
+					return k.WithImage("registry.local:5000/kind/node:v1.20.2-custom")
+				},
+			},
+			env:            map[string]string{},
+			wantKindConfig: "testdata/kind_config.yaml",
+			wantImage:      "registry.local:5000/kind/node:v1.20.2-custom",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,6 +127,9 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 			)
 			spec = clusterSpec
 			image = kindImage
+			if tt.wantImage != "" {
+				image = tt.wantImage
+			}
 
 			executable.EXPECT().ExecuteWithEnv(
 				ctx,
@@ -141,6 +158,8 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 }
 
 func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
+	// The registry CA cert is written under the cluster name folder in the working directory.
+	wd := chdirTempDir(t)
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
@@ -249,7 +268,7 @@ func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
 			).Return(bytes.Buffer{}, nil).Times(1).Do(
 				func(ctx context.Context, envs map[string]string, args ...string) (stdout bytes.Buffer, err error) {
 					gotKindConfig := args[9]
-					test.AssertFilesEquals(t, gotKindConfig, tt.wantKindConfig)
+					test.AssertFilesEquals(t, gotKindConfig, filepath.Join(wd, tt.wantKindConfig))
 
 					return bytes.Buffer{}, nil
 				},
@@ -291,6 +310,49 @@ func TestKindCreateBootstrapClusterExecutableError(t *testing.T) {
 	}
 }
 
+func TestKindCreateBootstrapClusterInvalidImage(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "clusterName"
+		s.VersionsBundle = versionBundle
+	})
+
+	tests := []struct {
+		name    string
+		image   string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			image:   "",
+			wantErr: "bootstrap cluster image can't be empty",
+		},
+		{
+			name:    "no tag",
+			image:   "registry.local:5000/kind/node",
+			wantErr: "bootstrap cluster image registry.local:5000/kind/node is invalid, it must include a tag or digest",
+		},
+		{
+			name:    "whitespace",
+			image:   "kind/node: v1.20.2",
+			wantErr: "bootstrap cluster image kind/node: v1.20.2 is invalid, it can't contain whitespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, writer := test.NewWriter(t)
+			mockCtrl := gomock.NewController(t)
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			k := executables.NewKind(executable, writer)
+
+			_, err := k.CreateBootstrapCluster(ctx, clusterSpec, k.WithImage(tt.image))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Kind.CreateBootstrapCluster() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestKindCreateBootstrapClusterExecutableWithRegistryMirrorError(t *testing.T) {
 	registryMirror := "registry-mirror.test"
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
@@ -427,3 +489,24 @@ func TestKindGetKubeconfig(t *testing.T) {
 		t.Fatalf("Kind.GetKubeconfig() error = %v, wantErr nil", err)
 	}
 }
+
+// chdirTempDir changes the working directory to a temporary directory for the duration of the test and
+// returns the original working directory.
+func chdirTempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getting working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("changing working directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("restoring working directory: %v", err)
+		}
+	})
+
+	return wd
+}
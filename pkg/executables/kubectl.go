@@ -285,6 +285,28 @@ func (k *Kubectl) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, clust
 	return nil
 }
 
+// ServerSideApplyKubeSpecFromBytes creates/updates the objects defined in a yaml manifest using server-side apply,
+// taking ownership of any conflicting fields for fieldManager. If namespace is empty, the manifest namespaces are used.
+func (k *Kubectl) ServerSideApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte, namespace, fieldManager string) error {
+	if len(data) == 0 {
+		logger.V(6).Info("Skipping applying empty kube spec from bytes")
+		return nil
+	}
+
+	params := []string{"apply", "-f", "-", "--server-side", "--force-conflicts", "--field-manager", fieldManager}
+	if namespace != "" {
+		params = append(params, "--namespace", namespace)
+	}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	_, err := k.ExecuteWithStdin(ctx, data, params...)
+	if err != nil {
+		return fmt.Errorf("executing server-side apply: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
 	params := []string{"apply", "-f", "-", "--force"}
 	if cluster.KubeconfigFile != "" {
@@ -318,7 +340,12 @@ func (k *Kubectl) DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cl
 }
 
 func (k *Kubectl) WaitForClusterReady(ctx context.Context, cluster *types.Cluster, timeout string, clusterName string) error {
-	return k.Wait(ctx, cluster.KubeconfigFile, timeout, "Ready", fmt.Sprintf("%s/%s", capiClustersResourceType, clusterName), constants.EksaSystemNamespace)
+	return k.WaitForClusterCondition(ctx, cluster, timeout, "Ready", clusterName)
+}
+
+// WaitForClusterCondition blocks until the CAPI cluster has the given condition.
+func (k *Kubectl) WaitForClusterCondition(ctx context.Context, cluster *types.Cluster, timeout, condition, clusterName string) error {
+	return k.Wait(ctx, cluster.KubeconfigFile, timeout, condition, fmt.Sprintf("%s/%s", capiClustersResourceType, clusterName), constants.EksaSystemNamespace)
 }
 
 func (k *Kubectl) WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error {
@@ -1048,6 +1075,27 @@ func (k *Kubectl) GetMachines(ctx context.Context, cluster *types.Cluster, clust
 	return response.Items, nil
 }
 
+// GetMachinesForMachineDeployment returns the machines owned by the given machine deployment.
+func (k *Kubectl) GetMachinesForMachineDeployment(ctx context.Context, cluster *types.Cluster, machineDeploymentName string) ([]types.Machine, error) {
+	params := []string{
+		"get", "machines.cluster.x-k8s.io", "-o", "json", "--kubeconfig", cluster.KubeconfigFile,
+		"--selector=cluster.x-k8s.io/deployment-name=" + machineDeploymentName,
+		"--namespace", constants.EksaSystemNamespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting machines for deployment %s: %v", machineDeploymentName, err)
+	}
+
+	response := &machinesResponse{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("parsing get machines response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 type machineSetResponse struct {
 	Items []clusterv1.MachineSet `json:"items,omitempty"`
 }
@@ -1464,7 +1512,7 @@ func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, cl
 		if err != nil {
 			return nil, fmt.Errorf("getting eksa cluster: %v", err)
 		}
-		return nil, fmt.Errorf("cluster %s not found of custom resource type %s", clusterName, eksaClusterResourceType)
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "clusters"}, clusterName)
 	}
 
 	response := &v1alpha1.Cluster{}
@@ -1476,6 +1524,16 @@ func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, cl
 	return response, nil
 }
 
+// GetEksaClusterStatus returns the status of the EKS-A Cluster clusterName.
+func (k *Kubectl) GetEksaClusterStatus(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.ClusterStatus, error) {
+	eksaCluster, err := k.GetEksaCluster(ctx, cluster, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eksaCluster.Status, nil
+}
+
 func (k *Kubectl) SearchVsphereMachineConfig(ctx context.Context, name string, kubeconfigFile string, namespace string) ([]*v1alpha1.VSphereMachineConfig, error) {
 	params := []string{
 		"get", eksaVSphereMachineResourceType, "-o", "json", "--kubeconfig",
@@ -1996,11 +2054,17 @@ func (k *Kubectl) ListObjects(ctx context.Context, resourceType, namespace, kube
 	return k.get(ctx, resourceType, kubeconfig, list, withGetNamespace(namespace))
 }
 
+// ListObjectsInAllNamespaces reads all Objects of a particular resource type across all namespaces.
+func (k *Kubectl) ListObjectsInAllNamespaces(ctx context.Context, resourceType, kubeconfig string, list kubernetes.ObjectList) error {
+	return k.get(ctx, resourceType, kubeconfig, list, withGetAllNamespaces())
+}
+
 type (
 	getOption  func(*getOptions)
 	getOptions struct {
-		name      string
-		namespace string
+		name          string
+		namespace     string
+		allNamespaces bool
 	}
 )
 
@@ -2016,6 +2080,12 @@ func withGetNamespace(namespace string) getOption {
 	}
 }
 
+func withGetAllNamespaces() getOption {
+	return func(o *getOptions) {
+		o.allNamespaces = true
+	}
+}
+
 func (k *Kubectl) get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...getOption) error {
 	o := &getOptions{}
 	for _, opt := range opts {
@@ -2023,7 +2093,9 @@ func (k *Kubectl) get(ctx context.Context, resourceType, kubeconfig string, obj
 	}
 
 	params := []string{"get", "--ignore-not-found", "-o", "json", "--kubeconfig", kubeconfig, resourceType}
-	if o.namespace != "" {
+	if o.allNamespaces {
+		params = append(params, "--all-namespaces")
+	} else if o.namespace != "" {
 		params = append(params, "--namespace", o.namespace)
 	}
 	if o.name != "" {
@@ -2062,6 +2134,19 @@ func (k *Kubectl) Apply(ctx context.Context, kubeconfig string, obj runtime.Obje
 	return nil
 }
 
+// ApplyWithFieldManager creates/updates an object following a server side apply mechanism, recording
+// fieldManager as the manager of the applied fields.
+func (k *Kubectl) ApplyWithFieldManager(ctx context.Context, kubeconfig string, obj runtime.Object, fieldManager string) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshalling object: %v", err)
+	}
+	if _, err := k.ExecuteWithStdin(ctx, b, "apply", "-f", "-", "--kubeconfig", kubeconfig, "--server-side", "--field-manager", fieldManager); err != nil {
+		return fmt.Errorf("applying object with kubectl: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error) {
 	obj := &eksdv1alpha1.Release{}
 	if err := k.GetObject(ctx, eksdReleaseType, name, namespace, kubeconfigFile, obj); err != nil {
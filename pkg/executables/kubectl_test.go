@@ -278,6 +278,39 @@ func TestKubectlApplyKubeSpecFromBytesWithNamespaceError(t *testing.T) {
 	}
 }
 
+func TestKubectlServerSideApplyKubeSpecFromBytesSuccess(t *testing.T) {
+	data := []byte("someData")
+	namespace := "eksa-system"
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-f", "-", "--server-side", "--force-conflicts", "--field-manager", "eks-a-cli", "--namespace", namespace, "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().ExecuteWithStdin(ctx, data, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, nil)
+	if err := k.ServerSideApplyKubeSpecFromBytes(ctx, cluster, data, namespace, "eks-a-cli"); err != nil {
+		t.Errorf("Kubectl.ServerSideApplyKubeSpecFromBytes() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlServerSideApplyKubeSpecFromBytesSuccessWithEmptyInput(t *testing.T) {
+	var data []byte
+
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().ExecuteWithStdin(ctx, data, gomock.Any()).Times(0)
+	if err := k.ServerSideApplyKubeSpecFromBytes(ctx, cluster, data, "", "eks-a-cli"); err != nil {
+		t.Errorf("Kubectl.ServerSideApplyKubeSpecFromBytes() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlServerSideApplyKubeSpecFromBytesError(t *testing.T) {
+	data := []byte("someData")
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-f", "-", "--server-side", "--force-conflicts", "--field-manager", "eks-a-cli", "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().ExecuteWithStdin(ctx, data, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, errors.New("error from execute"))
+	if err := k.ServerSideApplyKubeSpecFromBytes(ctx, cluster, data, "", "eks-a-cli"); err == nil {
+		t.Errorf("Kubectl.ServerSideApplyKubeSpecFromBytes() error = nil, want not nil")
+	}
+}
+
 func TestKubectlCreateNamespaceSuccess(t *testing.T) {
 	var kubeconfig, namespace string
 
@@ -1002,6 +1035,34 @@ func TestKubectlGetMachines(t *testing.T) {
 	}
 }
 
+func TestKubectlGetMachinesForMachineDeployment(t *testing.T) {
+	g := NewWithT(t)
+	k, ctx, cluster, e := newKubectl(t)
+	fileContent := test.ReadFile(t, "testdata/kubectl_machines_with_node_ref.json")
+	e.EXPECT().Execute(ctx, []string{
+		"get", "machines.cluster.x-k8s.io", "-o", "json", "--kubeconfig", cluster.KubeconfigFile,
+		"--selector=cluster.x-k8s.io/deployment-name=eksa-test-capd-md-0",
+		"--namespace", constants.EksaSystemNamespace,
+	}).Return(*bytes.NewBufferString(fileContent), nil)
+
+	gotMachines, err := k.GetMachinesForMachineDeployment(ctx, cluster, "eksa-test-capd-md-0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotMachines).NotTo(BeEmpty())
+}
+
+func TestKubectlGetMachinesForMachineDeploymentError(t *testing.T) {
+	g := NewWithT(t)
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, []string{
+		"get", "machines.cluster.x-k8s.io", "-o", "json", "--kubeconfig", cluster.KubeconfigFile,
+		"--selector=cluster.x-k8s.io/deployment-name=eksa-test-capd-md-0",
+		"--namespace", constants.EksaSystemNamespace,
+	}).Return(bytes.Buffer{}, errors.New("error in kubectl"))
+
+	_, err := k.GetMachinesForMachineDeployment(ctx, cluster, "eksa-test-capd-md-0")
+	g.Expect(err).To(MatchError(ContainSubstring("getting machines for deployment eksa-test-capd-md-0")))
+}
+
 func TestKubectlGetEksaCloudStackMachineConfig(t *testing.T) {
 	tests := []struct {
 		testName         string
@@ -1384,6 +1445,27 @@ func TestKubectlGetEKSAClusters(t *testing.T) {
 	}
 }
 
+func TestKubectlGetEKSAClusterNotFound(t *testing.T) {
+	tt := newKubectlTest(t)
+	clusterName := "test-cluster"
+	tt.e.EXPECT().Execute(tt.ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "jsonpath={.items[0]}", "--kubeconfig", tt.cluster.KubeconfigFile, "--field-selector=metadata.name=" + clusterName}).Return(bytes.Buffer{}, errors.New("array index out of bounds"))
+	tt.e.EXPECT().Execute(tt.ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "--kubeconfig", tt.cluster.KubeconfigFile, "--field-selector=metadata.name=" + clusterName}).Return(bytes.Buffer{}, nil)
+
+	_, err := tt.k.GetEksaCluster(tt.ctx, tt.cluster, clusterName)
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "error should be NotFound")
+}
+
+func TestKubectlGetEksaClusterStatus(t *testing.T) {
+	tt := newKubectlTest(t)
+	clusterName := "test-cluster"
+	response := `{"metadata":{"name":"test-cluster"},"status":{"conditions":[{"type":"Ready","status":"True"}]}}`
+	tt.e.EXPECT().Execute(tt.ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "jsonpath={.items[0]}", "--kubeconfig", tt.cluster.KubeconfigFile, "--field-selector=metadata.name=" + clusterName}).Return(*bytes.NewBufferString(response), nil)
+
+	status, err := tt.k.GetEksaClusterStatus(tt.ctx, tt.cluster, clusterName)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.Conditions).To(Equal([]clusterv1.Condition{{Type: clusterv1.ReadyCondition, Status: "True"}}))
+}
+
 func TestKubectlGetGetApiServerUrlSuccess(t *testing.T) {
 	wantUrl := "https://127.0.0.1:37479"
 	k, ctx, cluster, e := newKubectl(t)
@@ -2673,6 +2755,20 @@ func TestKubectlWaitForClusterReady(t *testing.T) {
 	tt.Expect(tt.k.WaitForClusterReady(tt.ctx, tt.cluster, timeout, "test")).To(Succeed())
 }
 
+func TestKubectlWaitForClusterCondition(t *testing.T) {
+	tt := newKubectlTest(t)
+
+	timeout := "5m"
+	expectedTimeout := "300.00s"
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"wait", "--timeout", expectedTimeout, "--for=condition=InfrastructureReady", "clusters.cluster.x-k8s.io/test", "--kubeconfig", tt.cluster.KubeconfigFile, "-n", "eksa-system",
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.WaitForClusterCondition(tt.ctx, tt.cluster, timeout, "InfrastructureReady", "test")).To(Succeed())
+}
+
 func TestWaitForRufioMachines(t *testing.T) {
 	kt := newKubectlTest(t)
 
@@ -2702,6 +2798,21 @@ func TestKubectlApply(t *testing.T) {
 	tt.Expect(tt.k.Apply(tt.ctx, tt.kubeconfig, secret)).To(Succeed())
 }
 
+func TestKubectlApplyWithFieldManager(t *testing.T) {
+	tt := newKubectlTest(t)
+	secret := &corev1.Secret{}
+	b, err := yaml.Marshal(secret)
+	tt.Expect(err).To(Succeed())
+
+	tt.e.EXPECT().ExecuteWithStdin(
+		tt.ctx,
+		b,
+		"apply", "-f", "-", "--kubeconfig", tt.kubeconfig, "--server-side", "--field-manager", "flux",
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.ApplyWithFieldManager(tt.ctx, tt.kubeconfig, secret, "flux")).To(Succeed())
+}
+
 func TestKubectlListObjects(t *testing.T) {
 	tt := newKubectlTest(t)
 	list := &v1alpha1.ClusterList{}
@@ -2735,6 +2846,23 @@ func TestKubectlListObjectsMarshalError(t *testing.T) {
 	tt.Expect(tt.k.ListObjects(tt.ctx, "clusters", tt.namespace, tt.kubeconfig, &v1alpha1.ClusterList{})).To(MatchError(ContainSubstring("parsing get clusters response")))
 }
 
+func TestKubectlListObjectsInAllNamespaces(t *testing.T) {
+	tt := newKubectlTest(t)
+	list := &v1alpha1.ClusterList{
+		Items: []v1alpha1.Cluster{{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "other"}}},
+	}
+	b, err := json.Marshal(list)
+	tt.Expect(err).To(Succeed())
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig, "clusters", "--all-namespaces",
+	).Return(*bytes.NewBuffer(b), nil)
+
+	got := &v1alpha1.ClusterList{}
+	tt.Expect(tt.k.ListObjectsInAllNamespaces(tt.ctx, "clusters", tt.kubeconfig, got)).To(Succeed())
+	tt.Expect(got).To(Equal(list))
+}
+
 func TestKubectlHasResource(t *testing.T) {
 	tt := newKubectlTest(t)
 	pbc := &packagesv1.PackageBundleController{
@@ -3,6 +3,7 @@ package common
 import (
 	_ "embed"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -83,24 +84,59 @@ func WorkerMachineTemplateBase(clusterName, workerNodeGroupName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, workerNodeGroupName)
 }
 
+// TemplateNameSuffix returns the suffix appended to generated machine and kubeadm config template names.
+// New template names are what trigger CAPI rollouts, so the suffix must change whenever the templates do.
+type TemplateNameSuffix func() string
+
+// TimestampTemplateNameSuffix suffixes template names with the milliseconds since epoch returned by now.
+func TimestampTemplateNameSuffix(now types.NowFunc) TemplateNameSuffix {
+	return func() string {
+		return strconv.FormatInt(now().UnixNano()/int64(time.Millisecond), 10)
+	}
+}
+
+// GenerationTemplateNameSuffix suffixes template names with generation, so rendering the same spec
+// with the same generation always produces the same template names.
+func GenerationTemplateNameSuffix(generation int) TemplateNameSuffix {
+	return func() string {
+		return strconv.Itoa(generation)
+	}
+}
+
 func CPMachineTemplateName(clusterName string, now types.NowFunc) string {
-	t := now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-%d", CPMachineTemplateBase(clusterName), t)
+	return CPMachineTemplateNameWithSuffix(clusterName, TimestampTemplateNameSuffix(now))
+}
+
+// CPMachineTemplateNameWithSuffix returns the control plane machine template name ending with suffix.
+func CPMachineTemplateNameWithSuffix(clusterName string, suffix TemplateNameSuffix) string {
+	return fmt.Sprintf("%s-%s", CPMachineTemplateBase(clusterName), suffix())
 }
 
 func EtcdMachineTemplateName(clusterName string, now types.NowFunc) string {
-	t := now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-%d", EtcdMachineTemplateBase(clusterName), t)
+	return EtcdMachineTemplateNameWithSuffix(clusterName, TimestampTemplateNameSuffix(now))
+}
+
+// EtcdMachineTemplateNameWithSuffix returns the etcd machine template name ending with suffix.
+func EtcdMachineTemplateNameWithSuffix(clusterName string, suffix TemplateNameSuffix) string {
+	return fmt.Sprintf("%s-%s", EtcdMachineTemplateBase(clusterName), suffix())
 }
 
 func WorkerMachineTemplateName(clusterName, workerNodeGroupName string, now types.NowFunc) string {
-	t := now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-%d", WorkerMachineTemplateBase(clusterName, workerNodeGroupName), t)
+	return WorkerMachineTemplateNameWithSuffix(clusterName, workerNodeGroupName, TimestampTemplateNameSuffix(now))
+}
+
+// WorkerMachineTemplateNameWithSuffix returns the worker node group machine template name ending with suffix.
+func WorkerMachineTemplateNameWithSuffix(clusterName, workerNodeGroupName string, suffix TemplateNameSuffix) string {
+	return fmt.Sprintf("%s-%s", WorkerMachineTemplateBase(clusterName, workerNodeGroupName), suffix())
 }
 
 func KubeadmConfigTemplateName(clusterName, workerNodeGroupName string, now types.NowFunc) string {
-	t := now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-%s-template-%d", clusterName, workerNodeGroupName, t)
+	return KubeadmConfigTemplateNameWithSuffix(clusterName, workerNodeGroupName, TimestampTemplateNameSuffix(now))
+}
+
+// KubeadmConfigTemplateNameWithSuffix returns the worker node group kubeadm config template name ending with suffix.
+func KubeadmConfigTemplateNameWithSuffix(clusterName, workerNodeGroupName string, suffix TemplateNameSuffix) string {
+	return fmt.Sprintf("%s-%s-template-%s", clusterName, workerNodeGroupName, suffix())
 }
 
 // GetCAPIBottlerocketSettingsConfig returns the formatted CAPI Bottlerocket settings config as a YAML marshaled string.
@@ -3,6 +3,7 @@ package common_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -99,3 +100,32 @@ func TestGetCAPIBottlerocketSettingsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateNamesWithSuffix(t *testing.T) {
+	g := NewWithT(t)
+	now := func() time.Time { return time.UnixMilli(1234) }
+	tests := []struct {
+		name   string
+		suffix common.TemplateNameSuffix
+		want   string
+	}{
+		{
+			name:   "timestamp",
+			suffix: common.TimestampTemplateNameSuffix(now),
+			want:   "1234",
+		},
+		{
+			name:   "generation",
+			suffix: common.GenerationTemplateNameSuffix(3),
+			want:   "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Expect(common.CPMachineTemplateNameWithSuffix("test", tt.suffix)).To(Equal("test-control-plane-template-" + tt.want))
+			g.Expect(common.EtcdMachineTemplateNameWithSuffix("test", tt.suffix)).To(Equal("test-etcd-template-" + tt.want))
+			g.Expect(common.WorkerMachineTemplateNameWithSuffix("test", "md-0", tt.suffix)).To(Equal("test-md-0-" + tt.want))
+			g.Expect(common.KubeadmConfigTemplateNameWithSuffix("test", "md-0", tt.suffix)).To(Equal("test-md-0-template-" + tt.want))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// requireDigestPinnedImagesKey is the template values key set by RequireDigestPinnedImages.
+const requireDigestPinnedImagesKey = "requireDigestPinnedImages"
+
+// RequireDigestPinnedImages is a build option for GenerateCAPISpecControlPlane and GenerateCAPISpecWorkers
+// that makes the template builders fail if any image referenced in the generated spec isn't pinned by a
+// sha256 digest.
+func RequireDigestPinnedImages(values map[string]interface{}) {
+	values[requireDigestPinnedImagesKey] = true
+}
+
+// digestPinnedRepositoryTagKeys maps the template values keys of the images referenced by repository and tag,
+// like the kubeadm control plane images, to the key of their tag.
+var digestPinnedRepositoryTagKeys = map[string]string{
+	"kubernetesRepository":            "kubernetesVersion",
+	"etcdRepository":                  "etcdImageTag",
+	"corednsRepository":               "corednsVersion",
+	"pauseRepository":                 "pauseVersion",
+	"bottlerocketBootstrapRepository": "bottlerocketBootstrapVersion",
+}
+
+// ValidateDigestPinnedImages checks that all the images in the template values are pinned by digest,
+// only if RequireDigestPinnedImages was applied to them. Images are the non empty string values
+// with a key ending in "Image", and the images built from the repository and tag pairs in
+// digestPinnedRepositoryTagKeys.
+func ValidateDigestPinnedImages(values map[string]interface{}) error {
+	if required, _ := values[requireDigestPinnedImagesKey].(bool); !required {
+		return nil
+	}
+
+	var unpinned []string
+	for key, value := range values {
+		image, ok := value.(string)
+		if !ok || image == "" || !strings.HasSuffix(key, "Image") {
+			continue
+		}
+		if !strings.Contains(image, "@sha256:") {
+			unpinned = append(unpinned, image)
+		}
+	}
+
+	for repositoryKey, tagKey := range digestPinnedRepositoryTagKeys {
+		repository, _ := values[repositoryKey].(string)
+		if repository == "" {
+			continue
+		}
+		tag, _ := values[tagKey].(string)
+		if image := repository + ":" + tag; !strings.Contains(image, "@sha256:") {
+			unpinned = append(unpinned, image)
+		}
+	}
+
+	if len(unpinned) > 0 {
+		sort.Strings(unpinned)
+		return fmt.Errorf("images must be pinned by digest: %s", strings.Join(unpinned, ", "))
+	}
+
+	return nil
+}
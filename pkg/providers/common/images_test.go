@@ -0,0 +1,74 @@
+package common_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+func TestValidateDigestPinnedImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		require bool
+		wantErr string
+	}{
+		{
+			name: "not required",
+			values: map[string]interface{}{
+				"kubeVipImage": "public.ecr.aws/kube-vip/kube-vip:v0.3.7",
+			},
+		},
+		{
+			name: "all pinned",
+			values: map[string]interface{}{
+				"kubeVipImage":    "public.ecr.aws/kube-vip/kube-vip@sha256:abc",
+				"etcdImage":       "",
+				"kubernetesImage": 1,
+				"clusterName":     "test",
+			},
+			require: true,
+		},
+		{
+			name: "tag only",
+			values: map[string]interface{}{
+				"managerImage": "public.ecr.aws/vsphere/manager:v1.0.0",
+				"kubeVipImage": "public.ecr.aws/kube-vip/kube-vip:v0.3.7",
+				"syncerImage":  "public.ecr.aws/vsphere/syncer@sha256:abc",
+			},
+			require: true,
+			wantErr: "images must be pinned by digest: public.ecr.aws/kube-vip/kube-vip:v0.3.7, public.ecr.aws/vsphere/manager:v1.0.0",
+		},
+		{
+			name: "repository and tag pairs",
+			values: map[string]interface{}{
+				"kubernetesRepository":            "public.ecr.aws/eks-distro/kubernetes",
+				"kubernetesVersion":               "v1.21.2-eks-1-21-4",
+				"corednsRepository":               "public.ecr.aws/eks-distro/coredns",
+				"corednsVersion":                  "v1.8.3-eks-1-21-4@sha256:abc",
+				"pauseRepository":                 "public.ecr.aws/eks-distro/kubernetes/pause@sha256",
+				"pauseVersion":                    "abc",
+				"bottlerocketBootstrapRepository": "public.ecr.aws/bottlerocket/bottlerocket-bootstrap",
+				"bottlerocketBootstrapVersion":    "v1-21-4-eks-a-v0.0.0-dev-build.158",
+			},
+			require: true,
+			wantErr: "images must be pinned by digest: public.ecr.aws/bottlerocket/bottlerocket-bootstrap:v1-21-4-eks-a-v0.0.0-dev-build.158, public.ecr.aws/eks-distro/kubernetes:v1.21.2-eks-1-21-4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			if tt.require {
+				common.RequireDigestPinnedImages(tt.values)
+			}
+			err := common.ValidateDigestPinnedImages(tt.values)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
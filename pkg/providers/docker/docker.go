@@ -175,7 +175,7 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluste
 	return bytes, nil
 }
 
-func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
+func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	workerSpecs := make([][]byte, 0, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		values, err := buildTemplateMapMD(clusterSpec, workerNodeGroupConfiguration)
@@ -185,6 +185,10 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spe
 		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupConfiguration.Name]
 		values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name]
 
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
+
 		bytes, err := templater.Execute(defaultCAPIConfigMD, values)
 		if err != nil {
 			return nil, err
@@ -267,6 +271,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"etcdExtraArgs":                 etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
+		"auditLogRotationArgs":          clusterapi.AuditLogRotationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.AuditLogRotation).ToPartialYaml(),
 		"controllermanagerExtraArgs":    controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":            sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":              kubeletExtraArgs.ToPartialYaml(),
@@ -3,12 +3,17 @@ package tinkerbell
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	tinkerbellv1 "github.com/tinkerbell/cluster-api-provider-tinkerbell/api/v1beta1"
+	rufiov1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
@@ -89,6 +94,70 @@ func AssertOsFamilyValid(spec *ClusterSpec) error {
 	return validateOsFamily(spec)
 }
 
+// AssertMTUNotSetWithTemplateRefs ensures the datacenter config MTU isn't set when a machine config references
+// a TinkerbellTemplateConfig. The MTU is only applied by the default templates so it would be silently ignored.
+func AssertMTUNotSetWithTemplateRefs(spec *ClusterSpec) error {
+	if spec.DatacenterConfig.Spec.MTU == 0 {
+		return nil
+	}
+
+	for _, machineConfig := range usedMachineConfigs(spec) {
+		if machineConfig != nil && machineConfig.Spec.TemplateRef.Name != "" {
+			return fmt.Errorf("mtu is not supported with custom TinkerbellTemplateConfig %s referenced by TinkerbellMachineConfig %s: configure the MTU in the template actions instead",
+				machineConfig.Spec.TemplateRef.Name, machineConfig.Name)
+		}
+	}
+
+	return nil
+}
+
+// AssertWorkerNodeGroupOSFamilyMatchesTemplate ensures the OSFamily of each worker node group machine config
+// matches the OS provisioned by its referenced TinkerbellTemplateConfig. Default template configs are generated
+// for the machine config OSFamily so they always match. Template configs whose OS can't be determined from their
+// actions are skipped.
+func AssertWorkerNodeGroupOSFamilyMatchesTemplate(spec *ClusterSpec) error {
+	for _, group := range spec.WorkerNodeGroupConfigurations() {
+		machineConfig := spec.WorkerNodeGroupMachineConfig(group)
+		if machineConfig == nil || machineConfig.Spec.TemplateRef.Name == "" {
+			continue
+		}
+
+		templateConfig, ok := spec.TinkerbellTemplateConfigs[machineConfig.Spec.TemplateRef.Name]
+		if !ok {
+			continue
+		}
+
+		bottlerocket, known := templateConfigProvisionsBottlerocket(templateConfig)
+		if !known || bottlerocket == (machineConfig.OSFamily() == v1alpha1.Bottlerocket) {
+			continue
+		}
+
+		templateOS := "a cloud-init based OS (ubuntu or redhat)"
+		if bottlerocket {
+			templateOS = string(v1alpha1.Bottlerocket)
+		}
+		return fmt.Errorf("worker node group %s: TinkerbellMachineConfig %s osFamily %s doesn't match TinkerbellTemplateConfig %s, which provisions %s",
+			group.Name, machineConfig.Name, machineConfig.OSFamily(), templateConfig.Name, templateOS)
+	}
+	return nil
+}
+
+// templateConfigProvisionsBottlerocket reports whether config provisions Bottlerocket based on its actions.
+// known is false when the actions don't match any of the default Bottlerocket or cloud-init actions.
+func templateConfigProvisionsBottlerocket(config *v1alpha1.TinkerbellTemplateConfig) (bottlerocket, known bool) {
+	for _, task := range config.Spec.Template.Tasks {
+		for _, action := range task.Actions {
+			switch action.Name {
+			case "write-bootconfig":
+				return true, true
+			case "add-tink-cloud-init-config", "add-tink-cloud-init-ds-config", "disable-cloud-init-network-capabilities":
+				return false, true
+			}
+		}
+	}
+	return false, false
+}
+
 // AssertcontrolPlaneIPNotInUse ensures the endpoint host for the control plane isn't in use.
 // The check may be unreliable due to its implementation.
 func NewIPNotInUseAssertion(client networkutils.NetClient) ClusterSpecAssertion {
@@ -133,6 +202,49 @@ func AssertPortsNotInUse(client networkutils.NetClient) ClusterSpecAssertion {
 	}
 }
 
+// AssertOSImageURLReachable ensures the OS image URL of every machine config used by the cluster,
+// the machine config OSImageURL or, when unset, the datacenter OSImageURL, responds successfully
+// to a HEAD request. Machines without an OS image URL are skipped.
+func AssertOSImageURLReachable(client HTTPHeadClient) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		checked := make(map[string]struct{})
+		for _, machineConfig := range usedMachineConfigs(spec) {
+			if machineConfig == nil {
+				continue
+			}
+
+			url := osImageURL(spec.DatacenterConfig.Spec, machineConfig.Spec)
+			if url == "" {
+				continue
+			}
+			if _, ok := checked[url]; ok {
+				continue
+			}
+			checked[url] = struct{}{}
+
+			if err := assertURLReachable(client, url); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func assertURLReachable(client HTTPHeadClient, url string) error {
+	resp, err := client.Head(url)
+	if err != nil {
+		return fmt.Errorf("osImageURL %s is not reachable: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("osImageURL %s is not reachable: received status %s", url, resp.Status)
+	}
+
+	return nil
+}
+
 // HardwareSatisfiesOnlyOneSelectorAssertion ensures hardware in catalogue only satisfies 1
 // of the MachineConfig's HardwareSelector's from the spec.
 func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
@@ -146,6 +258,158 @@ func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) Cl
 	}
 }
 
+// minimumDiskSizeGiB is the smallest disk, in GiB, the OS images of each OS family can be installed on.
+var minimumDiskSizeGiB = map[v1alpha1.OSFamily]int{
+	v1alpha1.Ubuntu:       20,
+	v1alpha1.RedHat:       20,
+	v1alpha1.Bottlerocket: 10,
+}
+
+// AssertHardwareDiskSizes ensures the disk of every hardware in catalogue matching a machine config
+// hardware selector is big enough for the OS image of that machine config. Hardware without a disk size
+// isn't validated.
+func AssertHardwareDiskSizes(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		var missingSize bool
+		for _, machineConfig := range usedMachineConfigs(spec) {
+			if machineConfig == nil {
+				continue
+			}
+
+			minimum, ok := minimumDiskSizeGiB[machineConfig.OSFamily()]
+			if !ok {
+				continue
+			}
+
+			for _, h := range catalogue.AllHardware() {
+				if !hardware.LabelsMatchSelector(machineConfig.Spec.HardwareSelector, h.Labels) {
+					continue
+				}
+
+				size, ok := hardware.DiskSizeGiB(h)
+				if !ok {
+					missingSize = true
+					continue
+				}
+
+				if size < minimum {
+					return fmt.Errorf(
+						"hardware %s disk is %dGiB, smaller than the %dGiB required by %s machine config %s",
+						h.Spec.Metadata.Instance.ID, size, minimum, machineConfig.OSFamily(), machineConfig.Name,
+					)
+				}
+			}
+		}
+
+		if missingSize {
+			logger.Info("Warning: disk size not provided for some hardware, skipping disk size validation for them")
+		}
+
+		return nil
+	}
+}
+
+// AssertHardwareBMCsConfigured ensures the hardware in catalogue either all have a BMC or none do, and
+// that every BMC referenced by hardware exists with an IP address, a username and a password, as
+// the Rufio machines can't be contactable otherwise.
+func AssertHardwareBMCsConfigured(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		bmcs := map[string]*rufiov1.Machine{}
+		for _, bmc := range catalogue.AllBMCs() {
+			bmcs[bmc.Name] = bmc
+		}
+
+		secrets := map[string]*corev1.Secret{}
+		for _, secret := range catalogue.AllSecrets() {
+			secrets[secret.Name] = secret
+		}
+
+		var withBMC, withoutBMC []string
+		for _, h := range catalogue.AllHardware() {
+			if h.Spec.BMCRef == nil {
+				withoutBMC = append(withoutBMC, h.Name)
+				continue
+			}
+			withBMC = append(withBMC, h.Name)
+
+			bmc, ok := bmcs[h.Spec.BMCRef.Name]
+			if !ok {
+				return fmt.Errorf("hardware %s references BMC %s which doesn't exist", h.Name, h.Spec.BMCRef.Name)
+			}
+			if bmc.Spec.Connection.Host == "" {
+				return fmt.Errorf("BMC %s of hardware %s has no IP address", bmc.Name, h.Name)
+			}
+
+			secret, ok := secrets[bmc.Spec.Connection.AuthSecretRef.Name]
+			if !ok {
+				return fmt.Errorf("BMC %s of hardware %s has no credentials", bmc.Name, h.Name)
+			}
+			for _, key := range []string{"username", "password"} {
+				if len(secret.Data[key]) == 0 {
+					return fmt.Errorf("BMC %s of hardware %s has no %s", bmc.Name, h.Name, key)
+				}
+			}
+		}
+
+		if len(withBMC) > 0 && len(withoutBMC) > 0 {
+			return fmt.Errorf("hardware without a BMC (%s) can't be mixed with hardware with a BMC (%s): either all or none of the hardware must have a BMC",
+				strings.Join(withoutBMC, ", "), strings.Join(withBMC, ", "))
+		}
+
+		return nil
+	}
+}
+
+// AssertEndpointIPsNotAssignedToHardware ensures the control plane endpoint and the TinkerbellIP
+// aren't also the host or BMC IP of any hardware in catalogue, as that causes ARP conflicts once
+// kube-vip or the Tinkerbell stack start advertising them.
+func AssertEndpointIPsNotAssignedToHardware(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		bmcIPs := map[string]string{}
+		for _, bmc := range catalogue.AllBMCs() {
+			bmcIPs[bmc.Name] = bmc.Spec.Connection.Host
+		}
+
+		endpoints := []struct {
+			name string
+			ip   string
+		}{
+			{name: "control plane endpoint", ip: spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host},
+			{name: "tinkerbellIP", ip: spec.DatacenterConfig.Spec.TinkerbellIP},
+		}
+
+		for _, h := range catalogue.AllHardware() {
+			var hostIPs []string
+			for _, iface := range h.Spec.Interfaces {
+				if iface.DHCP != nil && iface.DHCP.IP != nil {
+					hostIPs = append(hostIPs, iface.DHCP.IP.Address)
+				}
+			}
+
+			var bmcIP string
+			if h.Spec.BMCRef != nil {
+				bmcIP = bmcIPs[h.Spec.BMCRef.Name]
+			}
+
+			for _, endpoint := range endpoints {
+				if endpoint.ip == "" {
+					continue
+				}
+				for _, ip := range hostIPs {
+					if ip == endpoint.ip {
+						return fmt.Errorf("%s %s is the IP of hardware %s", endpoint.name, endpoint.ip, h.Name)
+					}
+				}
+				if bmcIP == endpoint.ip {
+					return fmt.Errorf("%s %s is the BMC IP of hardware %s", endpoint.name, endpoint.ip, h.Name)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
 // selectorsFromClusterSpec extracts all selectors specified on MachineConfig's from spec.
 func selectorsFromClusterSpec(spec *ClusterSpec) (selectorSet, error) {
 	selectors := selectorSet{}
@@ -183,40 +447,50 @@ func MinimumHardwareAvailableAssertionForCreate(catalogue *hardware.Catalogue) C
 			return err
 		}
 
-		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
-		// will account for the same selector being specified on different groups.
-		requirements := minimumHardwareRequirements{}
-
-		err := requirements.Add(
-			spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-			spec.ControlPlaneConfiguration().Count,
-		)
+		requirements, err := createHardwareRequirements(spec)
 		if err != nil {
 			return err
 		}
 
-		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
-			err := requirements.Add(
-				spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
-				*nodeGroup.Count,
-			)
-			if err != nil {
-				return err
-			}
-		}
+		return validateMinimumHardwareRequirements(requirements, catalogue)
+	}
+}
 
-		if spec.HasExternalEtcd() {
-			err := requirements.Add(
-				spec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
-				spec.ExternalEtcdConfiguration().Count,
-			)
-			if err != nil {
-				return err
-			}
+// createHardwareRequirements builds the hardware required by each machine group of spec to create
+// the cluster. minimumHardwareRequirements accounts for the same selector being specified on
+// different groups.
+func createHardwareRequirements(spec *ClusterSpec) (minimumHardwareRequirements, error) {
+	requirements := minimumHardwareRequirements{}
+
+	err := requirements.Add(
+		spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		spec.ControlPlaneConfiguration().Count,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+		err := requirements.Add(
+			spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
+			*nodeGroup.Count,
+		)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		return validateMinimumHardwareRequirements(requirements, catalogue)
+	if spec.HasExternalEtcd() {
+		err := requirements.Add(
+			spec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
+			spec.ExternalEtcdConfiguration().Count,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	return requirements, nil
 }
 
 // WorkerNodeHardware holds machine deployment name, replica count and hardware selector for a Tinkerbell worker node.
@@ -293,9 +567,6 @@ func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current Validatable
 			return err
 		}
 
-		if spec.HasExternalEtcd() {
-			return fmt.Errorf("scale up/down not supported for external etcd")
-		}
 		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
 		// will account for the same selector being specified on different groups.
 		requirements := minimumHardwareRequirements{}
@@ -361,6 +632,21 @@ func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current Validatable
 // ExtraHardwareAvailableAssertionForRollingUpgrade asserts that catalogue has sufficient hardware to
 // support the ClusterSpec during an rolling upgrade workflow.
 func ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return extraHardwareAvailableAssertionForRollout(catalogue, true, func(string) bool { return true })
+}
+
+// ExtraHardwareAvailableAssertionForWorkerRollout asserts that catalogue has sufficient hardware to
+// surge the machines of nodeGroups, the worker node groups getting new machine templates while the
+// control plane and external etcd aren't rolled out.
+func ExtraHardwareAvailableAssertionForWorkerRollout(catalogue *hardware.Catalogue, nodeGroups []string) ClusterSpecAssertion {
+	rolledOut := make(map[string]bool, len(nodeGroups))
+	for _, name := range nodeGroups {
+		rolledOut[name] = true
+	}
+	return extraHardwareAvailableAssertionForRollout(catalogue, false, func(name string) bool { return rolledOut[name] })
+}
+
+func extraHardwareAvailableAssertionForRollout(catalogue *hardware.Catalogue, controlPlaneAndEtcd bool, workerRolledOut func(name string) bool) ClusterSpecAssertion {
 	return func(spec *ClusterSpec) error {
 		// Without Hardware selectors we get undesirable behavior so ensure we have them for
 		// all MachineConfigs.
@@ -372,37 +658,63 @@ func ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue *hardware.Catalo
 		// will account for the same selector being specified on different groups.
 		requirements := minimumHardwareRequirements{}
 
-		maxSurge := 1
-		if spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
-			maxSurge = spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		// surges records, per selector, which groups need spare hardware and how much so the
+		// error explains where the requirement comes from.
+		surges := map[string][]string{}
+		add := func(selector v1alpha1.HardwareSelector, maxSurge int, group string) error {
+			if err := requirements.Add(selector, maxSurge); err != nil {
+				return fmt.Errorf("for rolling upgrade, %v", err)
+			}
+			name, err := selector.ToString()
+			if err != nil {
+				return fmt.Errorf("for rolling upgrade, %v", err)
+			}
+			surges[name] = append(surges[name], fmt.Sprintf("%v (maxSurge %v)", group, maxSurge))
+			return nil
 		}
-		err := requirements.Add(
-			spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-			maxSurge,
-		)
-		if err != nil {
-			return fmt.Errorf("for rolling upgrade, %v", err)
+
+		if controlPlaneAndEtcd {
+			maxSurge := 1
+			if spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+				maxSurge = spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+			}
+			if err := add(spec.ControlPlaneMachineConfig().Spec.HardwareSelector, maxSurge, "control plane"); err != nil {
+				return err
+			}
 		}
 
 		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
-			maxSurge = 1
+			if !workerRolledOut(nodeGroup.Name) {
+				continue
+			}
+			maxSurge := 1
 			if nodeGroup.UpgradeRolloutStrategy != nil {
 				maxSurge = nodeGroup.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
 			}
-			err := requirements.Add(
-				spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
-				maxSurge,
-			)
-			if err != nil {
-				return fmt.Errorf("for rolling upgrade, %v", err)
+			group := fmt.Sprintf("worker node group %v", nodeGroup.Name)
+			if err := add(spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector, maxSurge, group); err != nil {
+				return err
 			}
 		}
 
-		if spec.HasExternalEtcd() {
-			return fmt.Errorf("external etcd upgrade is not supported")
+		// The etcdadm controller replaces etcd machines one at a time so it needs a single extra
+		// machine to roll out the upgrade.
+		if controlPlaneAndEtcd && spec.HasExternalEtcd() {
+			if err := add(spec.ExternalEtcdMachineConfig().Spec.HardwareSelector, 1, "external etcd"); err != nil {
+				return err
+			}
 		}
 
 		if err := validateMinimumHardwareRequirements(requirements, catalogue); err != nil {
+			var countErr minimumHardwareCountErr
+			if errors.As(err, &countErr) {
+				return fmt.Errorf(
+					"for rolling upgrade, %v; a rolling upgrade needs unprovisioned hardware matching the "+
+						"selector for each new machine it surges, required by %v",
+					err,
+					strings.Join(surges[countErr.Selector], ", "),
+				)
+			}
 			return fmt.Errorf("for rolling upgrade, %v", err)
 		}
 		return nil
@@ -444,3 +756,19 @@ type missingHardwareSelectorErr struct {
 func (e missingHardwareSelectorErr) Error() string {
 	return fmt.Sprintf("missing hardware selector for %v", e.Name)
 }
+
+// minimumHardwareCountErr is returned when fewer hardware than required match a selector.
+type minimumHardwareCountErr struct {
+	Selector string
+	Have     int
+	Require  int
+}
+
+func (e minimumHardwareCountErr) Error() string {
+	return fmt.Sprintf(
+		"minimum hardware count not met for selector '%v': have %v, require %v",
+		e.Selector,
+		e.Have,
+		e.Require,
+	)
+}
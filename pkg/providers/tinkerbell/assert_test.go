@@ -2,24 +2,31 @@ package tinkerbell_test
 
 import (
 	"errors"
+	"io"
 	"net"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
 	"github.com/tinkerbell/cluster-api-provider-tinkerbell/api/v1beta1"
+	rufiov1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	"github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	tinkerbellworkflow "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	tinkerbellmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
@@ -153,6 +160,66 @@ func TestAssertK8SVersionNot120_Error(t *testing.T) {
 	g.Expect(tinkerbell.AssertK8SVersionNot120(clusterSpec)).Error().Should(gomega.HaveOccurred())
 }
 
+func TestAssertOsFamilyValid_UbuntuWithMTUSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.MTU = 9000
+	g.Expect(tinkerbell.AssertOsFamilyValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertOsFamilyValid_BottlerocketWithMTUFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	for _, config := range clusterSpec.MachineConfigs {
+		config.Spec.OSFamily = eksav1alpha1.Bottlerocket
+	}
+	clusterSpec.DatacenterConfig.Spec.MTU = 9000
+	g.Expect(tinkerbell.AssertOsFamilyValid(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("mtu is not supported for bottlerocket")))
+}
+
+func TestAssertMTUNotSetWithTemplateRefs_DefaultTemplatesSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.MTU = 9000
+	g.Expect(tinkerbell.AssertMTUNotSetWithTemplateRefs(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMTUNotSetWithTemplateRefs_TemplateRefWithoutMTUSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.ControlPlaneMachineConfig().Spec.TemplateRef = eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellTemplateConfigKind, Name: "custom-template"}
+	g.Expect(tinkerbell.AssertMTUNotSetWithTemplateRefs(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMTUNotSetWithTemplateRefs_TemplateRefWithMTUFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.MTU = 9000
+	workerMachineConfig := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0])
+	workerMachineConfig.Spec.TemplateRef = eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellTemplateConfigKind, Name: "custom-template"}
+	g.Expect(tinkerbell.AssertMTUNotSetWithTemplateRefs(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring(
+		"mtu is not supported with custom TinkerbellTemplateConfig custom-template referenced by TinkerbellMachineConfig " + workerMachineConfig.Name,
+	)))
+}
+
+func TestAssertOsFamilyValid_MachineConfigOSImageURLsSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = ""
+	for _, config := range clusterSpec.MachineConfigs {
+		config.Spec.OSImageURL = "https://ubuntu.gz"
+	}
+	g.Expect(tinkerbell.AssertOsFamilyValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertOsFamilyValid_MissingWorkerOSImageURLFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = ""
+	clusterSpec.ControlPlaneMachineConfig().Spec.OSImageURL = "https://ubuntu.gz"
+	g.Expect(tinkerbell.AssertOsFamilyValid(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("provide a valid osImageURL")))
+}
+
 func TestAssertWorkerNodeGroupMachineRefsExists_Missing(t *testing.T) {
 	g := gomega.NewWithT(t)
 	builder := NewDefaultValidClusterSpecBuilder()
@@ -251,6 +318,60 @@ func TestAssertTinkerbellIPAndControlPlaneIPNotSame_SameFails(t *testing.T) {
 	g.Expect(tinkerbell.AssertTinkerbellIPAndControlPlaneIPNotSame(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestAssertWorkerNodeGroupOSFamilyMatchesTemplate_MatchingUbuntuSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.TinkerbellTemplateConfigs = map[string]*eksav1alpha1.TinkerbellTemplateConfig{
+		"ubuntu-template": newTemplateConfigWithActions("ubuntu-template", "stream-image", "write-netplan", "add-tink-cloud-init-config", "reboot-image"),
+	}
+	workerMachineConfig := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0])
+	workerMachineConfig.Spec.OSFamily = eksav1alpha1.Ubuntu
+	workerMachineConfig.Spec.TemplateRef = eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellTemplateConfigKind, Name: "ubuntu-template"}
+
+	g.Expect(tinkerbell.AssertWorkerNodeGroupOSFamilyMatchesTemplate(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertWorkerNodeGroupOSFamilyMatchesTemplate_BottlerocketWithUbuntuTemplateFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.TinkerbellTemplateConfigs = map[string]*eksav1alpha1.TinkerbellTemplateConfig{
+		"ubuntu-template": newTemplateConfigWithActions("ubuntu-template", "stream-image", "write-netplan", "add-tink-cloud-init-config", "reboot-image"),
+	}
+	group := clusterSpec.WorkerNodeGroupConfigurations()[0]
+	workerMachineConfig := clusterSpec.WorkerNodeGroupMachineConfig(group)
+	workerMachineConfig.Spec.OSFamily = eksav1alpha1.Bottlerocket
+	workerMachineConfig.Spec.TemplateRef = eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellTemplateConfigKind, Name: "ubuntu-template"}
+
+	g.Expect(tinkerbell.AssertWorkerNodeGroupOSFamilyMatchesTemplate(clusterSpec)).To(gomega.MatchError(
+		"worker node group " + group.Name + ": TinkerbellMachineConfig " + workerMachineConfig.Name +
+			" osFamily bottlerocket doesn't match TinkerbellTemplateConfig ubuntu-template, which provisions a cloud-init based OS (ubuntu or redhat)",
+	))
+}
+
+func TestAssertWorkerNodeGroupOSFamilyMatchesTemplate_DefaultTemplateSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	workerMachineConfig := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0])
+	workerMachineConfig.Spec.OSFamily = eksav1alpha1.Bottlerocket
+
+	g.Expect(tinkerbell.AssertWorkerNodeGroupOSFamilyMatchesTemplate(clusterSpec)).To(gomega.Succeed())
+}
+
+func newTemplateConfigWithActions(name string, actionNames ...string) *eksav1alpha1.TinkerbellTemplateConfig {
+	actions := make([]tinkerbellworkflow.Action, 0, len(actionNames))
+	for _, n := range actionNames {
+		actions = append(actions, tinkerbellworkflow.Action{Name: n})
+	}
+	return &eksav1alpha1.TinkerbellTemplateConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: eksav1alpha1.TinkerbellTemplateConfigSpec{
+			Template: tinkerbellworkflow.Workflow{
+				Tasks: []tinkerbellworkflow.Task{{Actions: actions}},
+			},
+		},
+	}
+}
+
 func TestAssertPortsNotInUse_Succeeds(t *testing.T) {
 	g := gomega.NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -286,6 +407,197 @@ func TestAssertPortsNotInUse_Fails(t *testing.T) {
 	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestAssertOSImageURLReachable_Succeeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = "http://example.com/ubuntu.gz"
+
+	client := tinkerbellmocks.NewMockHTTPHeadClient(ctrl)
+	client.EXPECT().
+		Head("http://example.com/ubuntu.gz").
+		Return(&http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	assertion := tinkerbell.AssertOSImageURLReachable(client)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertOSImageURLReachable_MachineConfigURLs(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	builder := NewDefaultValidClusterSpecBuilder()
+	clusterSpec := builder.Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = "http://example.com/ubuntu.gz"
+	clusterSpec.MachineConfigs[builder.ExternalEtcdMachineName].Spec.OSImageURL = "http://example.com/ubuntu.gz"
+	clusterSpec.MachineConfigs[builder.WorkerNodeGroupMachineName].Spec.OSImageURL = "http://example.com/worker.gz"
+
+	client := tinkerbellmocks.NewMockHTTPHeadClient(ctrl)
+	client.EXPECT().
+		Head("http://example.com/ubuntu.gz").
+		Return(&http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(""))}, nil)
+	client.EXPECT().
+		Head("http://example.com/worker.gz").
+		Return(&http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	assertion := tinkerbell.AssertOSImageURLReachable(client)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("osImageURL http://example.com/worker.gz is not reachable: received status 404 Not Found"))
+}
+
+func TestAssertOSImageURLReachable_SkipsEmptyURL(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = ""
+
+	client := tinkerbellmocks.NewMockHTTPHeadClient(ctrl)
+
+	assertion := tinkerbell.AssertOSImageURLReachable(client)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertOSImageURLReachable_ErrorStatusFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = "http://example.com/ubuntu.gz"
+
+	client := tinkerbellmocks.NewMockHTTPHeadClient(ctrl)
+	client.EXPECT().
+		Head("http://example.com/ubuntu.gz").
+		Return(&http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	assertion := tinkerbell.AssertOSImageURLReachable(client)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("osImageURL http://example.com/ubuntu.gz is not reachable: received status 404 Not Found"))
+}
+
+func TestAssertOSImageURLReachable_ConnectionErrorFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = "http://example.com/ubuntu.gz"
+
+	client := tinkerbellmocks.NewMockHTTPHeadClient(ctrl)
+	client.EXPECT().
+		Head("http://example.com/ubuntu.gz").
+		Return(nil, errors.New("connection refused"))
+
+	assertion := tinkerbell.AssertOSImageURLReachable(client)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("osImageURL http://example.com/ubuntu.gz is not reachable: connection refused"))
+}
+
+func TestAssertEndpointIPsNotAssignedToHardware_TinkerbellIPIsBMCIPFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertBMC(&rufiov1.Machine{
+		ObjectMeta: v1.ObjectMeta{Name: "bmc-worker1"},
+		Spec: rufiov1.MachineSpec{
+			Connection: rufiov1.Connection{Host: "1.1.1.2"},
+		},
+	})).To(gomega.Succeed())
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{Name: "worker1"},
+		Spec: v1alpha1.HardwareSpec{
+			BMCRef: &corev1.TypedLocalObjectReference{Name: "bmc-worker1"},
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.AssertEndpointIPsNotAssignedToHardware(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("tinkerbellIP 1.1.1.2 is the BMC IP of hardware worker1"))
+}
+
+func TestAssertEndpointIPsNotAssignedToHardware_NoConflictSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{Name: "worker1"},
+		Spec: v1alpha1.HardwareSpec{
+			Interfaces: []v1alpha1.Interface{
+				{DHCP: &v1alpha1.DHCP{IP: &v1alpha1.IP{Address: "10.10.10.10"}}},
+			},
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.AssertEndpointIPsNotAssignedToHardware(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertHardwareDiskSizes_DiskTooSmallFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Labels:      clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+			Annotations: map[string]string{hardware.DiskSizeGiBAnnotation: "100"},
+		},
+		Spec: v1alpha1.HardwareSpec{
+			Metadata: &v1alpha1.HardwareMetadata{Instance: &v1alpha1.MetadataInstance{ID: "00:00:00:00:00:01"}},
+		},
+	})).To(gomega.Succeed())
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Labels:      clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector,
+			Annotations: map[string]string{hardware.DiskSizeGiBAnnotation: "8"},
+		},
+		Spec: v1alpha1.HardwareSpec{
+			Metadata: &v1alpha1.HardwareMetadata{Instance: &v1alpha1.MetadataInstance{ID: "00:00:00:00:00:02"}},
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.AssertHardwareDiskSizes(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(
+		"hardware 00:00:00:00:00:02 disk is 8GiB, smaller than the 20GiB required by ubuntu machine config worker-node-group",
+	))
+}
+
+func TestAssertHardwareDiskSizes_NoDiskSizeSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.AssertHardwareDiskSizes(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertHardwareDiskSizes_MissingMachineConfigSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	builder := NewDefaultValidClusterSpecBuilder()
+	clusterSpec := builder.Build()
+	delete(clusterSpec.MachineConfigs, builder.WorkerNodeGroupMachineName)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Labels:      clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+			Annotations: map[string]string{hardware.DiskSizeGiBAnnotation: "100"},
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.AssertHardwareDiskSizes(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
 func TestMinimumHardwareAvailableAssertionForCreate_SufficientSucceeds(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -400,6 +712,73 @@ func TestMinimumHardwareAvailableAssertionForCreate_NoWorkerNodeGroupSelectorMat
 	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestMinimumHardwareAvailableAssertionForCreate_SharedWorkerMachineConfigSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations = append(
+		clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations,
+		eksav1alpha1.WorkerNodeGroupConfiguration{
+			Name:            "worker-node-group-1",
+			Count:           ptr.Int(1),
+			MachineGroupRef: clusterSpec.WorkerNodeGroupConfigurations()[0].MachineGroupRef,
+		},
+	)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "cp",
+			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+
+	selector := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector
+	for _, name := range []string{"worker-0", "worker-1"} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: selector},
+		})).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestMinimumHardwareAvailableAssertionForCreate_SharedWorkerMachineConfigInsufficientFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations = append(
+		clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations,
+		eksav1alpha1.WorkerNodeGroupConfiguration{
+			Name:            "worker-node-group-1",
+			Count:           ptr.Int(1),
+			MachineGroupRef: clusterSpec.WorkerNodeGroupConfigurations()[0].MachineGroupRef,
+		},
+	)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "cp",
+			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "worker-0",
+			Labels: clusterSpec.WorkerNodeGroupMachineConfig(
+				clusterSpec.WorkerNodeGroupConfigurations()[0],
+			).Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("have 1, require 2")))
+}
+
 func TestMinimumHardwareAvailableAssertionForCreate_InsufficientFails(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -546,7 +925,145 @@ func TestAssertionsForScaleUpDown_AddWorkerSuccess(t *testing.T) {
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForScaleUpDown_ExternalEtcdErrorFails(t *testing.T) {
+func TestExtraHardwareAvailableAssertionForRollingUpgrade_ExternalEtcdSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	for _, selector := range []eksav1alpha1.HardwareSelector{
+		clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		clusterSpec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
+		clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector,
+	} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{
+				Labels: selector,
+			},
+		})).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestExtraHardwareAvailableAssertionForRollingUpgrade_InsufficientExternalEtcdFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	for _, selector := range []eksav1alpha1.HardwareSelector{
+		clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector,
+	} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{
+				Labels: selector,
+			},
+		})).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("for rolling upgrade")))
+}
+
+func TestExtraHardwareAvailableAssertionForRollingUpgrade_WorkerMaxSurgeSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	nodeGroup := &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	nodeGroup.UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: eksav1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 1},
+	}
+
+	catalogue := hardware.NewCatalogue()
+	for _, selector := range []eksav1alpha1.HardwareSelector{
+		clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		clusterSpec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
+		clusterSpec.WorkerNodeGroupMachineConfig(*nodeGroup).Spec.HardwareSelector,
+	} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{
+				Labels: selector,
+			},
+		})).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestExtraHardwareAvailableAssertionForRollingUpgrade_InsufficientWorkerMaxSurgeFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	nodeGroup := &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	nodeGroup.UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: eksav1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+	}
+
+	catalogue := hardware.NewCatalogue()
+	for _, selector := range []eksav1alpha1.HardwareSelector{
+		clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		clusterSpec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
+		clusterSpec.WorkerNodeGroupMachineConfig(*nodeGroup).Spec.HardwareSelector,
+	} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{
+				Labels: selector,
+			},
+		})).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.And(
+		gomega.ContainSubstring("have 1, require 2"),
+		gomega.ContainSubstring("required by worker node group "+nodeGroup.Name+" (maxSurge 2)"),
+	)))
+}
+
+func TestExtraHardwareAvailableAssertionForWorkerRollout_InsufficientWorkerMaxSurgeFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	nodeGroup := &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	nodeGroup.UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: eksav1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+	}
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Labels: clusterSpec.WorkerNodeGroupMachineConfig(*nodeGroup).Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForWorkerRollout(catalogue, []string{nodeGroup.Name})
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.And(
+		gomega.ContainSubstring("have 1, require 2"),
+		gomega.ContainSubstring("required by worker node group "+nodeGroup.Name+" (maxSurge 2)"),
+	)))
+}
+
+func TestExtraHardwareAvailableAssertionForWorkerRollout_IgnoresControlPlaneAndUnlistedGroups(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	nodeGroup := &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	nodeGroup.UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: eksav1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+	}
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertionForWorkerRollout(hardware.NewCatalogue(), []string{"other-group"})
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertionsForScaleUpDown_ExternalEtcdSuccess(t *testing.T) {
 	g := gomega.NewWithT(t)
 
 	catalogue := hardware.NewCatalogue()
@@ -555,7 +1072,7 @@ func TestAssertionsForScaleUpDown_ExternalEtcdErrorFails(t *testing.T) {
 	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 
-	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("scale up/down not supported for external etcd")))
+	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
 func TestAssertionsForScaleUpDown_FailsScaleUpAndRollingError(t *testing.T) {
@@ -108,6 +108,8 @@ func NewClusterSpecValidator(assertions ...ClusterSpecAssertion) *ClusterSpecVal
 		AssertMachineConfigsValid,
 		AssertMachineConfigNamespaceMatchesDatacenterConfig,
 		AssertOsFamilyValid,
+		AssertMTUNotSetWithTemplateRefs,
+		AssertWorkerNodeGroupOSFamilyMatchesTemplate,
 		AssertTinkerbellIPAndControlPlaneIPNotSame,
 	)
 	v.Register(assertions...)
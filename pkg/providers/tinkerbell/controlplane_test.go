@@ -151,6 +151,8 @@ func TestControlPlaneSpecRegistryMirrorAuthentication(t *testing.T) {
 	t.Setenv("REGISTRY_USERNAME", "username")
 	t.Setenv("REGISTRY_PASSWORD", "password")
 	spec.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint:     "1.2.3.4",
+		Port:         "443",
 		Authenticate: true,
 	}
 	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
@@ -162,6 +164,18 @@ func TestControlPlaneSpecRegistryMirrorAuthentication(t *testing.T) {
 	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
 }
 
+func TestControlPlaneSpecMachineConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+	spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name = "missing"
+
+	_, err := ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellMachineConfig missing referenced by control plane not found")))
+}
+
 func tinkerbellCluster() *tinkerbellv1.TinkerbellCluster {
 	return &tinkerbellv1.TinkerbellCluster{
 		TypeMeta: metav1.TypeMeta{
@@ -369,9 +383,9 @@ spec:
         imageTag: v1.8.3-eks-1-21-4
       etcd:
         local:
-          imageRepository: public.ecr.aws/eks-distro/etcd-io
+          imageRepository: 1.2.3.4:443/eks-distro/etcd-io
           imageTag: v3.4.16-eks-1-21-4
-      imageRepository: public.ecr.aws/eks-distro/kubernetes
+      imageRepository: 1.2.3.4:443/eks-distro/kubernetes
       networking: {}
       pause: {}
       proxy: {}
@@ -470,8 +484,8 @@ spec:
     - content: |
         [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
           [plugins."io.containerd.grpc.v1.cri".registry.mirrors."public.ecr.aws"]
-            endpoint = ["https://:"]
-          [plugins."io.containerd.grpc.v1.cri".registry.configs.":".auth]
+            endpoint = ["https://1.2.3.4:443"]
+          [plugins."io.containerd.grpc.v1.cri".registry.configs."1.2.3.4:443".auth]
             username = "username"
             password = "password"
       owner: root:root
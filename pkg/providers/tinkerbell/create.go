@@ -38,6 +38,7 @@ func (p *Provider) PreCAPIInstallOnBootstrap(ctx context.Context, cluster *types
 		stack.WithHostPortEnabled(true), // enable host port on bootstrap cluster
 	)
 	if err != nil {
+		p.uninstallLocalBootsOnFailure(ctx)
 		return fmt.Errorf("install Tinkerbell stack on bootstrap cluster: %v", err)
 	}
 
@@ -45,7 +46,11 @@ func (p *Provider) PreCAPIInstallOnBootstrap(ctx context.Context, cluster *types
 }
 
 func (p *Provider) PostBootstrapSetup(ctx context.Context, clusterConfig *v1alpha1.Cluster, cluster *types.Cluster) error {
-	return p.applyHardware(ctx, cluster)
+	if err := p.applyHardware(ctx, cluster); err != nil {
+		p.uninstallLocalBootsOnFailure(ctx)
+		return err
+	}
+	return nil
 }
 
 // ApplyHardwareToCluster adds all the hardwares to the cluster.
@@ -59,7 +64,7 @@ func (p *Provider) applyHardware(ctx context.Context, cluster *types.Cluster) er
 		return fmt.Errorf("applying hardware yaml: %v", err)
 	}
 	if len(p.catalogue.AllBMCs()) > 0 {
-		err = p.providerKubectlClient.WaitForRufioMachines(ctx, cluster, "5m", "Contactable", constants.EksaSystemNamespace)
+		err = p.providerKubectlClient.WaitForRufioMachines(ctx, cluster, p.rufioContactableTimeout.String(), "Contactable", constants.EksaSystemNamespace)
 		if err != nil {
 			return fmt.Errorf("waiting for baseboard management to be contactable: %v", err)
 		}
@@ -88,6 +93,7 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 				!p.datacenterConfig.Spec.SkipLoadBalancerDeployment), // configure load balancer based on datacenterConfig.Spec.SkipLoadBalancerDeployment
 	)
 	if err != nil {
+		p.uninstallLocalBootsOnFailure(ctx)
 		return fmt.Errorf("installing stack on workload cluster: %v", err)
 	}
 
@@ -98,11 +104,19 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
-func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
-	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		return errExternalEtcdUnsupported
+// uninstallLocalBootsOnFailure removes the local boots container started by this create when one of its
+// steps fails and the provider is configured not to keep it for inspection.
+func (p *Provider) uninstallLocalBootsOnFailure(ctx context.Context) {
+	if p.keepLocalBootsOnFailure {
+		return
 	}
 
+	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
+		logger.Info("Warning: failed to remove the local boots container", "error", err)
+	}
+}
+
+func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	if err := p.stackInstaller.CleanupLocalBoots(ctx, p.forceCleanup); err != nil {
 		return err
 	}
@@ -113,6 +127,9 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 		return err
 	}
 	if p.hardwareCSVIsProvided() {
+		if err := validateHardwareCSV(p.hardwareCSVFile); err != nil {
+			return err
+		}
 		if err := p.readCSVToCatalogue(); err != nil {
 			return err
 		}
@@ -138,7 +155,7 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 			return fmt.Errorf("TinkerbellDatacenterConfig %s already exists", p.datacenterConfig.Name)
 		}
 
-		if err := p.getHardwareFromManagementCluster(ctx, clusterSpec.ManagementCluster); err != nil {
+		if err := p.getHardwareFromManagementCluster(ctx, clusterSpec.ManagementCluster, spec); err != nil {
 			return err
 		}
 
@@ -160,10 +177,16 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	clusterSpecValidator := NewClusterSpecValidator(
 		MinimumHardwareAvailableAssertionForCreate(p.catalogue),
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		AssertEndpointIPsNotAssignedToHardware(p.catalogue),
+		AssertHardwareDiskSizes(p.catalogue),
 	)
 
 	clusterSpecValidator.Register(AssertPortsNotInUse(p.netClient))
 
+	if p.osImageURLClient != nil {
+		clusterSpecValidator.Register(AssertOSImageURLReachable(p.osImageURLClient))
+	}
+
 	if !p.skipIpCheck {
 		clusterSpecValidator.Register(NewIPNotInUseAssertion(p.netClient))
 		if !p.clusterConfig.IsManaged() {
@@ -182,7 +205,7 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	return nil
 }
 
-func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster *types.Cluster) error {
+func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster *types.Cluster, spec *ClusterSpec) error {
 	// Retrieve all unprovisioned hardware from the management cluster and populate the catalogue so
 	// it can be considered for the workload creation.
 	hardware, err := p.providerKubectlClient.GetUnprovisionedTinkerbellHardware(
@@ -210,6 +233,15 @@ func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster
 		return fmt.Errorf("retrieving provisioned hardware: %v", err)
 	}
 
+	// Selecting hardware already provisioned by another cluster would steal machines from it.
+	selectors, err := selectorsFromClusterSpec(spec)
+	if err != nil {
+		return err
+	}
+	if err := validateSelectedHardwareNotProvisioned(p.catalogue.AllHardware(), hardware, selectors); err != nil {
+		return err
+	}
+
 	// Remove all the provisioned hardware from the existing cluster if repeated from the hardware csv input.
 	if err := p.catalogue.RemoveHardwares(hardware); err != nil {
 		return err
@@ -219,18 +251,41 @@ func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster
 }
 
 func (p *Provider) readCSVToCatalogue() error {
+	return p.readCSVInto(p.catalogue)
+}
+
+// readCSVInto reads the hardware CSV into catalogue.
+func (p *Provider) readCSVInto(catalogue *hardware.Catalogue) error {
 	// Create a catalogue writer used to write hardware to the catalogue.
-	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
+	catalogueWriter := hardware.NewMachineCatalogueWriter(catalogue)
 
-	machineValidator := hardware.NewDefaultMachineValidator()
+	files, err := hardwareCSVFiles(p.hardwareCSVFile)
+	if err != nil {
+		return err
+	}
+
+	// A single validator is shared by all the files so the default uniqueness assertions hold across them.
+	// The hardware ID, the MAC address of the machine, is checked before them so a duplicate is reported
+	// with the file it was already defined in.
+	var file string
+	seen := make(map[string]string)
+	machineValidator := &hardware.DefaultMachineValidator{}
+	machineValidator.Register(uniqueHardwareIDAcrossFiles(&file, seen))
+	hardware.RegisterDefaultAssertions(machineValidator)
 
 	// Translate all Machine instances from the p.machines source into Kubernetes object types.
 	// The PostBootstrapSetup() call invoked elsewhere in the program serializes the catalogue
 	// and submits it to the clsuter.
-	machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
-	if err != nil {
-		return err
+	for _, file = range files {
+		machines, err := hardware.NewNormalizedCSVReaderFromFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := hardware.TranslateAll(machines, catalogueWriter, machineValidator); err != nil {
+			return err
+		}
 	}
 
-	return hardware.TranslateAll(machines, catalogueWriter, machineValidator)
+	return nil
 }
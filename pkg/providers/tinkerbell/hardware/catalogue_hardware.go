@@ -3,6 +3,7 @@ package hardware
 import (
 	"fmt"
 	"math"
+	"strconv"
 
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -153,18 +154,43 @@ func (w *HardwareCatalogueWriter) Write(m Machine) error {
 	return w.catalogue.InsertHardware(hardwareFromMachine(m))
 }
 
+// DiskSizeGiBAnnotation is the Hardware annotation holding the capacity of its disk in GiB, when known.
+const DiskSizeGiBAnnotation = "anywhere.eks.amazonaws.com/disk-size-gib"
+
+// DiskSizeGiB returns the capacity of the disk of h in GiB. It returns false if h doesn't have
+// a valid disk size.
+func DiskSizeGiB(h *tinkv1alpha1.Hardware) (int, bool) {
+	value, ok := h.Annotations[DiskSizeGiBAnnotation]
+	if !ok {
+		return 0, false
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return size, true
+}
+
 func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 	// allow is necessary to allocate memory so we can get a bool pointer required by
 	// the hardware.
 	allow := true
 
+	var annotations map[string]string
+	if m.DiskSizeGiB > 0 {
+		annotations = map[string]string{DiskSizeGiBAnnotation: strconv.Itoa(m.DiskSizeGiB)}
+	}
+
 	// TODO(chrisdoherty4) Set the namespace to the CAPT namespace.
 	return &tinkv1alpha1.Hardware{
 		TypeMeta: newHardwareTypeMeta(),
 		ObjectMeta: v1.ObjectMeta{
-			Name:      m.Hostname,
-			Namespace: constants.EksaSystemNamespace,
-			Labels:    m.Labels,
+			Name:        m.Hostname,
+			Namespace:   constants.EksaSystemNamespace,
+			Labels:      m.Labels,
+			Annotations: annotations,
 		},
 		Spec: tinkv1alpha1.HardwareSpec{
 			BMCRef: newBMCRefFromMachine(m),
@@ -181,3 +181,18 @@ func TestHardwareCatalogueWriter_Write(t *testing.T) {
 	g.Expect(hardware).To(gomega.HaveLen(1))
 	g.Expect(hardware[0].Name).To(gomega.Equal(machine.Hostname))
 }
+
+func TestHardwareCatalogueWriter_WriteDiskSize(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewHardwareCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.DiskSizeGiB = 120
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	size, ok := hardware.DiskSizeGiB(catalogue.AllHardware()[0])
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(size).To(gomega.Equal(120))
+}
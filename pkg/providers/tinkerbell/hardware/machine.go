@@ -20,6 +20,10 @@ type Machine struct {
 	// is either: control plane hardware, external etcd hard, or the definable worker node groups.
 	Disk string `csv:"disk"`
 
+	// DiskSizeGiB is the capacity of Disk in GiB. It's optional and only used for validating the disk
+	// is big enough for the OS image.
+	DiskSizeGiB int `csv:"disk_size_gib, omitempty"`
+
 	// Labels to be applied to the Hardware resource.
 	Labels Labels `csv:"labels"`
 
@@ -0,0 +1,139 @@
+package tinkerbell
+
+import (
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// HardwareFitReport describes how the hardware catalogue fits a cluster spec.
+type HardwareFitReport struct {
+	// Requirements holds the hardware available and required by each hardware selector of the
+	// cluster, ordered by selector. Groups sharing a selector are combined into a single requirement.
+	Requirements []HardwareRequirementFit
+
+	// UnmatchedSelectors holds the names of the machine groups whose hardware selector doesn't match
+	// any hardware in the catalogue.
+	UnmatchedSelectors []string
+
+	// Errors holds the failures of the assertions run on create, like too few hardware for a
+	// selector, hardware matching several selectors or endpoint IPs assigned to hardware, and of the
+	// BMC checks, like hardware missing BMC credentials.
+	Errors []string
+}
+
+// HardwareRequirementFit holds the hardware available and required by a hardware selector.
+type HardwareRequirementFit struct {
+	Selector v1alpha1.HardwareSelector
+	// Groups holds the names of the machine groups using Selector.
+	Groups    []string
+	Available int
+	Required  int
+}
+
+// Fits returns true if all the assertions passed.
+func (r *HardwareFitReport) Fits() bool {
+	return len(r.Errors) == 0
+}
+
+// ValidateHardwareFitsSpec checks clusterSpec against the hardware catalogue without creating anything,
+// returning a report with the result of every check instead of stopping at the first failure.
+// It errors only when the checks can't be run, like when a machine config has no hardware selector.
+//
+// If the catalogue is empty the hardware CSV is read into a separate catalogue, leaving the provider
+// catalogue untouched.
+func (p *Provider) ValidateHardwareFitsSpec(clusterSpec *cluster.Spec) (*HardwareFitReport, error) {
+	catalogue := p.catalogue
+	if catalogue.TotalHardware() == 0 && p.hardwareCSVIsProvided() {
+		catalogue = hardware.NewCatalogue()
+		if err := p.readCSVInto(catalogue); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := NewClusterSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
+	if err := ensureHardwareSelectorsSpecified(spec); err != nil {
+		return nil, err
+	}
+
+	requirements, err := createHardwareRequirements(spec)
+	if err != nil {
+		return nil, err
+	}
+	countAvailableHardware(requirements, catalogue)
+
+	groups, err := hardwareSelectorGroups(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(requirements))
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := &HardwareFitReport{}
+	for _, name := range names {
+		r := requirements[name]
+		report.Requirements = append(report.Requirements, HardwareRequirementFit{
+			Selector:  r.Selector,
+			Groups:    groups[name],
+			Available: r.count,
+			Required:  r.MinCount,
+		})
+		if r.count == 0 {
+			report.UnmatchedSelectors = append(report.UnmatchedSelectors, groups[name]...)
+		}
+	}
+
+	assertions := []ClusterSpecAssertion{
+		MinimumHardwareAvailableAssertionForCreate(catalogue),
+		HardwareSatisfiesOnlyOneSelectorAssertion(catalogue),
+		AssertTinkerbellIPAndControlPlaneIPNotSame,
+		AssertEndpointIPsNotAssignedToHardware(catalogue),
+		AssertHardwareBMCsConfigured(catalogue),
+	}
+	for _, assert := range assertions {
+		if err := assert(spec); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+
+	return report, nil
+}
+
+// hardwareSelectorGroups returns the names of the machine groups of spec keyed by the string form of
+// their hardware selector, matching the keys of minimumHardwareRequirements.
+func hardwareSelectorGroups(spec *ClusterSpec) (map[string][]string, error) {
+	groups := map[string][]string{}
+	add := func(name string, selector v1alpha1.HardwareSelector) error {
+		key, err := selector.ToString()
+		if err != nil {
+			return err
+		}
+		groups[key] = append(groups[key], name)
+		return nil
+	}
+
+	if err := add(providers.GetControlPlaneNodeName(spec.Cluster.Name), spec.ControlPlaneMachineConfig().Spec.HardwareSelector); err != nil {
+		return nil, err
+	}
+
+	for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+		if err := add(nodeGroup.Name, spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.HasExternalEtcd() {
+		if err := add(providers.GetEtcdNodeName(spec.Cluster.Name), spec.ExternalEtcdMachineConfig().Spec.HardwareSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,94 @@
+package tinkerbell
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// PreviewHardwareSelection returns, for each machine group in clusterSpec, the IDs of the hardware in the
+// catalogue matching the group's hardware selector. Control plane and external etcd groups are keyed by
+// their node names and worker node groups by their names. It errors if the groups sharing a hardware
+// selector don't have enough matching hardware for their combined count.
+//
+// If the catalogue is empty the hardware CSV is read into a separate catalogue, leaving the provider
+// catalogue untouched.
+func (p *Provider) PreviewHardwareSelection(clusterSpec *cluster.Spec) (map[string][]string, error) {
+	catalogue := p.catalogue
+	if catalogue.TotalHardware() == 0 && p.hardwareCSVIsProvided() {
+		catalogue = hardware.NewCatalogue()
+		if err := p.readCSVInto(catalogue); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := NewClusterSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
+	if err := ensureHardwareSelectorsSpecified(spec); err != nil {
+		return nil, err
+	}
+
+	requirements, err := createHardwareRequirements(spec)
+	if err != nil {
+		return nil, err
+	}
+	countAvailableHardware(requirements, catalogue)
+
+	groups, err := hardwareSelectorGroups(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	selection := map[string][]string{}
+	preview := func(name string, selector v1alpha1.HardwareSelector) error {
+		key, err := selector.ToString()
+		if err != nil {
+			return err
+		}
+
+		r := requirements[key]
+		if r.count < r.MinCount {
+			return fmt.Errorf("not enough hardware for %s: have %d matching selector %v, require %d", strings.Join(groups[key], ", "), r.count, selector, r.MinCount)
+		}
+
+		ids := matchingHardwareIDs(catalogue, selector)
+		sort.Strings(ids)
+		selection[name] = ids
+
+		return nil
+	}
+
+	if err := preview(providers.GetControlPlaneNodeName(spec.Cluster.Name), spec.ControlPlaneMachineConfig().Spec.HardwareSelector); err != nil {
+		return nil, err
+	}
+
+	for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+		if err := preview(nodeGroup.Name, spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.HasExternalEtcd() {
+		if err := preview(providers.GetEtcdNodeName(spec.Cluster.Name), spec.ExternalEtcdMachineConfig().Spec.HardwareSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	return selection, nil
+}
+
+// matchingHardwareIDs returns the IDs of the hardware in catalogue matching selector.
+func matchingHardwareIDs(catalogue *hardware.Catalogue, selector v1alpha1.HardwareSelector) []string {
+	ids := []string{}
+	for _, h := range catalogue.AllHardware() {
+		if hardware.LabelsMatchSelector(selector, h.Labels) {
+			ids = append(ids, h.Spec.Metadata.Instance.ID)
+		}
+	}
+
+	return ids
+}
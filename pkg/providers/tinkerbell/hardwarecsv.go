@@ -0,0 +1,128 @@
+package tinkerbell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// HardwareCSVError is a problem found in a single row of a hardware CSV.
+type HardwareCSVError struct {
+	// Line is the line number of the row in the CSV, where the header is line 1.
+	Line int
+	Err  error
+}
+
+func (e HardwareCSVError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// ValidateHardwareCSV parses every row of the hardware CSV at path and returns an error for each
+// malformed row, instead of stopping at the first one. The returned error is only non-nil when the CSV
+// can't be read at all, for example when it doesn't exist or is missing required columns.
+func ValidateHardwareCSV(path string) ([]HardwareCSVError, error) {
+	machines, err := hardware.NewNormalizedCSVReaderFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := hardware.NewDefaultMachineValidator()
+
+	var csvErrs []HardwareCSVError
+	// The header takes the first line so the first machine is in line 2.
+	for line := 2; ; line++ {
+		machine, err := machines.Read()
+		if err == io.EOF {
+			return csvErrs, nil
+		}
+
+		if err != nil {
+			csvErrs = append(csvErrs, HardwareCSVError{Line: line, Err: fmt.Errorf("invalid hardware: %v", err)})
+			continue
+		}
+
+		if err := validator.Validate(machine); err != nil {
+			csvErrs = append(csvErrs, HardwareCSVError{Line: line, Err: err})
+		}
+	}
+}
+
+// hardwareCSVFiles returns the hardware CSV files found at path. When path is a directory, it
+// returns every *.csv file directly inside it, sorted by name. Otherwise it returns path itself.
+func hardwareCSVFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no hardware csv files found in directory %s", path)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// validateHardwareCSV validates the hardware CSV at path, or every hardware CSV in path when it's a
+// directory, returning a single error that lists all the malformed rows of the first invalid file.
+func validateHardwareCSV(path string) error {
+	files, err := hardwareCSVFiles(path)
+	if err != nil {
+		return fmt.Errorf("reading hardware csv %s: %v", path, err)
+	}
+
+	for _, file := range files {
+		if err := validateHardwareCSVFile(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateHardwareCSVFile(path string) error {
+	csvErrs, err := ValidateHardwareCSV(path)
+	if err != nil {
+		return fmt.Errorf("reading hardware csv %s: %v", path, err)
+	}
+
+	if len(csvErrs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(csvErrs))
+	for _, e := range csvErrs {
+		msgs = append(msgs, e.Error())
+	}
+
+	return fmt.Errorf("hardware csv %s has %d invalid rows: %s", path, len(csvErrs), strings.Join(msgs, "; "))
+}
+
+// uniqueHardwareIDAcrossFiles asserts a Machine read from the file pointed to by file has a hardware ID that
+// wasn't seen in any other hardware CSV file. seen maps every hardware ID written so far to the file it was read from.
+func uniqueHardwareIDAcrossFiles(file *string, seen map[string]string) hardware.MachineAssertion {
+	return func(m hardware.Machine) error {
+		if other, ok := seen[m.MACAddress]; ok && other != *file {
+			return fmt.Errorf("duplicate hardware id %s in %s: already defined in %s", m.MACAddress, *file, other)
+		}
+
+		seen[m.MACAddress] = *file
+
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+)
+
+func TestValidateHardwareCSVValid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	csvErrs, err := tinkerbell.ValidateHardwareCSV("testdata/hardware.csv")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(csvErrs).To(gomega.BeEmpty())
+}
+
+func TestValidateHardwareCSVReportsAllInvalidRows(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	csvErrs, err := tinkerbell.ValidateHardwareCSV("testdata/hardware_invalid_rows.csv")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(csvErrs).To(gomega.HaveLen(3))
+
+	g.Expect(csvErrs[0].Line).To(gomega.Equal(3))
+	g.Expect(csvErrs[0].Error()).To(gomega.ContainSubstring("BMCUsername"))
+
+	g.Expect(csvErrs[1].Line).To(gomega.Equal(4))
+	g.Expect(csvErrs[1].Error()).To(gomega.ContainSubstring("duplicate MACAddress: 00:00:00:00:00:01"))
+
+	g.Expect(csvErrs[2].Line).To(gomega.Equal(5))
+	g.Expect(csvErrs[2].Error()).To(gomega.ContainSubstring("IPAddress"))
+}
+
+func TestValidateHardwareCSVMissingFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := tinkerbell.ValidateHardwareCSV("testdata/does_not_exist.csv")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
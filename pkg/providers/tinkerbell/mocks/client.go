@@ -1,11 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,SSHAuthKeyGenerator)
+// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,SSHAuthKeyGenerator,HTTPHeadClient)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	http "net/http"
 	reflect "reflect"
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -428,3 +429,41 @@ func (mr *MockSSHAuthKeyGeneratorMockRecorder) GenerateSSHAuthKey(arg0 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSSHAuthKey", reflect.TypeOf((*MockSSHAuthKeyGenerator)(nil).GenerateSSHAuthKey), arg0)
 }
+
+// MockHTTPHeadClient is a mock of HTTPHeadClient interface.
+type MockHTTPHeadClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPHeadClientMockRecorder
+}
+
+// MockHTTPHeadClientMockRecorder is the mock recorder for MockHTTPHeadClient.
+type MockHTTPHeadClientMockRecorder struct {
+	mock *MockHTTPHeadClient
+}
+
+// NewMockHTTPHeadClient creates a new mock instance.
+func NewMockHTTPHeadClient(ctrl *gomock.Controller) *MockHTTPHeadClient {
+	mock := &MockHTTPHeadClient{ctrl: ctrl}
+	mock.recorder = &MockHTTPHeadClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPHeadClient) EXPECT() *MockHTTPHeadClientMockRecorder {
+	return m.recorder
+}
+
+// Head mocks base method.
+func (m *MockHTTPHeadClient) Head(arg0 string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Head", arg0)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Head indicates an expected call of Head.
+func (mr *MockHTTPHeadClientMockRecorder) Head(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Head", reflect.TypeOf((*MockHTTPHeadClient)(nil).Head), arg0)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -46,6 +47,8 @@ type TemplateBuilder struct {
 	etcdMachineSpec             *v1alpha1.TinkerbellMachineConfigSpec
 	tinkerbellIP                string
 	now                         types.NowFunc
+	// templateNameSuffix is appended to the machine and kubeadm config template names generated for create and upgrade.
+	templateNameSuffix common.TemplateNameSuffix
 }
 
 // NewTemplateBuilder creates a new TemplateBuilder instance.
@@ -57,6 +60,7 @@ func NewTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec,
 		etcdMachineSpec:             etcdMachineSpec,
 		tinkerbellIP:                tinkerbellIP,
 		now:                         now,
+		templateNameSuffix:          common.TimestampTemplateNameSuffix(now),
 	}
 }
 
@@ -64,7 +68,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 	cpTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.controlPlaneMachineSpec.TemplateRef.Name]
 	if cpTemplateConfig == nil {
 		versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
+		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, osImageURL(*tb.datacenterSpec, *tb.controlPlaneMachineSpec), tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily, tb.datacenterSpec.MTU)
 	}
 
 	cpTemplateString, err := cpTemplateConfig.ToTemplateString()
@@ -79,7 +83,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		etcdTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.etcdMachineSpec.TemplateRef.Name]
 		if etcdTemplateConfig == nil {
 			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
+			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, osImageURL(*tb.datacenterSpec, *tb.etcdMachineSpec), tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily, tb.datacenterSpec.MTU)
 		}
 		etcdTemplateString, err = etcdTemplateConfig.ToTemplateString()
 		if err != nil {
@@ -94,6 +98,10 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 	for _, buildOption := range buildOptions {
 		buildOption(values)
 	}
+
+	if err = common.ValidateDigestPinnedImages(values); err != nil {
+		return nil, err
+	}
 	bytes, err := templater.Execute(defaultCAPIConfigCP, values)
 	if err != nil {
 		return nil, err
@@ -101,14 +109,14 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 	return bytes, nil
 }
 
-func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
+func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	workerSpecs := make([][]byte, 0, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		workerNodeMachineSpec := tb.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name]
 		wTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[workerNodeMachineSpec.TemplateRef.Name]
 		if wTemplateConfig == nil {
 			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
+			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, osImageURL(*tb.datacenterSpec, workerNodeMachineSpec), tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily, tb.datacenterSpec.MTU)
 		}
 
 		wTemplateString, err := wTemplateConfig.ToTemplateString()
@@ -142,6 +150,18 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 			values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
 		}
 
+		if workerNodeGroupConfiguration.DeletePolicy != "" {
+			values["deletePolicy"] = workerNodeGroupConfiguration.DeletePolicy
+		}
+
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
+
+		if err = common.ValidateDigestPinnedImages(values); err != nil {
+			return nil, err
+		}
+
 		bytes, err := templater.Execute(defaultClusterConfigMD, values)
 		if err != nil {
 			return nil, err
@@ -152,6 +172,10 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 }
 
 func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
+	if err := validateSSHAuthorizedKeys(NewClusterSpec(newClusterSpec, p.machineConfigs, p.datacenterConfig)); err != nil {
+		return nil, nil, err
+	}
+
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
 	var needsNewEtcdTemplate bool
@@ -172,7 +196,7 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 		}
 		controlPlaneTemplateName = cp.Spec.MachineTemplate.InfrastructureRef.Name
 	} else {
-		controlPlaneTemplateName = common.CPMachineTemplateName(clusterName, p.templateBuilder.now)
+		controlPlaneTemplateName = common.CPMachineTemplateNameWithSuffix(clusterName, p.templateBuilder.templateNameSuffix)
 	}
 
 	previousWorkerNodeGroupConfigs := cluster.BuildMapForWorkerNodeGroupsByName(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations)
@@ -198,7 +222,7 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 			kubeadmconfigTemplateName = md.Spec.Template.Spec.Bootstrap.ConfigRef.Name
 			kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name] = kubeadmconfigTemplateName
 		} else {
-			kubeadmconfigTemplateName = common.KubeadmConfigTemplateName(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
+			kubeadmconfigTemplateName = common.KubeadmConfigTemplateNameWithSuffix(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.templateNameSuffix)
 			kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name] = kubeadmconfigTemplateName
 		}
 
@@ -211,22 +235,19 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 			workloadTemplateName = md.Spec.Template.Spec.InfrastructureRef.Name
 			workloadTemplateNames[workerNodeGroupConfiguration.Name] = workloadTemplateName
 		} else {
-			workloadTemplateName = common.WorkerMachineTemplateName(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
+			workloadTemplateName = common.WorkerMachineTemplateNameWithSuffix(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.templateNameSuffix)
 			workloadTemplateNames[workerNodeGroupConfiguration.Name] = workloadTemplateName
 		}
 		p.templateBuilder.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name] = p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name].Spec
 	}
 
-	// @TODO: upgrade of external etcd
 	if newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		// etcdMachineConfig := p.machineConfigs[newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
-		// etcdMachineTmc, err := p.providerKubectlClient.GetEksaTinkerbellMachineConfig(ctx, c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
-		// if err != nil {
-		//	return nil, nil, err
-		// }
-		// needsNewEtcdTemplate = NeedsNewEtcdTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, etcdMachineTmc, etcdMachineConfig)
-		/*** @TODO: hardcoding this to false, remove later *****/
-		needsNewEtcdTemplate = false
+		etcdMachineConfig := p.machineConfigs[newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
+		etcdMachineTmc, err := p.providerKubectlClient.GetEksaTinkerbellMachineConfig(ctx, c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		needsNewEtcdTemplate = NeedsNewEtcdTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, etcdMachineTmc, etcdMachineConfig)
 		if !needsNewEtcdTemplate {
 			etcdadmCluster, err := p.providerKubectlClient.GetEtcdadmCluster(ctx, workloadCluster, clusterName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 			if err != nil {
@@ -245,7 +266,7 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 			if err != nil {
 				return nil, nil, err
 			}
-			etcdTemplateName = common.EtcdMachineTemplateName(clusterName, p.templateBuilder.now)
+			etcdTemplateName = common.EtcdMachineTemplateNameWithSuffix(clusterName, p.templateBuilder.templateNameSuffix)
 		}
 	}
 
@@ -298,14 +319,18 @@ func (p *Provider) GenerateCAPISpecForCreate(ctx context.Context, _ *types.Clust
 }
 
 func (p *Provider) generateCAPISpecForCreate(ctx context.Context, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
+	if err := validateSSHAuthorizedKeys(NewClusterSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)); err != nil {
+		return nil, nil, err
+	}
+
 	clusterName := clusterSpec.Cluster.Name
 	cpOpt := func(values map[string]interface{}) {
-		values["controlPlaneTemplateName"] = common.CPMachineTemplateName(clusterName, p.templateBuilder.now)
+		values["controlPlaneTemplateName"] = common.CPMachineTemplateNameWithSuffix(clusterName, p.templateBuilder.templateNameSuffix)
 		values["controlPlaneSshAuthorizedKey"] = p.machineConfigs[p.clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Users[0].SshAuthorizedKeys[0]
 		if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 			values["etcdSshAuthorizedKey"] = p.machineConfigs[p.clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name].Spec.Users[0].SshAuthorizedKeys[0]
 		}
-		values["etcdTemplateName"] = common.EtcdMachineTemplateName(clusterName, p.templateBuilder.now)
+		values["etcdTemplateName"] = common.EtcdMachineTemplateNameWithSuffix(clusterName, p.templateBuilder.templateNameSuffix)
 	}
 	controlPlaneSpec, err = p.templateBuilder.GenerateCAPISpecControlPlane(clusterSpec, cpOpt)
 	if err != nil {
@@ -315,8 +340,8 @@ func (p *Provider) generateCAPISpecForCreate(ctx context.Context, clusterSpec *c
 	workloadTemplateNames := make(map[string]string, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	kubeadmconfigTemplateNames := make(map[string]string, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
-		workloadTemplateNames[workerNodeGroupConfiguration.Name] = common.WorkerMachineTemplateName(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
-		kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name] = common.KubeadmConfigTemplateName(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
+		workloadTemplateNames[workerNodeGroupConfiguration.Name] = common.WorkerMachineTemplateNameWithSuffix(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name, p.templateBuilder.templateNameSuffix)
+		kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name] = common.KubeadmConfigTemplateNameWithSuffix(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name, p.templateBuilder.templateNameSuffix)
 		p.templateBuilder.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name] = p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name].Spec
 	}
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(clusterSpec, workloadTemplateNames, kubeadmconfigTemplateNames)
@@ -327,8 +352,13 @@ func (p *Provider) generateCAPISpecForCreate(ctx context.Context, clusterSpec *c
 }
 
 func (p *Provider) needsNewMachineTemplate(ctx context.Context, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, vdc *v1alpha1.TinkerbellDatacenterConfig, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
-	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
-		return needsNewWorkloadTemplate(currentSpec, newClusterSpec), nil
+	if prevWorkerNodeGroupConfig, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
+		oldTmc, err := p.providerKubectlClient.GetEksaTinkerbellMachineConfig(ctx, prevWorkerNodeGroupConfig.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+		if err != nil {
+			return false, err
+		}
+		newTmc := p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]
+		return needsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, oldTmc, newTmc), nil
 	}
 	return true, nil
 }
@@ -366,10 +396,12 @@ func buildTemplateMapCP(
 	if clusterSpec.Cluster.Spec.KubernetesVersion == v1alpha1.Kube121 {
 		apiServerExtraArgs.Append(clusterapi.FeatureGatesExtraArgs("ServiceLoadBalancerClass=true"))
 	}
+	apiServerExtraArgs.AppendIfNotPresent(clusterapi.ClusterAPIServerExtraArgs(clusterSpec.Cluster))
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		AppendIfNotPresent(clusterapi.ControlPlaneKubeletExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
@@ -399,6 +431,8 @@ func buildTemplateMapCP(
 		"controlPlaneTaints":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 		"workerNodeGroupConfigurations": clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
 		"skipLoadBalancerDeployment":    datacenterSpec.SkipLoadBalancerDeployment,
+		"skipKubeVip":                   skipKubeVip(clusterSpec, datacenterSpec),
+		"capiObjectLabels":              clusterSpec.Cluster.Spec.CAPIObjectLabels,
 	}
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
@@ -412,15 +446,32 @@ func buildTemplateMapCP(
 		localRegistry := values["publicMirror"].(string)
 		cpTemplateOverride = strings.ReplaceAll(cpTemplateOverride, defaultRegistry, localRegistry)
 		etcdTemplateOverride = strings.ReplaceAll(etcdTemplateOverride, defaultRegistry, localRegistry)
+		// Pull the kubernetes and etcd images from their namespaced mirror instead of relying on the containerd mirror config.
+		registryMirror := registrymirror.FromCluster(clusterSpec.Cluster)
+		values["kubernetesRepository"] = registryMirror.ReplaceRegistry(bundle.KubeDistro.Kubernetes.Repository)
+		values["etcdRepository"] = registryMirror.ReplaceRegistry(bundle.KubeDistro.Etcd.Repository)
 	}
 
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
+		if err := validateProxyConfiguration(clusterSpec.Cluster.Spec.ProxyConfiguration); err != nil {
+			return nil, err
+		}
 		values["proxyConfig"] = true
 		values["httpProxy"] = clusterSpec.Cluster.Spec.ProxyConfiguration.HttpProxy
 		values["httpsProxy"] = clusterSpec.Cluster.Spec.ProxyConfiguration.HttpsProxy
 		values["noProxy"] = GenerateNoProxyList(clusterSpec.Cluster, datacenterSpec, tinkerbellIP)
 	}
 
+	if coreDNS := clusterSpec.Cluster.Spec.CoreDNSConfiguration; coreDNS != nil {
+		if controlPlaneMachineSpec.OSFamily == v1alpha1.Bottlerocket {
+			return nil, fmt.Errorf("coreDNSConfiguration is not supported for %s", v1alpha1.Bottlerocket)
+		}
+		if coreDNS.Replicas != nil {
+			values["corednsReplicas"] = *coreDNS.Replicas
+		}
+		values["corednsPodAntiAffinity"] = coreDNS.PodAntiAffinity
+	}
+
 	values["controlPlanetemplateOverride"] = cpTemplateOverride
 
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -433,7 +484,8 @@ func buildTemplateMapCP(
 
 	if controlPlaneMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
-		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
+		// Point the pause image at the mirror directly so air-gapped nodes never pull it from the public registry.
+		values["pauseRepository"] = registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(bundle.KubeDistro.Pause.Image())
 		values["pauseVersion"] = bundle.KubeDistro.Pause.Tag()
 		values["bottlerocketBootstrapRepository"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
@@ -448,6 +500,10 @@ func buildTemplateMapCP(
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
 		}
 
+		if len(controlPlaneMachineSpec.HostOSConfiguration.RunCmd) > 0 {
+			values["cpRunCmd"] = controlPlaneMachineSpec.HostOSConfiguration.RunCmd
+		}
+
 		brSettings, err := common.GetCAPIBottlerocketSettingsConfig(controlPlaneMachineSpec.HostOSConfiguration.BottlerocketConfiguration)
 		if err != nil {
 			return nil, err
@@ -471,7 +527,8 @@ func buildTemplateMapMD(
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		AppendIfNotPresent(clusterapi.WorkerKubeletExtraArgs(workerNodeGroupConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
@@ -484,11 +541,12 @@ func buildTemplateMapMD(
 		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
 		"hardwareSelector":       workerNodeGroupMachineSpec.HardwareSelector,
 		"workerNodeGroupTaints":  workerNodeGroupConfiguration.Taints,
+		"capiObjectLabels":       clusterSpec.Cluster.Spec.CAPIObjectLabels,
 	}
 
 	if workerNodeGroupMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
-		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
+		values["pauseRepository"] = registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(bundle.KubeDistro.Pause.Image())
 		values["pauseVersion"] = bundle.KubeDistro.Pause.Tag()
 		values["bottlerocketBootstrapRepository"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
@@ -502,6 +560,9 @@ func buildTemplateMapMD(
 	}
 
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
+		if err := validateProxyConfiguration(clusterSpec.Cluster.Spec.ProxyConfiguration); err != nil {
+			return nil, err
+		}
 		values["proxyConfig"] = true
 		values["httpProxy"] = clusterSpec.Cluster.Spec.ProxyConfiguration.HttpProxy
 		values["httpsProxy"] = clusterSpec.Cluster.Spec.ProxyConfiguration.HttpsProxy
@@ -515,6 +576,10 @@ func buildTemplateMapMD(
 			values["ntpServers"] = workerNodeGroupMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
 		}
 
+		if len(workerNodeGroupMachineSpec.HostOSConfiguration.RunCmd) > 0 {
+			values["runCmd"] = workerNodeGroupMachineSpec.HostOSConfiguration.RunCmd
+		}
+
 		brSettings, err := common.GetCAPIBottlerocketSettingsConfig(workerNodeGroupMachineSpec.HostOSConfiguration.BottlerocketConfiguration)
 		if err != nil {
 			return nil, err
@@ -617,6 +682,10 @@ func getEtcdMachineSpec(clusterSpec *cluster.Spec) (*v1alpha1.TinkerbellMachineC
 }
 
 func generateTemplateBuilder(clusterSpec *cluster.Spec) (providers.TemplateBuilder, error) {
+	if err := validateMachineGroupRefsExist(clusterSpec); err != nil {
+		return nil, err
+	}
+
 	controlPlaneMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating control plane machine spec")
@@ -642,7 +711,73 @@ func generateTemplateBuilder(clusterSpec *cluster.Spec) (providers.TemplateBuild
 	return templateBuilder, nil
 }
 
+// validateMachineGroupRefsExist ensures the machine group refs of the control plane, the worker node groups
+// and the external etcd point at machine configs in clusterSpec, as the machine specs are missing from the
+// templates otherwise.
+func validateMachineGroupRefsExist(clusterSpec *cluster.Spec) error {
+	if err := validateMachineGroupRefExists(clusterSpec, "control plane", clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef); err != nil {
+		return err
+	}
+
+	for _, wnConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if err := validateMachineGroupRefExists(clusterSpec, "worker node group "+wnConfig.Name, wnConfig.MachineGroupRef); err != nil {
+			return err
+		}
+	}
+
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		return validateMachineGroupRefExists(clusterSpec, "external etcd", clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef)
+	}
+
+	return nil
+}
+
+func validateMachineGroupRefExists(clusterSpec *cluster.Spec, owner string, ref *v1alpha1.Ref) error {
+	if ref == nil {
+		return nil
+	}
+
+	if clusterSpec.TinkerbellMachineConfigs[ref.Name] == nil {
+		return fmt.Errorf("TinkerbellMachineConfig %s referenced by %s not found", ref.Name, owner)
+	}
+
+	return nil
+}
+
+// validateProxyConfiguration checks that the http and https proxies are well-formed URLs including a scheme.
+// containerd and kubelet silently ignore proxies that can't be parsed as URLs.
+func validateProxyConfiguration(proxyConfig *v1alpha1.ProxyConfiguration) error {
+	if err := validateProxyURL("httpProxy", proxyConfig.HttpProxy); err != nil {
+		return err
+	}
+	return validateProxyURL("httpsProxy", proxyConfig.HttpsProxy)
+}
+
+func validateProxyURL(name, proxy string) error {
+	if !strings.Contains(proxy, "://") {
+		return fmt.Errorf("%s %s is missing a scheme, please provide a URL such as http://%s", name, proxy, proxy)
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("%s %s is not a valid URL: %v", name, proxy, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s %s is not a valid URL: missing host", name, proxy)
+	}
+	return nil
+}
+
+// osImageURL returns the OS image URL for machines using machineSpec. The machine config OSImageURL
+// takes precedence over the datacenter one.
+func osImageURL(datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec, machineSpec v1alpha1.TinkerbellMachineConfigSpec) string {
+	if machineSpec.OSImageURL != "" {
+		return machineSpec.OSImageURL
+	}
+	return datacenterSpec.OSImageURL
+}
+
 // GenerateNoProxyList generates NOPROXY list for tinkerbell provider based on HTTP_PROXY, HTTPS_PROXY, NOPROXY and tinkerbellIP.
+// Duplicated entries are removed, keeping the first occurrence of each so the order is deterministic.
 func GenerateNoProxyList(clusterSpec *v1alpha1.Cluster, datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec, tinkerbellIP string) []string {
 	capacity := len(clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks) +
 		len(clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks) +
@@ -660,5 +795,35 @@ func GenerateNoProxyList(clusterSpec *v1alpha1.Cluster, datacenterSpec v1alpha1.
 		tinkerbellIP,
 	)
 
-	return noProxyList
+	return removeDuplicates(noProxyList)
+}
+
+// removeDuplicates returns a copy of list without duplicated elements, preserving the order of
+// their first occurrence.
+func removeDuplicates(list []string) []string {
+	seen := make(map[string]struct{}, len(list))
+	unique := make([]string, 0, len(list))
+	for _, e := range list {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		unique = append(unique, e)
+	}
+	return unique
+}
+
+// kubeVipManifestPath is the path of the kube-vip static pod manifest the control plane template writes.
+const kubeVipManifestPath = "/etc/kubernetes/manifests/kube-vip.yaml"
+
+// skipKubeVip returns true when the control plane of a multi-node cluster is fronted by an external load
+// balancer, which is the case when the load balancer deployment is skipped. Single-node clusters keep
+// kube-vip for the control plane endpoint.
+func skipKubeVip(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec) bool {
+	if !datacenterSpec.SkipLoadBalancerDeployment {
+		return false
+	}
+	singleNode := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count == 1 &&
+		len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations) == 0
+	return !singleNode
 }
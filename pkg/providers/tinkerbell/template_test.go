@@ -2,11 +2,14 @@ package tinkerbell
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 )
 
 func TestGenerateTemplateBuilder(t *testing.T) {
@@ -56,3 +59,289 @@ func TestGenerateTemplateBuilder(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(gotEtcdMachineSpec).To(Equal(expectedEtcdMachineSpec))
 }
+
+func TestValidateProxyConfiguration(t *testing.T) {
+	tests := []struct {
+		name        string
+		proxyConfig *v1alpha1.ProxyConfiguration
+		wantErr     string
+	}{
+		{
+			name: "valid proxies",
+			proxyConfig: &v1alpha1.ProxyConfiguration{
+				HttpProxy:  "http://1.1.1.1:8080",
+				HttpsProxy: "https://proxy.example.com:8443",
+			},
+		},
+		{
+			name: "http proxy missing scheme",
+			proxyConfig: &v1alpha1.ProxyConfiguration{
+				HttpProxy:  "1.1.1.1:8080",
+				HttpsProxy: "http://1.1.1.1:8443",
+			},
+			wantErr: "httpProxy 1.1.1.1:8080 is missing a scheme, please provide a URL such as http://1.1.1.1:8080",
+		},
+		{
+			name: "https proxy missing scheme",
+			proxyConfig: &v1alpha1.ProxyConfiguration{
+				HttpProxy:  "http://1.1.1.1:8080",
+				HttpsProxy: "proxy.example.com:8443",
+			},
+			wantErr: "httpsProxy proxy.example.com:8443 is missing a scheme",
+		},
+		{
+			name: "proxy missing host",
+			proxyConfig: &v1alpha1.ProxyConfiguration{
+				HttpProxy:  "http://",
+				HttpsProxy: "http://1.1.1.1:8443",
+			},
+			wantErr: "httpProxy http:// is not a valid URL: missing host",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateProxyConfiguration(tt.proxyConfig)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+const testImageDigest = "@sha256:6ac32ff6c7a33f1d2d6a87f7b5e05bf0a0b3c0d9a1b9cb4e5d1a4bd1f7e8f9a0"
+
+// pinImages pins by digest all the images the default templates reference.
+func pinImages(bundle *cluster.VersionsBundle) {
+	bundle.Tinkerbell.KubeVip.URI = bundle.Tinkerbell.KubeVip.Image() + testImageDigest
+	bundle.KubeDistro.Pause.URI = bundle.KubeDistro.Pause.Image() + testImageDigest
+	bundle.BottleRocketHostContainers.KubeadmBootstrap.URI = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image() + testImageDigest
+	bundle.KubeDistro.Kubernetes.Tag += testImageDigest
+	bundle.KubeDistro.Etcd.Tag += testImageDigest
+	bundle.KubeDistro.CoreDNS.Tag += testImageDigest
+}
+
+func TestTemplateBuilderGenerateCAPISpecControlPlaneRequireDigestPinnedImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		unpin   func(bundle *cluster.VersionsBundle)
+		wantErr string
+	}{
+		{
+			name: "tag only image",
+			unpin: func(bundle *cluster.VersionsBundle) {
+				bundle.Tinkerbell.KubeVip.URI = "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.581"
+			},
+			wantErr: "images must be pinned by digest: public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.581",
+		},
+		{
+			name: "tag only kubeadm images",
+			unpin: func(bundle *cluster.VersionsBundle) {
+				bundle.KubeDistro.Kubernetes.Tag = "v1.21.2-eks-1-21-4"
+				bundle.KubeDistro.Etcd.Tag = "v3.4.16-eks-1-21-4"
+			},
+			wantErr: "images must be pinned by digest: public.ecr.aws/eks-distro/etcd-io:v3.4.16-eks-1-21-4, public.ecr.aws/eks-distro/kubernetes:v1.21.2-eks-1-21-4",
+		},
+		{
+			name:  "digest pinned images",
+			unpin: func(bundle *cluster.VersionsBundle) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+			pinImages(clusterSpec.VersionsBundle)
+			tt.unpin(clusterSpec.VersionsBundle)
+
+			cpMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
+			g.Expect(err).NotTo(HaveOccurred())
+			workerMachineSpecs, err := getWorkerNodeGroupMachineSpec(clusterSpec)
+			g.Expect(err).NotTo(HaveOccurred())
+			builder := NewTemplateBuilder(&clusterSpec.TinkerbellDatacenter.Spec, cpMachineSpec, nil, workerMachineSpecs, "1.2.3.4", time.Now)
+
+			_, err = builder.GenerateCAPISpecControlPlane(clusterSpec, common.RequireDigestPinnedImages)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestTemplateBuilderGenerateCAPISpecWorkersRequireDigestPinnedImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		unpin   func(bundle *cluster.VersionsBundle)
+		wantErr string
+	}{
+		{
+			name: "tag only pause image",
+			unpin: func(bundle *cluster.VersionsBundle) {
+				bundle.KubeDistro.Pause.URI = "public.ecr.aws/eks-distro/kubernetes/pause:v1.21.2-eks-1-21-4"
+			},
+			wantErr: "images must be pinned by digest: public.ecr.aws/eks-distro/kubernetes/pause:v1.21.2-eks-1-21-4",
+		},
+		{
+			name:  "digest pinned images",
+			unpin: func(bundle *cluster.VersionsBundle) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+			pinImages(clusterSpec.VersionsBundle)
+			tt.unpin(clusterSpec.VersionsBundle)
+
+			cpMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
+			g.Expect(err).NotTo(HaveOccurred())
+			workerMachineSpecs, err := getWorkerNodeGroupMachineSpec(clusterSpec)
+			g.Expect(err).NotTo(HaveOccurred())
+			workloadTemplateNames := map[string]string{}
+			kubeadmconfigTemplateNames := map[string]string{}
+			for name, spec := range workerMachineSpecs {
+				spec.OSFamily = v1alpha1.Bottlerocket
+				workerMachineSpecs[name] = spec
+			}
+			for _, group := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+				workloadTemplateNames[group.Name] = group.Name + "-template"
+				kubeadmconfigTemplateNames[group.Name] = group.Name + "-kubeadm-template"
+			}
+			builder := NewTemplateBuilder(&clusterSpec.TinkerbellDatacenter.Spec, cpMachineSpec, nil, workerMachineSpecs, "1.2.3.4", time.Now)
+
+			_, err = builder.GenerateCAPISpecWorkers(clusterSpec, workloadTemplateNames, kubeadmconfigTemplateNames, common.RequireDigestPinnedImages)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestTemplateBuilderGenerateCAPISpecControlPlaneDualStack(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+	clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:100:64::/48"}
+	clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:100:96::/108"}
+	clusterSpec.Cluster.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
+		HttpProxy:  "http://1.1.1.1:8080",
+		HttpsProxy: "http://1.1.1.1:8080",
+	}
+
+	cpMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	workerMachineSpecs, err := getWorkerNodeGroupMachineSpec(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	builder := NewTemplateBuilder(&clusterSpec.TinkerbellDatacenter.Spec, cpMachineSpec, nil, workerMachineSpecs, "1.2.3.4", test.FakeNow)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_dual_stack_cp.yaml")
+}
+
+func TestNeedsNewKubeadmConfigTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldGroup v1alpha1.WorkerNodeGroupConfiguration
+		newGroup v1alpha1.WorkerNodeGroupConfiguration
+		want     bool
+	}{
+		{
+			name:     "no changes",
+			oldGroup: v1alpha1.WorkerNodeGroupConfiguration{KubeletExtraArgs: map[string]string{"kube-reserved": "cpu=1"}},
+			newGroup: v1alpha1.WorkerNodeGroupConfiguration{KubeletExtraArgs: map[string]string{"kube-reserved": "cpu=1"}},
+			want:     false,
+		},
+		{
+			name:     "labels changed",
+			oldGroup: v1alpha1.WorkerNodeGroupConfiguration{Labels: map[string]string{"pool": "md-0"}},
+			newGroup: v1alpha1.WorkerNodeGroupConfiguration{Labels: map[string]string{"pool": "md-1"}},
+			want:     true,
+		},
+		{
+			name:     "kubelet extra args changed",
+			oldGroup: v1alpha1.WorkerNodeGroupConfiguration{KubeletExtraArgs: map[string]string{"kube-reserved": "cpu=1"}},
+			newGroup: v1alpha1.WorkerNodeGroupConfiguration{KubeletExtraArgs: map[string]string{"kube-reserved": "cpu=2"}},
+			want:     true,
+		},
+		{
+			name:     "kubelet extra args added",
+			oldGroup: v1alpha1.WorkerNodeGroupConfiguration{},
+			newGroup: v1alpha1.WorkerNodeGroupConfiguration{KubeletExtraArgs: map[string]string{"system-reserved": "memory=1Gi"}},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(needsNewKubeadmConfigTemplate(&tt.newGroup, &tt.oldGroup)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNeedsNewWorkloadAndEtcdTemplateOSImageURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		oldTdc v1alpha1.TinkerbellDatacenterConfigSpec
+		newTdc v1alpha1.TinkerbellDatacenterConfigSpec
+		oldTmc v1alpha1.TinkerbellMachineConfigSpec
+		newTmc v1alpha1.TinkerbellMachineConfigSpec
+		want   bool
+	}{
+		{
+			name:   "no changes",
+			oldTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			oldTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			newTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			want:   false,
+		},
+		{
+			name:   "datacenter url changed",
+			oldTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu-new.gz"},
+			want:   true,
+		},
+		{
+			name:   "datacenter url changed but overridden by machine config",
+			oldTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu-new.gz"},
+			oldTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			newTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			want:   false,
+		},
+		{
+			name:   "machine config url changed",
+			oldTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			oldTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			newTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker-new.gz"},
+			want:   true,
+		},
+		{
+			name:   "machine config url added",
+			oldTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTdc: v1alpha1.TinkerbellDatacenterConfigSpec{OSImageURL: "http://example.com/ubuntu.gz"},
+			newTmc: v1alpha1.TinkerbellMachineConfigSpec{OSImageURL: "http://example.com/worker.gz"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec()
+			oldTdc := &v1alpha1.TinkerbellDatacenterConfig{Spec: tt.oldTdc}
+			newTdc := &v1alpha1.TinkerbellDatacenterConfig{Spec: tt.newTdc}
+			oldTmc := &v1alpha1.TinkerbellMachineConfig{Spec: tt.oldTmc}
+			newTmc := &v1alpha1.TinkerbellMachineConfig{Spec: tt.newTmc}
+
+			g.Expect(needsNewWorkloadTemplate(spec, spec, oldTdc, newTdc, oldTmc, newTmc)).To(Equal(tt.want))
+			g.Expect(NeedsNewEtcdTemplate(spec, spec, oldTdc, newTdc, oldTmc, newTmc)).To(Equal(tt.want))
+		})
+	}
+}
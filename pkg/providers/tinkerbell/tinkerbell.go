@@ -2,8 +2,8 @@ package tinkerbell
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -32,16 +32,15 @@ import (
 const (
 	maxRetries    = 30
 	backOffPeriod = 5 * time.Second
+
+	// DefaultRufioContactableTimeout is the default time to wait for the Rufio machines to be contactable.
+	DefaultRufioContactableTimeout = 5 * time.Minute
 )
 
 var (
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	tinkerbellStackPorts                 = []int{42113, 50051, 50061}
-
-	// errExternalEtcdUnsupported is returned from create or update when the user attempts to create
-	// or upgrade a cluster with an external etcd configuration.
-	errExternalEtcdUnsupported = errors.New("external etcd configuration is unsupported")
 )
 
 type Provider struct {
@@ -54,6 +53,7 @@ type Provider struct {
 	writer                filewriter.FileWriter
 	keyGenerator          SSHAuthKeyGenerator
 
+	// hardwareCSVFile is the path to a hardware CSV file or to a directory of hardware CSV files.
 	hardwareCSVFile string
 	catalogue       *hardware.Catalogue
 	tinkerbellIP    string
@@ -63,9 +63,50 @@ type Provider struct {
 	// constructor call for constructing the validator in-line.
 	netClient networkutils.NetClient
 
+	// osImageURLClient is used to check the OS image URLs are reachable during create. The check
+	// is skipped when nil.
+	osImageURLClient HTTPHeadClient
+
 	forceCleanup bool
 	skipIpCheck  bool
 	retrier      *retrier.Retrier
+
+	// keepLocalBootsOnFailure keeps the local boots started by a create when one of its steps fails.
+	// It defaults to true.
+	keepLocalBootsOnFailure bool
+
+	// rufioContactableTimeout is how long to wait for the Rufio machines to be contactable.
+	rufioContactableTimeout time.Duration
+}
+
+// ProviderOpt configures a Provider.
+type ProviderOpt func(*Provider)
+
+// WithRufioContactableTimeout sets how long the provider waits for the Rufio machines to be
+// contactable after applying the hardware. It defaults to 5 minutes.
+func WithRufioContactableTimeout(timeout time.Duration) ProviderOpt {
+	return func(p *Provider) {
+		p.rufioContactableTimeout = timeout
+	}
+}
+
+// WithDeterministicTemplateNames makes the machine and kubeadm config template names generated for create
+// and upgrade end with generation instead of a timestamp, so rendering the same spec with the same generation
+// always produces the same manifests. Callers must bump generation whenever the templates change, since new
+// template names are what trigger CAPI rollouts.
+func WithDeterministicTemplateNames(generation int) ProviderOpt {
+	return func(p *Provider) {
+		p.templateBuilder.templateNameSuffix = common.GenerationTemplateNameSuffix(generation)
+	}
+}
+
+// WithKeepLocalBootsOnFailure sets whether the local boots container is kept running when a create fails
+// after starting it, so its logs are still available for inspection. It defaults to true; passing false
+// removes the container as soon as the create fails.
+func WithKeepLocalBootsOnFailure(keep bool) ProviderOpt {
+	return func(p *Provider) {
+		p.keepLocalBootsOnFailure = keep
+	}
 }
 
 type ProviderKubectlClient interface {
@@ -98,6 +139,13 @@ type SSHAuthKeyGenerator interface {
 	GenerateSSHAuthKey(filewriter.FileWriter) (string, error)
 }
 
+// HTTPHeadClient issues HTTP HEAD requests. It's satisfied by *http.Client.
+type HTTPHeadClient interface {
+	Head(url string) (*http.Response, error)
+}
+
+// NewProvider creates a Tinkerbell provider. hardwareCSVPath can be a single hardware CSV file or a
+// directory, in which case every *.csv file in it is read.
 func NewProvider(
 	datacenterConfig *v1alpha1.TinkerbellDatacenterConfig,
 	machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig,
@@ -111,6 +159,7 @@ func NewProvider(
 	now types.NowFunc,
 	forceCleanup bool,
 	skipIpCheck bool,
+	opts ...ProviderOpt,
 ) (*Provider, error) {
 	var controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
@@ -140,7 +189,7 @@ func NewProvider(
 		proxyConfig = nil
 	}
 
-	return &Provider{
+	p := &Provider{
 		clusterConfig:         clusterConfig,
 		datacenterConfig:      datacenterConfig,
 		machineConfigs:        machineConfigs,
@@ -153,6 +202,7 @@ func NewProvider(
 			etcdMachineSpec:             etcdMachineSpec,
 			tinkerbellIP:                tinkerbellIP,
 			now:                         now,
+			templateNameSuffix:          common.TimestampTemplateNameSuffix(now),
 		},
 		writer:          writer,
 		hardwareCSVFile: hardwareCSVPath,
@@ -172,9 +222,28 @@ func NewProvider(
 		// directly. This is very much a hack for testability.
 		keyGenerator: common.SshAuthKeyGenerator{},
 		// Behavioral flags.
-		forceCleanup: forceCleanup,
-		skipIpCheck:  skipIpCheck,
-	}, nil
+		forceCleanup:            forceCleanup,
+		skipIpCheck:             skipIpCheck,
+		keepLocalBootsOnFailure: true,
+		rufioContactableTimeout: DefaultRufioContactableTimeout,
+	}
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p, nil
+}
+
+// EnableOSImageURLCheck opts in to validating that the OS image URL of every machine is reachable
+// during create, using client to issue a HEAD request.
+func (p *Provider) EnableOSImageURLCheck(client HTTPHeadClient) {
+	p.osImageURLClient = client
+}
+
+// NoProxyList returns the NO_PROXY list the provider configures on the cluster machines for clusterSpec.
+func (p *Provider) NoProxyList(clusterSpec *cluster.Spec) []string {
+	return GenerateNoProxyList(clusterSpec.Cluster, p.datacenterConfig.Spec, p.tinkerbellIP)
 }
 
 func (p *Provider) Name() string {
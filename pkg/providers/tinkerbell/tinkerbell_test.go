@@ -6,11 +6,19 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -18,6 +26,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	stackmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack/mocks"
@@ -60,7 +69,7 @@ func assertError(t *testing.T, expected string, err error) {
 	}
 }
 
-func newProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig, clusterConfig *v1alpha1.Cluster, writer filewriter.FileWriter, docker stack.Docker, helm stack.Helm, kubectl ProviderKubectlClient, forceCleanup bool) *Provider {
+func newProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig, clusterConfig *v1alpha1.Cluster, writer filewriter.FileWriter, docker stack.Docker, helm stack.Helm, kubectl ProviderKubectlClient, forceCleanup bool, opts ...ProviderOpt) *Provider {
 	hardwareFile := "./testdata/hardware.csv"
 	provider, err := NewProvider(
 		datacenterConfig,
@@ -75,6 +84,7 @@ func newProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 		test.FakeNow,
 		forceCleanup,
 		false,
+		opts...,
 	)
 	if err != nil {
 		panic(err)
@@ -84,7 +94,6 @@ func newProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 }
 
 func TestTinkerbellProviderGenerateDeploymentFileWithExternalEtcd(t *testing.T) {
-	t.Skip("External etcd unsupported for GA")
 	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
@@ -118,15 +127,164 @@ func TestTinkerbellProviderGenerateDeploymentFileWithExternalEtcd(t *testing.T)
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
 }
 
-func TestTinkerbellProviderWithExternalEtcdFail(t *testing.T) {
+func TestTinkerbellProviderGenerateCAPISpecForUpgradeUpdateMachineTemplateExternalEtcd(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "kubeconfig-file"}
+	bootstrapCluster := &types.Cluster{Name: "bootstrap-test"}
+	forceCleanup := false
+
+	currentClusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name).Return(currentClusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
+	kubectl.EXPECT().GetMachineDeployment(ctx, "test-md-0", gomock.Any(), gomock.Any()).Return(workerMachineDeployment("test-md-0-template-1234567890000"), nil)
+	kubectl.EXPECT().GetEksaTinkerbellMachineConfig(ctx, "test-md", cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(machineConfigs["test-md"], nil)
+	kubectl.EXPECT().GetEksaTinkerbellMachineConfig(ctx, "test-etcd", cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(machineConfigs["test-etcd"], nil)
+	kubectl.EXPECT().UpdateAnnotation(ctx, "etcdadmcluster", "test-etcd", map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"}, gomock.Any(), gomock.Any())
+
+	cp, md, err := provider.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentClusterSpec, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_external_etcd_upgrade.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_external_etcd_upgrade.yaml")
+}
+
+func TestTinkerbellProviderGenerateCAPISpecForUpgradeNotUpdateMachineTemplateExternalEtcd(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "kubeconfig-file"}
+	bootstrapCluster := &types.Cluster{Name: "bootstrap-test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+
+	oldCP := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					Name: "test-control-plane-template-original",
+				},
+			},
+		},
+	}
+	oldEtcdCluster := &etcdv1.EtcdadmCluster{
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: corev1.ObjectReference{
+				Name: "test-etcd-template-original",
+			},
+		},
+	}
+
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
+	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, gomock.Any(), gomock.Any()).Return(oldCP, nil)
+	kubectl.EXPECT().GetMachineDeployment(ctx, "test-md-0", gomock.Any(), gomock.Any()).Return(workerMachineDeployment("test-md-0-original"), nil).Times(2)
+	kubectl.EXPECT().GetEksaTinkerbellMachineConfig(ctx, "test-md", cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(machineConfigs["test-md"], nil)
+	kubectl.EXPECT().GetEksaTinkerbellMachineConfig(ctx, "test-etcd", cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(machineConfigs["test-etcd"], nil)
+	kubectl.EXPECT().GetEtcdadmCluster(ctx, cluster, clusterSpec.Cluster.Name, gomock.Any(), gomock.Any()).Return(oldEtcdCluster, nil)
+
+	cp, md, err := provider.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_external_etcd_no_machinetemplate_update.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_no_machinetemplate_update.yaml")
+}
+
+func workerMachineDeployment(templateName string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							Name: templateName,
+						},
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						Name: templateName,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReadCSVToCatalogueFromDirectory(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.hardwareCSVFile = "./testdata/hardware_dir"
+
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv directory: %v", err)
+	}
+	assert.Equal(t, 4, provider.catalogue.TotalHardware())
+	assert.Equal(t, 4, provider.catalogue.TotalBMCs())
+}
+
+func TestReadCSVToCatalogueDirectoryDuplicateIPAcrossFiles(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.hardwareCSVFile = "./testdata/hardware_dir_duplicate_ip"
+
+	err := provider.readCSVToCatalogue()
+	assertError(t, "duplicate IPAddress: 10.10.10.11", err)
+}
+
+func TestSetupAndValidateCreateClusterHardwareCSVDirectoryDuplicateID(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -136,12 +294,43 @@ func TestTinkerbellProviderWithExternalEtcdFail(t *testing.T) {
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_dir_duplicate_id"
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
 	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assert.Error(t, err, "expect validation to fail because external etcd is not supported")
+	assertError(t, "duplicate hardware id 00:00:00:00:00:02 in testdata/hardware_dir_duplicate_id/rack2.csv: already defined in testdata/hardware_dir_duplicate_id/rack1.csv", err)
+}
+
+func TestSetupAndValidateCreateClusterInvalidHardwareCSVRows(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_invalid_rows.csv"
 
-	err = provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
-	assert.Error(t, err, "expect validation to fail because external etcd is not supported")
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err == nil {
+		t.Fatal("expected validation to fail because of invalid hardware csv rows")
+	}
+	assert.Contains(t, err.Error(), "hardware csv ./testdata/hardware_invalid_rows.csv has 3 invalid rows")
+	assert.Contains(t, err.Error(), "line 3: ")
+	assert.Contains(t, err.Error(), "line 4: duplicate MACAddress")
+	assert.Contains(t, err.Error(), "line 5: ")
 }
 
 func TestTinkerbellProviderMachineConfigsMissingUserSshKeys(t *testing.T) {
@@ -218,6 +407,70 @@ func TestTinkerbellProviderGenerateDeploymentFileWithStackedEtcd(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
 }
 
+func TestTinkerbellProviderGenerateDeploymentFileWithAPIServerExtraArgs(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_apiserver_extra_args.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_apiserver_extra_args.yaml")
+}
+
+func TestTinkerbellProviderGenerateCAPISpecForCreateMissingWorkerSSHKey(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	machineConfigs["test-md"].Spec.Users[0].SshAuthorizedKeys = []string{}
+
+	_, _, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	assertError(t, "generating cluster api spec contents: TinkerbellMachineConfig test-md: spec.users[0].sshAuthorizedKeys must contain at least one key", err)
+}
+
 func TestTinkerbellProviderGenerateDeploymentFileWithAutoscalerConfiguration(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -292,8 +545,8 @@ func TestTinkerbellProviderGenerateDeploymentFileWithNodeLabels(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_node_labels.yaml")
 }
 
-func TestTinkerbellProviderGenerateDeploymentFileWithNodeTaints(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_node_taints.yaml"
+func TestTinkerbellProviderGenerateDeploymentFileWithKubeletExtraArgs(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_kubelet_extra_args.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -322,12 +575,12 @@ func TestTinkerbellProviderGenerateDeploymentFileWithNodeTaints(t *testing.T) {
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_node_taints.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_node_taints.yaml")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_kubelet_extra_args.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_kubelet_extra_args.yaml")
 }
 
-func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_multiple_node_groups.yaml"
+func TestTinkerbellProviderGenerateDeploymentFileWithCAPIObjectLabels(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_capi_object_labels.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -356,215 +609,1485 @@ func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *tes
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_external_etcd.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_tinkerbell_md_multiple_node_groups.yaml")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_capi_object_labels.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_capi_object_labels.yaml")
 }
 
-func TestPreCAPIInstallOnBootstrapSuccess(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestTinkerbellProviderGenerateDeploymentFileWithDeletePolicy(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_delete_policy.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
 
-	stackInstaller.EXPECT().Install(
-		ctx,
-		clusterSpec.VersionsBundle.Tinkerbell,
-		testIP,
-		"test.kubeconfig",
-		"",
-		gomock.Any(),
-		gomock.Any(),
-	)
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
-	err := provider.PreCAPIInstallOnBootstrap(ctx, cluster, clusterSpec)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
 	if err != nil {
-		t.Fatalf("failed PreCAPIInstallOnBootstrap: %v", err)
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_delete_policy.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_delete_policy.yaml")
 }
 
-func TestPostWorkloadInitSuccess(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestTinkerbellProviderGenerateDeploymentFileWithCoreDNSConfiguration(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_coredns.yaml"
 	mockCtrl := gomock.NewController(t)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
 
-	stackInstaller.EXPECT().Install(
-		ctx,
-		clusterSpec.VersionsBundle.Tinkerbell,
-		testIP,
-		"test.kubeconfig",
-		"",
-		gomock.Any(),
-		gomock.Any(),
-		gomock.Any(),
-		gomock.Any(),
-	)
-	stackInstaller.EXPECT().UninstallLocal(ctx)
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
-	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
 	if err != nil {
-		t.Fatalf("failed PostWorkloadInit: %v", err)
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_coredns.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_coredns.yaml")
 }
 
-func TestPostBootstrapSetupSuccess(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestTinkerbellProviderGenerateDeploymentFileWithNodeTaints(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_node_taints.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-
-	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
-	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).MaxTimes(2)
+	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
-		t.Fatalf("failed to read hardware csv: %v", err)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
 	}
 
-	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
 	if err != nil {
-		t.Fatalf("failed PostBootstrapSetup: %v", err)
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_node_taints.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_node_taints.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_multiple_node_groups.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	// md-0 and md-1 share a machine config so need hardware for both groups.
+	provider.hardwareCSVFile = "./testdata/hardware_multiple_worker_node_groups.csv"
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_tinkerbell_cp_multiple_node_groups.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_tinkerbell_md_multiple_node_groups.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWorkerNodeGroupOSImageURL(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_worker_os_image_url.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_worker_os_image_url.csv"
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_tinkerbell_cp_worker_os_image_url.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_tinkerbell_md_worker_os_image_url.yaml")
+}
+
+func TestPreCAPIInstallOnBootstrapSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(
+		ctx,
+		clusterSpec.VersionsBundle.Tinkerbell,
+		testIP,
+		"test.kubeconfig",
+		"",
+		gomock.Any(),
+		gomock.Any(),
+	)
+
+	err := provider.PreCAPIInstallOnBootstrap(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed PreCAPIInstallOnBootstrap: %v", err)
+	}
+}
+
+func TestPostWorkloadInitSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(
+		ctx,
+		clusterSpec.VersionsBundle.Tinkerbell,
+		testIP,
+		"test.kubeconfig",
+		"",
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	)
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed PostWorkloadInit: %v", err)
+	}
+}
+
+func TestPostWorkloadInitInstallErrorKeepsLocalBoots(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(ctx, clusterSpec.VersionsBundle.Tinkerbell, testIP, "test.kubeconfig", "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("helm install failed"))
+	stackInstaller.EXPECT().UninstallLocal(gomock.Any()).Times(0)
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	assertError(t, "installing stack on workload cluster: helm install failed", err)
+}
+
+func TestPostWorkloadInitInstallErrorRemoveLocalBootsOnFailure(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup, WithKeepLocalBootsOnFailure(false))
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(ctx, clusterSpec.VersionsBundle.Tinkerbell, testIP, "test.kubeconfig", "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("helm install failed"))
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	assertError(t, "installing stack on workload cluster: helm install failed", err)
+}
+
+func TestPostWorkloadInitRemoveLocalBootsOnFailureSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup, WithKeepLocalBootsOnFailure(false))
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(ctx, clusterSpec.VersionsBundle.Tinkerbell, testIP, "test.kubeconfig", "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	assert.NoError(t, err)
+}
+
+func TestPostBootstrapSetupWaitForRufioMachinesFailRemoveLocalBootsOnFailure(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).Return(errors.New("test error"))
+	docker.EXPECT().ForceRemove(ctx, "boots")
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup, WithKeepLocalBootsOnFailure(false))
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
+	assert.Error(t, err, "PostBootstrapSetup should fail")
+}
+
+func TestPostBootstrapSetupSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).MaxTimes(2)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
+	if err != nil {
+		t.Fatalf("failed PostBootstrapSetup: %v", err)
+	}
+}
+
+func TestPostBootstrapSetupCustomRufioContactableTimeout(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "15m0s", "Contactable", constants.EksaSystemNamespace)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup, WithRufioContactableTimeout(15*time.Minute))
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
+	if err != nil {
+		t.Fatalf("failed PostBootstrapSetup: %v", err)
+	}
+}
+
+func TestPostBootstrapSetupWaitForRufioMachinesFail(t *testing.T) {
+	wantError := errors.New("test error")
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).Return(wantError)
+	docker.EXPECT().ForceRemove(gomock.Any(), gomock.Any()).Times(0)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
+	assert.Error(t, err, "PostBootstrapSetup should fail")
+}
+
+func TestPostMoveManagementToBootstrapSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).Return(nil).MaxTimes(2)
+
+	tt := []struct {
+		name            string
+		hardwareCSVFile string
+	}{
+		{
+			name:            "bmc in hardware csv",
+			hardwareCSVFile: "./testdata/hardware.csv",
+		},
+		{
+			name:            "no bmc in hardware csv",
+			hardwareCSVFile: "./testdata/hardware_nobmc.csv",
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+			provider.hardwareCSVFile = test.hardwareCSVFile
+			if err := provider.readCSVToCatalogue(); err != nil {
+				t.Fatalf("failed to read hardware csv: %v", err)
+			}
+
+			err := provider.PostMoveManagementToBootstrap(ctx, cluster)
+			if err != nil {
+				t.Fatalf("failed PostMoveManagementToBootstrap: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostMoveManagementToBootstrapCustomRufioContactableTimeout(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "15m0s", "Contactable", constants.EksaSystemNamespace)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup, WithRufioContactableTimeout(15*time.Minute))
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostMoveManagementToBootstrap(ctx, cluster)
+	if err != nil {
+		t.Fatalf("failed PostMoveManagementToBootstrap: %v", err)
+	}
+}
+
+func TestPostMoveManagementToBootstrapWaitForRufioMachinesFail(t *testing.T) {
+	wantError := errors.New("test error")
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).Return(wantError)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
+	}
+
+	err := provider.PostMoveManagementToBootstrap(ctx, cluster)
+	assert.Error(t, err, "PostMoveManagementToBootstrap should fail")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWithFullOIDC(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_full_oidc.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_full_oidc.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWithOIDCGroupsClaimAndRequiredClaim(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_oidc_groups_claim.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_oidc_groups_claim.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWithMinimalOIDC(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_minimal_oidc.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_minimal_oidc.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWithAWSIamConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_awsiam.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_awsiam.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithMinimalRegistryMirror(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_minimal_registry_mirror.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_minimal_registry_mirror.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_minimal_registry_mirror.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithNamespacedRegistryMirror(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_registry_mirror_namespaced.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_namespaced.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithCert(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_registry_mirror_with_cert.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_with_cert.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_registry_mirror_with_cert.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithAuth(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_registry_mirror_with_auth.yaml"
+	if err := os.Setenv("REGISTRY_USERNAME", "username"); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := os.Setenv("REGISTRY_PASSWORD", "password"); err != nil {
+		t.Fatalf(err.Error())
+	}
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_with_auth.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_registry_mirror_with_auth.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithBottlerocketMinimalRegistryMirror(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_minimal_registry_mirror.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_minimal_registry_mirror.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_minimal_registry_mirror.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithCert(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_registry_mirror_with_cert.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_cert.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_cert.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithAuth(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_registry_mirror_with_auth.yaml"
+	t.Setenv("REGISTRY_USERNAME", "username")
+	t.Setenv("REGISTRY_PASSWORD", "password")
+
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_auth.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_auth.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForSingleNodeCluster(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_single_node.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	if len(md) != 0 {
+		t.Fatalf("expect nothing to be generated for worker node")
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_single_node.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForSingleNodeClusterSkipLB(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_single_node_skip_lb.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	if len(md) != 0 {
+		t.Fatalf("expect nothing to be generated for worker node")
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_single_node_skip_lb.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForMultiNodeClusterSkipLB(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_multi_node_skip_lb.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_multi_node_skip_lb.csv"
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	if strings.Contains(string(cp), "kube-vip") {
+		t.Fatalf("expected kube-vip to be omitted from the control plane spec")
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_multi_node_skip_lb.yaml")
+}
+
+func TestTinkerbellTemplate_isScaleUpDownSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	newClusterSpec := clusterSpec.DeepCopy()
+	newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	assert.True(t, provider.isScaleUpDown(clusterSpec.Cluster, newClusterSpec.Cluster), "expected scale up down true")
+}
+
+func TestSetupAndValidateCreateWorkloadClusterSuccess(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
+
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any())
+	kubectl.EXPECT().WaitForRufioMachines(ctx, clusterSpec.ManagementCluster, "5m0s", "Contactable", constants.EksaSystemNamespace)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("unexpected failure %v", err)
+	}
+	assert.NoError(t, err, "No error should be returned")
+}
+
+func TestSetupAndValidateCreateWorkloadClusterFailsIfSelectedHardwareIsProvisioned(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
+
+	provisioned := []tinkv1alpha1.Hardware{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"type":                  "cp",
+				hardware.OwnerNameLabel: "other-cluster-control-plane-abcde",
+			},
+		},
+	}}
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return(provisioned, nil)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, `hardware is already provisioned: hardware name 'worker1'; selector '{"type":"cp"}'; owner 'other-cluster-control-plane-abcde'`, err)
+}
+
+func TestSetupAndValidateCreateWorkloadClusterFailsIfMachineExists(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+
+	idx := 0
+	var existingMachine string
+	for _, config := range machineConfigs {
+		if idx == 0 {
+			kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{config}, nil)
+			existingMachine = config.Name
+		} else {
+			kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil).MaxTimes(1)
+		}
+		idx++
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	assertError(t, fmt.Sprintf("TinkerbellMachineConfig %s already exists", existingMachine), err)
+}
+
+func TestSetupAndValidateCreateWorkloadClusterFailsIfDatacenterExists(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{datacenterConfig}, nil)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	assertError(t, fmt.Sprintf("TinkerbellDatacenterConfig %s already exists", datacenterConfig.Name), err)
+}
+
+func TestSetupAndValidateCreateWorkloadClusterFailsIfDatacenterConfigError(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, errors.New("error getting TinkerbellDatacenterConfig"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "error getting TinkerbellDatacenterConfig", err)
+}
+
+func TestSetupAndValidateCreateWorkloadClusterErrorUnprovisionedHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
+
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, errors.New("error"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "retrieving unprovisioned hardware: error", err)
 }
 
-func TestPostBootstrapSetupWaitForRufioMachinesFail(t *testing.T) {
-	wantError := errors.New("test error")
+func TestSetupAndValidateCreateWorkloadClusterErrorProvisionedHardware(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-
-	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
-	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(wantError)
+	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
-		t.Fatalf("failed to read hardware csv: %v", err)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
+
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
 	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
 
-	err := provider.PostBootstrapSetup(ctx, provider.clusterConfig, cluster)
-	assert.Error(t, err, "PostBootstrapSetup should fail")
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, errors.New("error"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "retrieving provisioned hardware: error", err)
 }
 
-func TestPostMoveManagementToBootstrapSuccess(t *testing.T) {
+func TestSetupAndValidateUpgradeClusterErrorValidateClusterSpec(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
 
-	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(nil).MaxTimes(2)
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.providerKubectlClient = kubectl
 
-	tt := []struct {
-		name            string
-		hardwareCSVFile string
-	}{
-		{
-			name:            "bmc in hardware csv",
-			hardwareCSVFile: "./testdata/hardware.csv",
-		},
-		{
-			name:            "no bmc in hardware csv",
-			hardwareCSVFile: "./testdata/hardware_nobmc.csv",
-		},
-	}
+	cluster.KubeconfigFile = "kc.kubeconfig"
 
-	for _, test := range tt {
-		t.Run(test.name, func(t *testing.T) {
-			provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-			provider.hardwareCSVFile = test.hardwareCSVFile
-			if err := provider.readCSVToCatalogue(); err != nil {
-				t.Fatalf("failed to read hardware csv: %v", err)
-			}
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-			err := provider.PostMoveManagementToBootstrap(ctx, cluster)
-			if err != nil {
-				t.Fatalf("failed PostMoveManagementToBootstrap: %v", err)
-			}
-		})
-	}
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	provider.datacenterConfig.Spec.TinkerbellIP = ""
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
+	assertError(t, "TinkerbellDatacenterConfig: missing spec.tinkerbellIP field", err)
 }
 
-func TestPostMoveManagementToBootstrapWaitForRufioMachinesFail(t *testing.T) {
-	wantError := errors.New("test error")
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestSetupAndValidateUpgradeClusterErrorScaleExternalEtcd(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
-	ctx := context.Background()
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
+	currentClusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count = 3
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.providerKubectlClient = kubectl
 
-	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(wantError)
-	if err := provider.readCSVToCatalogue(); err != nil {
-		t.Fatalf("failed to read hardware csv: %v", err)
+	cluster.KubeconfigFile = "kc.kubeconfig"
+
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, currentClusterSpec)
+	assertError(t, "scale up/down not supported for external etcd", err)
+}
+
+func TestSetupAndValidateUpgradeClusterSkipLoadBalancerKubeVip(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []bootstrapv1.File
+		wantErr string
+	}{
+		{
+			name:    "control plane runs kube-vip",
+			files:   []bootstrapv1.File{{Path: "/etc/kubernetes/manifests/kube-vip.yaml"}},
+			wantErr: "control plane endpoint of cluster test is served by kube-vip",
+		},
+		{
+			name: "control plane without kube-vip",
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+			mockCtrl := gomock.NewController(t)
+			docker := stackmocks.NewMockDocker(mockCtrl)
+			helm := stackmocks.NewMockHelm(mockCtrl)
+			kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+			stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+			writer := filewritermocks.NewMockFileWriter(mockCtrl)
+			cluster := &types.Cluster{Name: "test", KubeconfigFile: "kc.kubeconfig"}
+			forceCleanup := false
+
+			clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+			datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+			datacenterConfig.Spec.SkipLoadBalancerDeployment = true
+			machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+			ctx := context.Background()
 
-	err := provider.PostMoveManagementToBootstrap(ctx, cluster)
-	assert.Error(t, err, "PostMoveManagementToBootstrap should fail")
+			provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+			provider.stackInstaller = stackInstaller
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{Files: tt.files},
+				},
+			}
+
+			kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+			kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+			kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, gomock.Any(), gomock.Any()).Return(kcp, nil)
+
+			err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
 }
 
-func TestTinkerbellProviderGenerateDeploymentFileWithFullOIDC(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_full_oidc.yaml"
+func TestSetupAndValidateUpgradeWorkloadClusterErrorApplyHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -581,24 +2104,28 @@ func TestTinkerbellProviderGenerateDeploymentFileWithFullOIDC(t *testing.T) {
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
+	provider.providerKubectlClient = kubectl
 
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
 	}
+	cluster.KubeconfigFile = "kc.kubeconfig"
 
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_full_oidc.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any()).Return(fmt.Errorf("error"))
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
+	assertError(t, "applying hardware yaml: error", err)
 }
 
-func TestTinkerbellProviderGenerateDeploymentFileWithMinimalOIDC(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_minimal_oidc.yaml"
+func TestSetupAndValidateUpgradeWorkloadClusterErrorBMC(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -615,31 +2142,38 @@ func TestTinkerbellProviderGenerateDeploymentFileWithMinimalOIDC(t *testing.T) {
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
+	provider.providerKubectlClient = kubectl
+	provider.hardwareCSVFile = "testdata/hardware.csv"
 
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
 	}
+	cluster.KubeconfigFile = "kc.kubeconfig"
 
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_minimal_oidc.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+
+	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any())
+
+	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m0s", "Contactable", gomock.Any()).Return(fmt.Errorf("error"))
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
+	assertError(t, "waiting for baseboard management to be contactable: error", err)
 }
 
-func TestTinkerbellProviderGenerateDeploymentFileWithAWSIamConfig(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_awsiam.yaml"
+func TestSetupAndValidateCreateWorkloadClusterErrorManagementCluster(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
+
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -651,29 +2185,36 @@ func TestTinkerbellProviderGenerateDeploymentFileWithAWSIamConfig(t *testing.T)
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
 	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
 	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_awsiam.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, fmt.Errorf("error getting management cluster data"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "error getting management cluster data", err)
 }
 
-func TestProviderGenerateDeploymentFileForWithMinimalRegistryMirror(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_minimal_registry_mirror.yaml"
+func TestSetupAndValidateCreateWorkloadClusterErrorManagementClusterTinkerbellIP(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
+
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -685,29 +2226,37 @@ func TestProviderGenerateDeploymentFileForWithMinimalRegistryMirror(t *testing.T
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
 	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
 	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_minimal_registry_mirror.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_minimal_registry_mirror.yaml")
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, fmt.Errorf("error"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "getting TinkerbellIP of management cluster: error", err)
 }
 
-func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithCert(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_registry_mirror_with_cert.yaml"
+func TestPreCoreComponentsUpgrade(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
+
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -719,28 +2268,30 @@ func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithCert(t *testing.
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+	provider.providerKubectlClient = kubectl
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
+	clusterSpec.Cluster.SetManagedBy("management-cluster")
+	clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
 	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	for _, config := range machineConfigs {
+		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
 	}
+	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_with_cert.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_registry_mirror_with_cert.yaml")
+	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
+	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, fmt.Errorf("error"))
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "getting TinkerbellIP of management cluster: error", err)
 }
 
-func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithAuth(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_registry_mirror_with_auth.yaml"
-	if err := os.Setenv("REGISTRY_USERNAME", "username"); err != nil {
-		t.Fatalf(err.Error())
-	}
-	if err := os.Setenv("REGISTRY_PASSWORD", "password"); err != nil {
-		t.Fatalf(err.Error())
-	}
+func TestProviderGenerateDeploymentFileForWithProxy(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_proxy.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -769,12 +2320,12 @@ func TestProviderGenerateDeploymentFileForWithRegistryMirrorWithAuth(t *testing.
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_with_auth.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_registry_mirror_with_auth.yaml")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_proxy.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_proxy.yaml")
 }
 
-func TestProviderGenerateDeploymentFileForWithBottlerocketMinimalRegistryMirror(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_minimal_registry_mirror.yaml"
+func TestProviderGenerateDeploymentFileForWithProxyMissingScheme(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_proxy.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -798,54 +2349,43 @@ func TestProviderGenerateDeploymentFileForWithBottlerocketMinimalRegistryMirror(
 		t.Fatalf("failed to setup and validate: %v", err)
 	}
 
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
-
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_minimal_registry_mirror.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_minimal_registry_mirror.yaml")
+	clusterSpec.Cluster.Spec.ProxyConfiguration.HttpProxy = "1.1.1.1:8080"
+	_, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	assertError(t, "generating cluster api spec contents: httpProxy 1.1.1.1:8080 is missing a scheme, please provide a URL such as http://1.1.1.1:8080", err)
 }
 
-func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithCert(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_registry_mirror_with_cert.yaml"
+func TestProviderNoProxyListRemovesDuplicates(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_proxy.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
+	clusterSpec.Cluster.Spec.ProxyConfiguration.NoProxy = []string{"10.96.0.0/12", "localhost", "1.2.3.4", testIP, "localhost"}
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
 
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
-	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	want := []string{
+		"192.168.0.0/16",
+		"10.96.0.0/12",
+		"localhost",
+		"1.2.3.4",
+		testIP,
+		"127.0.0.1",
+		".svc",
+		"2.3.4.5",
 	}
-
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_cert.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_cert.yaml")
+	assert.Equal(t, want, provider.NoProxyList(clusterSpec))
+	assert.Equal(t, want, provider.NoProxyList(clusterSpec))
 }
 
-func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithAuth(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_bottlerocket_registry_mirror_with_auth.yaml"
-	t.Setenv("REGISTRY_USERNAME", "username")
-	t.Setenv("REGISTRY_PASSWORD", "password")
-
+func TestProviderGenerateDeploymentFileForBottleRocketWithNTPConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_bottlerocket_ntp_config.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -874,12 +2414,12 @@ func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithAuth
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_auth.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_auth.yaml")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bottlerocket_ntp_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_bottlerocket_ntp_config_md.yaml")
 }
 
-func TestProviderGenerateDeploymentFileForSingleNodeCluster(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_single_node.yaml"
+func TestProviderGenerateDeploymentFileForUbuntuWithNTPConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_ubuntu_ntp_config.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -908,14 +2448,12 @@ func TestProviderGenerateDeploymentFileForSingleNodeCluster(t *testing.T) {
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	if len(md) != 0 {
-		t.Fatalf("expect nothing to be generated for worker node")
-	}
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_single_node.yaml")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ubuntu_ntp_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_ntp_config_md.yaml")
 }
 
-func TestProviderGenerateDeploymentFileForSingleNodeClusterSkipLB(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_single_node_skip_lb.yaml"
+func TestProviderGenerateDeploymentFileForUbuntuWithMTU(t *testing.T) {
+	clusterSpecManifest := "cluster_ubuntu_mtu.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -944,41 +2482,19 @@ func TestProviderGenerateDeploymentFileForSingleNodeClusterSkipLB(t *testing.T)
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	if len(md) != 0 {
-		t.Fatalf("expect nothing to be generated for worker node")
-	}
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_single_node_skip_lb.yaml")
-}
-
-func TestTinkerbellTemplate_isScaleUpDownSuccess(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
-	mockCtrl := gomock.NewController(t)
-	docker := stackmocks.NewMockDocker(mockCtrl)
-	helm := stackmocks.NewMockHelm(mockCtrl)
-	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	forceCleanup := false
-
-	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
-	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
-	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-
-	newClusterSpec := clusterSpec.DeepCopy()
-	newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
-
-	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	assert.True(t, provider.isScaleUpDown(clusterSpec.Cluster, newClusterSpec.Cluster), "expected scale up down true")
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ubuntu_mtu_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_mtu_md.yaml")
 }
 
-func TestSetupAndValidateCreateWorkloadClusterSuccess(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderGenerateDeploymentFileForUbuntuWithRunCmd(t *testing.T) {
+	clusterSpecManifest := "cluster_ubuntu_runcmd_config.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -990,42 +2506,29 @@ func TestSetupAndValidateCreateWorkloadClusterSuccess(t *testing.T) {
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
 
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
-	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
-	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any())
-	kubectl.EXPECT().WaitForRufioMachines(ctx, clusterSpec.ManagementCluster, "5m", "Contactable", constants.EksaSystemNamespace)
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
 	if err != nil {
-		t.Fatalf("unexpected failure %v", err)
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
-	assert.NoError(t, err, "No error should be returned")
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ubuntu_runcmd_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_runcmd_config_md.yaml")
 }
 
-func TestSetupAndValidateCreateWorkloadClusterFailsIfMachineExists(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderGenerateDeploymentFileForBottlerocketWithBottlerocketSettingsConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_bottlerocket_settings_config.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -1037,44 +2540,36 @@ func TestSetupAndValidateCreateWorkloadClusterFailsIfMachineExists(t *testing.T)
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
 
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
 	}
 
-	idx := 0
-	var existingMachine string
-	for _, config := range machineConfigs {
-		if idx == 0 {
-			kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{config}, nil)
-			existingMachine = config.Name
-		} else {
-			kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil).MaxTimes(1)
-		}
-		idx++
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	fmt.Println(string(md))
 
-	assertError(t, fmt.Sprintf("TinkerbellMachineConfig %s already exists", existingMachine), err)
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bottlerocket_settings_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_bottlerocket_settings_config_md.yaml")
 }
 
-func TestSetupAndValidateCreateWorkloadClusterFailsIfDatacenterExists(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestTinkerbellProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_awsiam.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
+	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.PodIAMConfig = &v1alpha1.PodIAMConfig{ServiceAccountIssuer: "https://test"}
+
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
 	ctx := context.Background()
@@ -1083,381 +2578,263 @@ func TestSetupAndValidateCreateWorkloadClusterFailsIfDatacenterExists(t *testing
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
 
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
 	}
-
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{datacenterConfig}, nil)
-
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
 
-	assertError(t, fmt.Sprintf("TinkerbellDatacenterConfig %s already exists", datacenterConfig.Name), err)
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_tinkerbell_pod_iam_config.yaml")
 }
 
-func TestSetupAndValidateCreateWorkloadClusterFailsIfDatacenterConfigError(t *testing.T) {
+func TestProviderPreviewHardwareSelection(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
 
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	selection, err := provider.PreviewHardwareSelection(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed PreviewHardwareSelection: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, errors.New("error getting TinkerbellDatacenterConfig"))
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "error getting TinkerbellDatacenterConfig", err)
+	assert.Equal(t, map[string][]string{
+		"test-cp": {"00:00:00:00:00:01", "00:00:00:00:00:04"},
+		"md-0":    {"00:00:00:00:00:02"},
+	}, selection)
+	assert.Equal(t, 0, provider.catalogue.TotalHardware())
 }
 
-func TestSetupAndValidateCreateWorkloadClusterErrorUnprovisionedHardware(t *testing.T) {
+func TestProviderPreviewHardwareSelectionSharedSelectorShort(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+		Name:            "md-1",
+		Count:           ptr.Int(1),
+		MachineGroupRef: clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef,
+	})
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
-	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, errors.New("error"))
-
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "retrieving unprovisioned hardware: error", err)
+
+	_, err := provider.PreviewHardwareSelection(clusterSpec)
+	assertError(t, "not enough hardware for md-0, md-1: have 1 matching selector map[type:worker], require 2", err)
 }
 
-func TestSetupAndValidateCreateWorkloadClusterErrorProvisionedHardware(t *testing.T) {
+func TestProviderPreviewHardwareSelectionWorkerGroupShort(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
-	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, errors.New("error"))
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "retrieving provisioned hardware: error", err)
+	_, err := provider.PreviewHardwareSelection(clusterSpec)
+	assertError(t, "not enough hardware for md-0: have 1 matching selector map[type:worker], require 2", err)
 }
 
-func TestSetupAndValidateUpgradeClusterErrorValidateClusterSpec(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpec(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-	provider.providerKubectlClient = kubectl
-
-	cluster.KubeconfigFile = "kc.kubeconfig"
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-	provider.datacenterConfig.Spec.TinkerbellIP = ""
-
-	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
-	assertError(t, "TinkerbellDatacenterConfig: missing spec.tinkerbellIP field", err)
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
+	}
+
+	assert.True(t, report.Fits())
+	assert.Equal(t, []HardwareRequirementFit{
+		{Selector: v1alpha1.HardwareSelector{"type": "cp"}, Groups: []string{"test-cp"}, Available: 2, Required: 1},
+		{Selector: v1alpha1.HardwareSelector{"type": "etcd"}, Groups: []string{"test-etcd"}, Available: 1, Required: 1},
+		{Selector: v1alpha1.HardwareSelector{"type": "worker"}, Groups: []string{"md-0"}, Available: 1, Required: 1},
+	}, report.Requirements)
+	assert.Empty(t, report.UnmatchedSelectors)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 0, provider.catalogue.TotalHardware())
 }
 
-func TestSetupAndValidateUpgradeWorkloadClusterErrorApplyHardware(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpecEtcdShort(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count = 3
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-	provider.providerKubectlClient = kubectl
 
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
 	}
-	cluster.KubeconfigFile = "kc.kubeconfig"
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any()).Return(fmt.Errorf("error"))
-
-	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
-	assertError(t, "applying hardware yaml: error", err)
+	assert.False(t, report.Fits())
+	assert.Equal(t, HardwareRequirementFit{
+		Selector: v1alpha1.HardwareSelector{"type": "etcd"}, Groups: []string{"test-etcd"}, Available: 1, Required: 3,
+	}, report.Requirements[1])
+	assert.Empty(t, report.UnmatchedSelectors)
+	assert.Equal(t, []string{`minimum hardware count not met for selector '{"type":"etcd"}': have 1, require 3`}, report.Errors)
 }
 
-func TestSetupAndValidateUpgradeWorkloadClusterErrorBMC(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpecUnmatchedSelector(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
+	machineConfigs["test-md"].Spec.HardwareSelector = v1alpha1.HardwareSelector{"type": "gpu"}
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-	provider.providerKubectlClient = kubectl
-	provider.hardwareCSVFile = "testdata/hardware.csv"
 
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
 	}
-	cluster.KubeconfigFile = "kc.kubeconfig"
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
 
-	kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, clusterSpec.ManagementCluster, gomock.Any())
-
-	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(fmt.Errorf("error"))
-
-	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
-	assertError(t, "waiting for baseboard management to be contactable: error", err)
+	assert.False(t, report.Fits())
+	assert.Equal(t, []string{"md-0"}, report.UnmatchedSelectors)
 }
 
-func TestSetupAndValidateCreateWorkloadClusterErrorManagementCluster(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpecSharedSelector(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+		Name:            "md-1",
+		Count:           ptr.Int(1),
+		MachineGroupRef: clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef,
+	})
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
 
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, fmt.Errorf("error getting management cluster data"))
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "error getting management cluster data", err)
+	assert.False(t, report.Fits())
+	assert.Equal(t, HardwareRequirementFit{
+		Selector: v1alpha1.HardwareSelector{"type": "worker"}, Groups: []string{"md-0", "md-1"}, Available: 1, Required: 2,
+	}, report.Requirements[2])
 }
 
-func TestSetupAndValidateCreateWorkloadClusterErrorManagementClusterTinkerbellIP(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpecBMCMissingCredentials(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed to read hardware csv: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
+	secret := provider.catalogue.AllSecrets()[0]
+	delete(secret.Data, "password")
 
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
-	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, fmt.Errorf("error"))
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
+	}
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "getting TinkerbellIP of management cluster: error", err)
+	assert.False(t, report.Fits())
+	assert.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "has no password")
 }
 
-func TestPreCoreComponentsUpgrade(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+func TestProviderValidateHardwareFitsSpecBMCMixed(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_mixed_bmc.csv"
 
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
-	provider.providerKubectlClient = kubectl
-
-	clusterSpec.Cluster.SetManagedBy("management-cluster")
-	clusterSpec.ManagementCluster = &types.Cluster{
-		Name:               "management-cluster",
-		KubeconfigFile:     "kc.kubeconfig",
-		ExistingManagement: true,
-	}
-	for _, config := range machineConfigs {
-		kubectl.EXPECT().SearchTinkerbellMachineConfig(ctx, config.Name, clusterSpec.ManagementCluster.KubeconfigFile, config.Namespace).Return([]*v1alpha1.TinkerbellMachineConfig{}, nil)
+	report, err := provider.ValidateHardwareFitsSpec(clusterSpec)
+	if err != nil {
+		t.Fatalf("failed ValidateHardwareFitsSpec: %v", err)
 	}
-	kubectl.EXPECT().SearchTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return([]*v1alpha1.TinkerbellDatacenterConfig{}, nil)
-
-	kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, clusterSpec.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace).Return([]tinkv1alpha1.Hardware{}, nil)
-	kubectl.EXPECT().GetEksaCluster(ctx, clusterSpec.ManagementCluster, clusterSpec.ManagementCluster.Name).Return(clusterSpec.Cluster, nil)
-	kubectl.EXPECT().GetEksaTinkerbellDatacenterConfig(ctx, datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, fmt.Errorf("error"))
 
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	assertError(t, "getting TinkerbellIP of management cluster: error", err)
+	assert.False(t, report.Fits())
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, "hardware without a BMC (worker2) can't be mixed with hardware with a BMC (worker1, worker3): either all or none of the hardware must have a BMC", report.Errors[0])
 }
 
-func TestProviderGenerateDeploymentFileForWithProxy(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_proxy.yaml"
+func TestTinkerbellProviderGenerateCAPISpecForCreateDeterministicTemplateNames(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
@@ -1472,7 +2849,10 @@ func TestProviderGenerateDeploymentFileForWithProxy(t *testing.T) {
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
 	ctx := context.Background()
 
-	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider, err := NewProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, "./testdata/hardware.csv", writer, docker, helm, kubectl, testIP, time.Now, forceCleanup, false, WithDeterministicTemplateNames(3))
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
 	provider.stackInstaller = stackInstaller
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
@@ -1481,24 +2861,25 @@ func TestProviderGenerateDeploymentFileForWithProxy(t *testing.T) {
 		t.Fatalf("failed to setup and validate: %v", err)
 	}
 
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
+	for i := 0; i < 2; i++ {
+		cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+		if err != nil {
+			t.Fatalf("failed to generate cluster api spec contents: %v", err)
+		}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_proxy.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_proxy.yaml")
+		test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_deterministic_template_names.yaml")
+		test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_deterministic_template_names.yaml")
+	}
 }
 
-func TestProviderGenerateDeploymentFileForBottleRocketWithNTPConfig(t *testing.T) {
-	clusterSpecManifest := "cluster_bottlerocket_ntp_config.yaml"
+func TestSetupAndValidateCreateClusterEndpointIsHardwareIP(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -1508,31 +2889,22 @@ func TestProviderGenerateDeploymentFileForBottleRocketWithNTPConfig(t *testing.T
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_endpoint_conflict.csv"
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
-	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
-
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bottlerocket_ntp_config_cp.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_bottlerocket_ntp_config_md.yaml")
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "control plane endpoint 1.2.3.4 is the IP of hardware worker2", err)
 }
 
-func TestProviderGenerateDeploymentFileForUbuntuWithNTPConfig(t *testing.T) {
-	clusterSpecManifest := "cluster_ubuntu_ntp_config.yaml"
+func TestSetupAndValidateCreateClusterHardwareDiskTooSmall(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -1542,31 +2914,22 @@ func TestProviderGenerateDeploymentFileForUbuntuWithNTPConfig(t *testing.T) {
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_disk_size.csv"
 
 	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
-	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
-
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ubuntu_ntp_config_cp.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_ntp_config_md.yaml")
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "hardware 00:00:00:00:00:02 disk is 8GiB, smaller than the 20GiB required by ubuntu machine config test-md", err)
 }
 
-func TestProviderGenerateDeploymentFileForBottlerocketWithBottlerocketSettingsConfig(t *testing.T) {
-	clusterSpecManifest := "cluster_bottlerocket_settings_config.yaml"
+func TestSetupAndValidateCreateClusterEndpointIsNotHardwareIP(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
@@ -1582,48 +2945,33 @@ func TestProviderGenerateDeploymentFileForBottlerocketWithBottlerocketSettingsCo
 	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
 		t.Fatalf("failed to setup and validate: %v", err)
 	}
-
-	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
-	}
-
-	fmt.Println(string(md))
-
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bottlerocket_settings_config_cp.yaml")
-	test.AssertContentToFile(t, string(md), "testdata/expected_results_bottlerocket_settings_config_md.yaml")
 }
 
-func TestTinkerbellProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
-	clusterSpecManifest := "cluster_tinkerbell_awsiam.yaml"
+func TestWorkerNodeGroupsWithNewTemplates(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
 	docker := stackmocks.NewMockDocker(mockCtrl)
 	helm := stackmocks.NewMockHelm(mockCtrl)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
-	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	cluster := &types.Cluster{Name: "test"}
 	forceCleanup := false
 
+	currentClusterSpec := givenClusterSpec(t, clusterSpecManifest)
 	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
-	clusterSpec.Cluster.Spec.PodIAMConfig = &v1alpha1.PodIAMConfig{ServiceAccountIssuer: "https://test"}
-
 	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
 	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
-	ctx := context.Background()
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	provider.stackInstaller = stackInstaller
-
-	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
 
-	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
-		t.Fatalf("failed to setup and validate: %v", err)
-	}
-	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
-	if err != nil {
-		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	if nodeGroups := provider.workerNodeGroupsWithNewTemplates(currentClusterSpec, clusterSpec); len(nodeGroups) != 0 {
+		t.Fatalf("workerNodeGroupsWithNewTemplates() = %v, want none", nodeGroups)
 	}
 
-	test.AssertContentToFile(t, string(cp), "testdata/expected_results_tinkerbell_pod_iam_config.yaml")
+	nodeGroup := &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	nodeGroup.Labels = map[string]string{"new": "label"}
+
+	nodeGroups := provider.workerNodeGroupsWithNewTemplates(currentClusterSpec, clusterSpec)
+	if len(nodeGroups) != 1 || nodeGroups[0] != nodeGroup.Name {
+		t.Fatalf("workerNodeGroupsWithNewTemplates() = %v, want [%s]", nodeGroups, nodeGroup.Name)
+	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/rufiounreleased"
@@ -36,7 +37,7 @@ func needsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
 	return false
 }
 
-func needsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
+func needsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldTdc, newTdc *v1alpha1.TinkerbellDatacenterConfig, oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
 	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
 		return true
 	}
@@ -45,6 +46,10 @@ func needsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
 		return true
 	}
 
+	if osImageURL(oldTdc.Spec, oldTmc.Spec) != osImageURL(newTdc.Spec, newTmc.Spec) {
+		return true
+	}
+
 	if !v1alpha1.WorkerNodeGroupConfigurationSliceTaintsEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
 		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
@@ -53,15 +58,30 @@ func needsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
 	return false
 }
 
+// NeedsNewEtcdTemplate determines if a new etcd TinkerbellMachineTemplate is needed when upgrading
+// from oldSpec to newSpec.
+func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldTdc, newTdc *v1alpha1.TinkerbellDatacenterConfig, oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
+	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+		return true
+	}
+
+	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
+		return true
+	}
+
+	if osImageURL(oldTdc.Spec, oldTmc.Spec) != osImageURL(newTdc.Spec, newTmc.Spec) {
+		return true
+	}
+
+	return oldTmc.Spec.TemplateRef != newTmc.Spec.TemplateRef
+}
+
 func needsNewKubeadmConfigTemplate(newWorkerNodeGroup, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration) bool {
-	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels)
+	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
+		!v1alpha1.MapEqual(newWorkerNodeGroup.KubeletExtraArgs, oldWorkerNodeGroup.KubeletExtraArgs)
 }
 
 func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, currentClusterSpec *cluster.Spec) error {
-	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		return errExternalEtcdUnsupported
-	}
-
 	if err := p.configureSshKeys(); err != nil {
 		return err
 	}
@@ -69,16 +89,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	// If we've been given a CSV with additional hardware for the cluster, validate it and
 	// write it to the catalogue so it can be used for further processing.
 	if p.hardwareCSVIsProvided() {
-		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
-
-		machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
-		if err != nil {
-			return err
-		}
-
-		machineValidator := hardware.NewDefaultMachineValidator()
-
-		if err := hardware.TranslateAll(machines, machineCatalogueWriter, machineValidator); err != nil {
+		if err := p.readCSVToCatalogue(); err != nil {
 			return err
 		}
 	}
@@ -119,12 +130,16 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		return err
 	}
 
+	if err := p.validateControlPlaneKubeVipNotRemoved(ctx, cluster, clusterSpec); err != nil {
+		return err
+	}
+
 	if p.clusterConfig.IsManaged() {
 		if err := p.applyHardwareUpgrade(ctx, clusterSpec.ManagementCluster); err != nil {
 			return err
 		}
 		if p.catalogue.TotalHardware() > 0 && p.catalogue.AllHardware()[0].Spec.BMCRef != nil {
-			err = p.providerKubectlClient.WaitForRufioMachines(ctx, cluster, "5m", "Contactable", constants.EksaSystemNamespace)
+			err = p.providerKubectlClient.WaitForRufioMachines(ctx, cluster, p.rufioContactableTimeout.String(), "Contactable", constants.EksaSystemNamespace)
 			if err != nil {
 				return fmt.Errorf("waiting for baseboard management to be contactable: %v", err)
 			}
@@ -134,6 +149,32 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	return nil
 }
 
+// validateControlPlaneKubeVipNotRemoved blocks upgrading a multi-node cluster that skips the load balancer
+// deployment while its control plane still runs kube-vip. Clusters created before kube-vip was omitted for them
+// serve the control plane endpoint with kube-vip, so rolling out a control plane without it would take the
+// endpoint down.
+func (p *Provider) validateControlPlaneKubeVipNotRemoved(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if !skipKubeVip(clusterSpec, p.datacenterConfig.Spec) {
+		return nil
+	}
+
+	kcp, err := p.providerKubectlClient.GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, executables.WithCluster(cluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return fmt.Errorf("getting KubeadmControlPlane to check for kube-vip: %v", err)
+	}
+
+	for _, file := range kcp.Spec.KubeadmConfigSpec.Files {
+		if file.Path == kubeVipManifestPath {
+			return fmt.Errorf("control plane endpoint of cluster %s is served by kube-vip, which isn't deployed on multi-node "+
+				"clusters with skipLoadBalancerDeployment set to true: set skipLoadBalancerDeployment to false to keep kube-vip, "+
+				"or move the control plane endpoint %s to an external load balancer before upgrading",
+				clusterSpec.Cluster.Name, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)
+		}
+	}
+
+	return nil
+}
+
 func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, currentSpec, newClusterSpec *cluster.Spec) (err error) {
 	clusterSpecValidator := NewClusterSpecValidator(
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
@@ -143,6 +184,13 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion {
 		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForRollingUpgrade(p.catalogue))
 		rollingUpgrade = true
+	} else if nodeGroups := p.workerNodeGroupsWithNewTemplates(currentSpec, newClusterSpec); len(nodeGroups) > 0 {
+		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForWorkerRollout(p.catalogue, nodeGroups))
+	}
+
+	if currentSpec.Cluster.Spec.ExternalEtcdConfiguration != nil && newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil &&
+		currentSpec.Cluster.Spec.ExternalEtcdConfiguration.Count != newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count {
+		return fmt.Errorf("scale up/down not supported for external etcd")
 	}
 
 	currentTinkerbellSpec := NewClusterSpec(currentSpec, currentSpec.TinkerbellMachineConfigs, currentSpec.TinkerbellDatacenter)
@@ -157,6 +205,37 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	return nil
 }
 
+// workerNodeGroupsWithNewTemplates returns the names of the worker node groups present in both currentSpec and
+// newClusterSpec that get a new machine or kubeadm config template, and so roll out their machines, on upgrade.
+func (p *Provider) workerNodeGroupsWithNewTemplates(currentSpec, newClusterSpec *cluster.Spec) []string {
+	if currentSpec.TinkerbellDatacenter == nil {
+		return nil
+	}
+
+	previousWorkerNodeGroupConfigs := cluster.BuildMapForWorkerNodeGroupsByName(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations)
+
+	var nodeGroups []string
+	for _, nodeGroup := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		previous, ok := previousWorkerNodeGroupConfigs[nodeGroup.Name]
+		if !ok || previous.MachineGroupRef == nil || nodeGroup.MachineGroupRef == nil {
+			continue
+		}
+
+		oldTmc := currentSpec.TinkerbellMachineConfigs[previous.MachineGroupRef.Name]
+		newTmc := p.machineConfigs[nodeGroup.MachineGroupRef.Name]
+		if oldTmc == nil || newTmc == nil {
+			continue
+		}
+
+		if needsNewWorkloadTemplate(currentSpec, newClusterSpec, currentSpec.TinkerbellDatacenter, p.datacenterConfig, oldTmc, newTmc) ||
+			needsNewKubeadmConfigTemplate(&nodeGroup, &previous) {
+			nodeGroups = append(nodeGroups, nodeGroup.Name)
+		}
+	}
+
+	return nodeGroups
+}
+
 func (p *Provider) PostBootstrapDeleteForUpgrade(ctx context.Context) error {
 	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
 		return err
@@ -191,7 +270,7 @@ func (p *Provider) PostMoveManagementToBootstrap(ctx context.Context, bootstrapC
 	// or no hardware with bmc, its sufficient to check the first hardware.
 	if p.catalogue.TotalHardware() > 0 && p.catalogue.AllHardware()[0].Spec.BMCRef != nil {
 		// Waiting to ensure all the new and exisiting baseboardmanagement connections are valid.
-		err := p.providerKubectlClient.WaitForRufioMachines(ctx, bootstrapCluster, "5m", "Contactable", constants.EksaSystemNamespace)
+		err := p.providerKubectlClient.WaitForRufioMachines(ctx, bootstrapCluster, p.rufioContactableTimeout.String(), "Contactable", constants.EksaSystemNamespace)
 		if err != nil {
 			return fmt.Errorf("waiting for baseboard management to be contactable: %v", err)
 		}
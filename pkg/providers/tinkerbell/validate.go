@@ -29,8 +29,49 @@ func validateOsFamily(spec *ClusterSpec) error {
 		}
 	}
 
-	if controlPlaneOsFamily != v1alpha1.Bottlerocket && spec.DatacenterConfig.Spec.OSImageURL == "" {
-		return fmt.Errorf("please use bottlerocket as osFamily for auto-importing or provide a valid osImageURL")
+	if controlPlaneOsFamily == v1alpha1.Bottlerocket && spec.DatacenterConfig.Spec.MTU != 0 {
+		return fmt.Errorf("mtu is not supported for bottlerocket osFamily")
+	}
+
+	if controlPlaneOsFamily != v1alpha1.Bottlerocket {
+		for _, machineConfig := range usedMachineConfigs(spec) {
+			if osImageURL(spec.DatacenterConfig.Spec, machineConfig.Spec) == "" {
+				return fmt.Errorf("please use bottlerocket as osFamily for auto-importing or provide a valid osImageURL")
+			}
+		}
+	}
+	return nil
+}
+
+// usedMachineConfigs returns the machine configs referenced by the control plane, external etcd
+// and worker node groups of spec.
+func usedMachineConfigs(spec *ClusterSpec) []*v1alpha1.TinkerbellMachineConfig {
+	machineConfigs := []*v1alpha1.TinkerbellMachineConfig{spec.ControlPlaneMachineConfig()}
+
+	if spec.HasExternalEtcd() {
+		machineConfigs = append(machineConfigs, spec.ExternalEtcdMachineConfig())
+	}
+
+	for _, group := range spec.WorkerNodeGroupConfigurations() {
+		machineConfigs = append(machineConfigs, spec.WorkerNodeGroupMachineConfig(group))
+	}
+
+	return machineConfigs
+}
+
+// validateSSHAuthorizedKeys ensures every machine config referenced by spec has a first user with
+// at least one ssh authorized key, as the CAPI templates require one.
+func validateSSHAuthorizedKeys(spec *ClusterSpec) error {
+	for _, machineConfig := range usedMachineConfigs(spec) {
+		if machineConfig == nil {
+			continue
+		}
+		if len(machineConfig.Spec.Users) == 0 {
+			return fmt.Errorf("TinkerbellMachineConfig %s: missing spec.users", machineConfig.Name)
+		}
+		if len(machineConfig.Spec.Users[0].SshAuthorizedKeys) == 0 {
+			return fmt.Errorf("TinkerbellMachineConfig %s: spec.users[0].sshAuthorizedKeys must contain at least one key", machineConfig.Name)
+		}
 	}
 	return nil
 }
@@ -103,13 +144,19 @@ type minimumHardwareRequirement struct {
 // specifying the same key-value pairs are combined.
 type minimumHardwareRequirements map[string]*minimumHardwareRequirement
 
-// Add a minimumHardwareRequirement to r.
+// Add a minimumHardwareRequirement to r. Adding a selector already in r increases its minimum
+// count by min so groups sharing a selector require enough hardware for all of them.
 func (r *minimumHardwareRequirements) Add(selector v1alpha1.HardwareSelector, min int) error {
 	name, err := selector.ToString()
 	if err != nil {
 		return err
 	}
 
+	if existing, ok := (*r)[name]; ok {
+		existing.MinCount += min
+		return nil
+	}
+
 	(*r)[name] = &minimumHardwareRequirement{
 		MinCount: min,
 		Selector: selector,
@@ -121,32 +168,35 @@ func (r *minimumHardwareRequirements) Add(selector v1alpha1.HardwareSelector, mi
 // validateminimumHardwareRequirements validates all requirements can be satisfied using hardware
 // registered with catalogue.
 func validateMinimumHardwareRequirements(requirements minimumHardwareRequirements, catalogue *hardware.Catalogue) error {
-	// Count all hardware that meets the selector requirements for each requirement.
-	// This does not consider whether or not a piece of hardware is selectable by multiple
-	// selectors. That requires a different validation ideally run before this one.
-	for _, h := range catalogue.AllHardware() {
-		for _, r := range requirements {
-			if hardware.LabelsMatchSelector(r.Selector, h.Labels) {
-				r.count++
-			}
-		}
-	}
+	countAvailableHardware(requirements, catalogue)
 
 	// Validate counts of hardware meet the minimum required count.
 	for name, r := range requirements {
 		if r.count < r.MinCount {
-			return fmt.Errorf(
-				"minimum hardware count not met for selector '%v': have %v, require %v",
-				name,
-				r.count,
-				r.MinCount,
-			)
+			return minimumHardwareCountErr{
+				Selector: name,
+				Have:     r.count,
+				Require:  r.MinCount,
+			}
 		}
 	}
 
 	return nil
 }
 
+// countAvailableHardware counts all hardware in catalogue that meets the selector of each requirement.
+// This does not consider whether or not a piece of hardware is selectable by multiple
+// selectors. That requires a different validation ideally run before this one.
+func countAvailableHardware(requirements minimumHardwareRequirements, catalogue *hardware.Catalogue) {
+	for _, h := range catalogue.AllHardware() {
+		for _, r := range requirements {
+			if hardware.LabelsMatchSelector(r.Selector, h.Labels) {
+				r.count++
+			}
+		}
+	}
+}
+
 // validateHardwareSatifiesOnlyOneSelector ensures hardware in allHardware meets one and only one
 // selector in selectors. selectors uses the selectorSet construct to ensure we don't
 // operate on duplicate selectors given a selector can be re-used among groups as they may reference
@@ -170,6 +220,47 @@ func validateHardwareSatisfiesOnlyOneSelector(allHardware []*tinkv1alpha1.Hardwa
 	return nil
 }
 
+// validateSelectedHardwareNotProvisioned ensures none of the catalogued hardware matching a selector is
+// already provisioned by another cluster. Hardware is considered the same when name and namespace match.
+func validateSelectedHardwareNotProvisioned(
+	catalogued []*tinkv1alpha1.Hardware,
+	provisioned []tinkv1alpha1.Hardware,
+	selectors selectorSet,
+) error {
+	owners := make(map[string]string, len(provisioned))
+	for _, hw := range provisioned {
+		owners[hw.Name+":"+hw.Namespace] = hw.Labels[hardware.OwnerNameLabel]
+	}
+
+	for _, h := range catalogued {
+		owner, ok := owners[h.Name+":"+h.Namespace]
+		if !ok {
+			continue
+		}
+
+		for _, selector := range getMatchingHardwareSelectors(h, selectors) {
+			// Empty selectors are rejected by other validations; don't report them here.
+			if len(selector) == 0 {
+				continue
+			}
+
+			slctrStr, err := selector.ToString()
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf(
+				"hardware is already provisioned: hardware name '%v'; selector '%v'; owner '%v'",
+				h.Name,
+				slctrStr,
+				owner,
+			)
+		}
+	}
+
+	return nil
+}
+
 // selectorSet defines a set of selectors. Selectors should be added using the Add method to ensure
 // deterministic key generation. The construct is useful to avoid treating selectors that are the
 // same as different.
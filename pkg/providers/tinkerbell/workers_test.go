@@ -142,6 +142,18 @@ func TestWorkersSpecMachineTemplateNotFound(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 }
 
+func TestWorkersSpecMachineConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_tinkerbell_multiple_node_groups.yaml")
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[1].MachineGroupRef.Name = "missing"
+	client := test.NewFakeKubeClient()
+
+	_, err := tinkerbell.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellMachineConfig missing referenced by worker node group md-1 not found")))
+}
+
 func TestWorkersSpecErrorFromClient(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
@@ -61,13 +61,7 @@ func (b *ControlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) erro
 func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*ControlPlane, error) {
 	templateBuilder := NewVsphereTemplateBuilder(time.Now)
 
-	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
-		spec,
-		func(values map[string]interface{}) {
-			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
-			values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
-		},
-	)
+	controlPlaneYaml, err := templateBuilder.CAPIControlPlaneSpecWithInitialNames(spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating vsphere control plane yaml spec")
 	}
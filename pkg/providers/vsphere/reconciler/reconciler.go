@@ -226,8 +226,13 @@ func (r *Reconciler) InstallStorageClass(ctx context.Context, log logr.Logger, c
 	if err != nil {
 		return controller.Result{}, err
 	}
+	storageClass, err := vsphere.GetStorageClass(!clusterSpec.VSphereDatacenter.Spec.DisableDefaultStorageClass)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
 	log.Info("Applying Storage Class")
-	if err := serverside.ReconcileYaml(ctx, remoteClient, vsphere.GetDefaultStorageClass()); err != nil {
+	if err := serverside.ReconcileYaml(ctx, remoteClient, storageClass); err != nil {
 		return controller.Result{}, err
 	}
 
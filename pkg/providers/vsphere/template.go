@@ -52,6 +52,10 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(
 		buildOption(values)
 	}
 
+	if err = common.ValidateDigestPinnedImages(values); err != nil {
+		return nil, err
+	}
+
 	bytes, err := templater.Execute(defaultCAPIConfigCP, values)
 	if err != nil {
 		return nil, err
@@ -61,6 +65,10 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(
 }
 
 func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(clusterSpec *cluster.Spec) (bool, error) {
+	if clusterSpec.VSphereDatacenter.Spec.CgroupDriver == anywherev1.CgroupDriverSystemd {
+		return true, nil
+	}
+
 	bundle := clusterSpec.VersionsBundle
 	k8sVersion, err := semver.New(bundle.KubeDistro.Kubernetes.Tag)
 	if err != nil {
@@ -72,6 +80,16 @@ func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(clusterSpec *cluster.Spe
 	return false, nil
 }
 
+// CAPIControlPlaneSpecWithInitialNames generates a yaml spec with the CAPI objects representing the control plane
+// and etcd nodes for a particular eks-a cluster. It uses default initial names (ended in '-1') for the vsphere
+// machine templates.
+func (vs *VsphereTemplateBuilder) CAPIControlPlaneSpecWithInitialNames(spec *cluster.Spec) (content []byte, err error) {
+	return vs.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+		values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+	})
+}
+
 // CAPIWorkersSpecWithInitialNames generates a yaml spec with the CAPI objects representing the worker
 // nodes for a particular eks-a cluster. It uses default initial names (ended in '-1') for the vsphere
 // machine templates and kubeadm config templates.
@@ -84,6 +102,7 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(
 	clusterSpec *cluster.Spec,
 	workloadTemplateNames,
 	kubeadmconfigTemplateNames map[string]string,
+	buildOptions ...providers.BuildMapOption,
 ) (content []byte, err error) {
 	// pin cgroupDriver to systemd for k8s >= 1.21 when generating template in controller
 	// remove this check once the controller supports order upgrade.
@@ -110,6 +129,14 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(
 
 		values["cgroupDriverSystemd"] = cgroupDriverSystemd
 
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
+
+		if err = common.ValidateDigestPinnedImages(values); err != nil {
+			return nil, err
+		}
+
 		bytes, err := templater.Execute(defaultClusterConfigMD, values)
 		if err != nil {
 			return nil, err
@@ -135,7 +162,8 @@ func buildTemplateMapCP(
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
-		Append(sharedExtraArgs)
+		Append(sharedExtraArgs).
+		AppendIfNotPresent(clusterapi.ClusterAPIServerExtraArgs(clusterSpec.Cluster))
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
 
@@ -187,6 +215,7 @@ func buildTemplateMapCP(
 		"etcdExtraArgs":                        etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
+		"auditLogRotationArgs":                 clusterapi.AuditLogRotationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.AuditLogRotation).ToPartialYaml(),
 		"controllerManagerExtraArgs":           controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
@@ -281,7 +310,8 @@ func buildTemplateMapCP(
 
 	if controlPlaneMachineSpec.OSFamily == anywherev1.Bottlerocket {
 		values["format"] = string(anywherev1.Bottlerocket)
-		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
+		// Point the pause image at the mirror directly so air-gapped nodes never pull it from the public registry.
+		values["pauseRepository"] = registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(bundle.KubeDistro.Pause.Image())
 		values["pauseVersion"] = bundle.KubeDistro.Pause.Tag()
 		values["bottlerocketBootstrapRepository"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
@@ -300,6 +330,10 @@ func buildTemplateMapCP(
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
 		}
 
+		if len(controlPlaneMachineSpec.HostOSConfiguration.RunCmd) > 0 {
+			values["cpRunCmd"] = controlPlaneMachineSpec.HostOSConfiguration.RunCmd
+		}
+
 		brSettings, err := common.GetCAPIBottlerocketSettingsConfig(controlPlaneMachineSpec.HostOSConfiguration.BottlerocketConfiguration)
 		if err != nil {
 			return nil, err
@@ -400,7 +434,7 @@ func buildTemplateMapMD(
 
 	if workerNodeGroupMachineSpec.OSFamily == anywherev1.Bottlerocket {
 		values["format"] = string(anywherev1.Bottlerocket)
-		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
+		values["pauseRepository"] = registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(bundle.KubeDistro.Pause.Image())
 		values["pauseVersion"] = bundle.KubeDistro.Pause.Tag()
 		values["bottlerocketBootstrapRepository"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
@@ -411,6 +445,10 @@ func buildTemplateMapMD(
 			values["ntpServers"] = workerNodeGroupMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
 		}
 
+		if len(workerNodeGroupMachineSpec.HostOSConfiguration.RunCmd) > 0 {
+			values["runCmd"] = workerNodeGroupMachineSpec.HostOSConfiguration.RunCmd
+		}
+
 		brSettings, err := common.GetCAPIBottlerocketSettingsConfig(workerNodeGroupMachineSpec.HostOSConfiguration.BottlerocketConfiguration)
 		if err != nil {
 			return nil, err
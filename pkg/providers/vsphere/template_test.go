@@ -10,8 +10,10 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneNoKubeVersion(t *testing.T) {
@@ -125,3 +127,157 @@ func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneInvalidEtcdSSHKey(t *
 func invalidSSHKey() string {
 	return "ssh-rsa AAAA    B3NzaC1K73CeQ== testemail@test.com"
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersCgroupDriver(t *testing.T) {
+	tests := []struct {
+		name         string
+		k8sTag       string
+		cgroupDriver v1alpha1.CgroupDriver
+		wantSystemd  bool
+	}{
+		{
+			name:        "auto 1.21",
+			k8sTag:      "v1.21.2-eks-1-21-4",
+			wantSystemd: true,
+		},
+		{
+			name:        "auto 1.22",
+			k8sTag:      "v1.22.1-eks-1-22-1",
+			wantSystemd: false,
+		},
+		{
+			name:         "forced systemd 1.24",
+			k8sTag:       "v1.24.1-eks-1-24-1",
+			cgroupDriver: v1alpha1.CgroupDriverSystemd,
+			wantSystemd:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+			spec.VersionsBundle.KubeDistro.Kubernetes.Tag = tt.k8sTag
+			spec.VSphereDatacenter.Spec.CgroupDriver = tt.cgroupDriver
+			builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+			md, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.wantSystemd {
+				g.Expect(string(md)).To(ContainSubstring("cgroup-driver: systemd"))
+			} else {
+				g.Expect(string(md)).NotTo(ContainSubstring("cgroup-driver: systemd"))
+			}
+		})
+	}
+}
+
+func TestVsphereTemplateBuilderCAPIControlPlaneSpecWithInitialNames(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.CAPIControlPlaneSpecWithInitialNames(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("name: test-control-plane-1\n"))
+	g.Expect(string(cp)).To(ContainSubstring("name: test-etcd-1\n"))
+
+	cpAgain, err := builder.CAPIControlPlaneSpecWithInitialNames(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cpAgain).To(Equal(cp))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneRequireDigestPinnedImagesTagOnly(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	_, err := builder.GenerateCAPISpecControlPlane(spec, common.RequireDigestPinnedImages)
+	g.Expect(err).To(MatchError(ContainSubstring("images must be pinned by digest: ")))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneRequireDigestPinnedImagesPinned(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	bundle := spec.VersionsBundle
+	for _, image := range []*releasev1alpha1.Image{
+		&bundle.KubeDistro.NodeDriverRegistrar,
+		&bundle.KubeDistro.LivenessProbe,
+		&bundle.KubeDistro.ExternalAttacher,
+		&bundle.KubeDistro.ExternalProvisioner,
+		&bundle.KubeDistro.EtcdImage,
+		&bundle.VSphere.Manager,
+		&bundle.VSphere.KubeVip,
+		&bundle.VSphere.Driver,
+		&bundle.VSphere.Syncer,
+	} {
+		image.URI = image.Image() + "@sha256:6ac32ff6c7a33f1d2d6a87f7b5e05bf0a0b3c0d9a1b9cb4e5d1a4bd1f7e8f9a0"
+	}
+	for _, repository := range []*cluster.VersionedRepository{
+		&bundle.KubeDistro.Kubernetes,
+		&bundle.KubeDistro.Etcd,
+		&bundle.KubeDistro.CoreDNS,
+	} {
+		repository.Tag += "@sha256:6ac32ff6c7a33f1d2d6a87f7b5e05bf0a0b3c0d9a1b9cb4e5d1a4bd1f7e8f9a0"
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	_, err := builder.GenerateCAPISpecControlPlane(spec, common.RequireDigestPinnedImages)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersRequireDigestPinnedImages(t *testing.T) {
+	tests := []struct {
+		name     string
+		pauseURI string
+		wantErr  string
+	}{
+		{
+			name:     "tag only pause image",
+			pauseURI: "public.ecr.aws/eks-distro/kubernetes/pause:v1.19.8-eks-1-19-4",
+			wantErr:  "images must be pinned by digest: public.ecr.aws/eks-distro/kubernetes/pause:v1.19.8-eks-1-19-4",
+		},
+		{
+			name:     "digest pinned pause image",
+			pauseURI: "public.ecr.aws/eks-distro/kubernetes/pause@sha256:6ac32ff6c7a33f1d2d6a87f7b5e05bf0a0b3c0d9a1b9cb4e5d1a4bd1f7e8f9a0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+			bundle := spec.VersionsBundle
+			bundle.KubeDistro.Pause.URI = tt.pauseURI
+			bundle.BottleRocketHostContainers.KubeadmBootstrap.URI = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image() + "@sha256:6ac32ff6c7a33f1d2d6a87f7b5e05bf0a0b3c0d9a1b9cb4e5d1a4bd1f7e8f9a0"
+			workloadTemplateNames := map[string]string{}
+			kubeadmconfigTemplateNames := map[string]string{}
+			for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+				spec.VSphereMachineConfigs[group.MachineGroupRef.Name].Spec.OSFamily = v1alpha1.Bottlerocket
+				workloadTemplateNames[group.Name] = group.Name + "-template"
+				kubeadmconfigTemplateNames[group.Name] = group.Name + "-kubeadm-template"
+			}
+			builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+			_, err := builder.GenerateCAPISpecWorkers(spec, workloadTemplateNames, kubeadmconfigTemplateNames, common.RequireDigestPinnedImages)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneDualStack(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:100:64::/48"}
+	spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:100:96::/108"}
+	spec.Cluster.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
+		HttpProxy:  "http://1.1.1.1:8080",
+		HttpsProxy: "http://1.1.1.1:8080",
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(test.FakeNow)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_main_dual_stack_cp.yaml")
+}
@@ -13,11 +13,14 @@ import (
 	"github.com/Masterminds/sprig"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -47,6 +50,11 @@ const (
 	backOffPeriod            = 5 * time.Second
 	disk1                    = "Hard disk 1"
 	disk2                    = "Hard disk 2"
+
+	storageClassResourceType            = "storageclasses.storage.k8s.io"
+	defaultStorageClassName             = "standard"
+	isDefaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaIsDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
 )
 
 //go:embed config/template-cp.yaml
@@ -80,6 +88,9 @@ type vsphereProvider struct {
 	validator             *Validator
 	defaulter             *Defaulter
 	ipValidator           IPValidator
+
+	// markDefaultStorageClass marks the StorageClass installed with the CSI driver as the cluster default.
+	markDefaultStorageClass bool
 }
 
 type ProviderGovcClient interface {
@@ -137,6 +148,7 @@ type ProviderKubectlClient interface {
 	DeleteEksaDatacenterConfig(ctx context.Context, vsphereDatacenterResourceType string, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, vsphereMachineResourceType string, vsphereMachineConfigName string, kubeconfigFile string, namespace string) error
 	ApplyTolerationsFromTaintsToDaemonSet(ctx context.Context, oldTaints []corev1.Taint, newTaints []corev1.Taint, dsName string, kubeconfigFile string) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
 }
 
 // IPValidator is an interface that defines methods to validate the control plane IP.
@@ -178,12 +190,13 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, cl
 		templateBuilder: NewVsphereTemplateBuilder(
 			now,
 		),
-		skipIPCheck: skipIpCheck,
-		csiEnabled:  !datacenterConfig.Spec.DisableCSI,
-		Retrier:     retrier,
-		validator:   v,
-		defaulter:   NewDefaulter(providerGovcClient),
-		ipValidator: ipValidator,
+		skipIPCheck:             skipIpCheck,
+		csiEnabled:              !datacenterConfig.Spec.DisableCSI,
+		markDefaultStorageClass: !datacenterConfig.Spec.DisableDefaultStorageClass,
+		Retrier:                 retrier,
+		validator:               v,
+		defaulter:               NewDefaulter(providerGovcClient),
+		ipValidator:             ipValidator,
 	}
 }
 
@@ -708,7 +721,40 @@ func (p *vsphereProvider) InstallStorageClass(ctx context.Context, cluster *type
 		return nil
 	}
 
-	return p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, defaultStorageClass)
+	if p.markDefaultStorageClass {
+		if err := p.validateNoOtherDefaultStorageClass(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	storageClass, err := GetStorageClass(p.markDefaultStorageClass)
+	if err != nil {
+		return err
+	}
+
+	return p.Retrier.Retry(func() error {
+		return p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, storageClass)
+	})
+}
+
+// validateNoOtherDefaultStorageClass checks the cluster doesn't already have a default StorageClass other than
+// the one installed by EKS Anywhere, so marking it as default won't leave the cluster with two of them.
+func (p *vsphereProvider) validateNoOtherDefaultStorageClass(ctx context.Context, cluster *types.Cluster) error {
+	storageClasses := &storagev1.StorageClassList{}
+	if err := p.providerKubectlClient.ListObjects(ctx, storageClassResourceType, "", cluster.KubeconfigFile, storageClasses); err != nil {
+		return fmt.Errorf("listing storage classes: %v", err)
+	}
+
+	for _, sc := range storageClasses.Items {
+		if sc.Name == defaultStorageClassName {
+			continue
+		}
+		if sc.Annotations[isDefaultStorageClassAnnotation] == "true" || sc.Annotations[betaIsDefaultStorageClassAnnotation] == "true" {
+			return fmt.Errorf("cluster already has default StorageClass %s, set disableDefaultStorageClass in the VSphereDatacenterConfig to not mark %s as default", sc.Name, defaultStorageClassName)
+		}
+	}
+
+	return nil
 }
 
 func (p *vsphereProvider) createSecret(ctx context.Context, cluster *types.Cluster, contents *bytes.Buffer) error {
@@ -1038,6 +1084,22 @@ func GetDefaultStorageClass() []byte {
 	return exportedStorageClass
 }
 
+// GetStorageClass returns the StorageClass installed with the vSphere CSI driver, marked as the
+// cluster default StorageClass when markDefault is true.
+func GetStorageClass(markDefault bool) ([]byte, error) {
+	if markDefault {
+		return GetDefaultStorageClass(), nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := yaml.Unmarshal(defaultStorageClass, storageClass); err != nil {
+		return nil, fmt.Errorf("parsing default storage class: %v", err)
+	}
+	delete(storageClass.Annotations, isDefaultStorageClassAnnotation)
+
+	return yaml.Marshal(storageClass)
+}
+
 // PreCoreComponentsUpgrade staisfies the Provider interface.
 func (p *vsphereProvider) PreCoreComponentsUpgrade(
 	ctx context.Context,
@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -35,6 +36,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	govmomi_mocks "github.com/aws/eks-anywhere/pkg/govmomi/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -1256,6 +1258,7 @@ func TestProviderInstallStorageClass(t *testing.T) {
 	ipValidator := mocks.NewMockIPValidator(ctrl)
 
 	var content []byte
+	kubectl.EXPECT().ListObjects(gomock.Any(), "storageclasses.storage.k8s.io", "", "", &storagev1.StorageClassList{}).Return(nil)
 	kubectl.EXPECT().
 		ApplyKubeSpecFromBytes(gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, _ *types.Cluster, ctnt []byte) error {
@@ -1279,6 +1282,124 @@ func TestProviderInstallStorageClass(t *testing.T) {
 	test.AssertContentToFile(t, string(content), "testdata/TestProviderInstallStorageClass_expect.yaml")
 }
 
+func TestProviderInstallStorageClassDisableDefaultStorageClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(ctrl)
+	ipValidator := mocks.NewMockIPValidator(ctrl)
+
+	datacenterConfig.Spec.DisableDefaultStorageClass = true
+
+	var content []byte
+	kubectl.EXPECT().
+		ApplyKubeSpecFromBytes(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, ctnt []byte) error {
+			content = ctnt
+			return nil
+		})
+
+	provider := newProviderWithKubectl(
+		t,
+		datacenterConfig,
+		clusterConfig,
+		kubectl,
+		ipValidator,
+	)
+
+	err := provider.InstallStorageClass(context.Background(), &types.Cluster{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(content), "storageclass.kubernetes.io/is-default-class") {
+		t.Fatalf("storage class should not be marked as default:\n%s", content)
+	}
+	test.AssertContentToFile(t, string(content), "testdata/TestProviderInstallStorageClassDisableDefaultStorageClass_expect.yaml")
+}
+
+func TestProviderInstallStorageClassExistingDefaultStorageClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(ctrl)
+	ipValidator := mocks.NewMockIPValidator(ctrl)
+
+	kubectl.EXPECT().
+		ListObjects(gomock.Any(), "storageclasses.storage.k8s.io", "", "", &storagev1.StorageClassList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list *storagev1.StorageClassList) error {
+			list.Items = []storagev1.StorageClass{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "standard", Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "custom", Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}},
+				},
+			}
+			return nil
+		})
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	provider := newProviderWithKubectl(
+		t,
+		datacenterConfig,
+		clusterConfig,
+		kubectl,
+		ipValidator,
+	)
+
+	err := provider.InstallStorageClass(context.Background(), &types.Cluster{})
+	thenErrorExpected(t, "cluster already has default StorageClass custom, set disableDefaultStorageClass in the VSphereDatacenterConfig to not mark standard as default", err)
+}
+
+func TestProviderInstallStorageClassListStorageClassesError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(ctrl)
+	ipValidator := mocks.NewMockIPValidator(ctrl)
+
+	kubectl.EXPECT().ListObjects(gomock.Any(), "storageclasses.storage.k8s.io", "", "", &storagev1.StorageClassList{}).Return(errors.New("list error"))
+
+	provider := newProviderWithKubectl(
+		t,
+		datacenterConfig,
+		clusterConfig,
+		kubectl,
+		ipValidator,
+	)
+
+	err := provider.InstallStorageClass(context.Background(), &types.Cluster{})
+	thenErrorExpected(t, "listing storage classes: list error", err)
+}
+
+func TestProviderInstallStorageClassRetriesApply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(ctrl)
+	ipValidator := mocks.NewMockIPValidator(ctrl)
+
+	kubectl.EXPECT().ListObjects(gomock.Any(), "storageclasses.storage.k8s.io", "", "", &storagev1.StorageClassList{}).Return(nil)
+	gomock.InOrder(
+		kubectl.EXPECT().ApplyKubeSpecFromBytes(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused")),
+		kubectl.EXPECT().ApplyKubeSpecFromBytes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	provider := newProviderWithKubectl(
+		t,
+		datacenterConfig,
+		clusterConfig,
+		kubectl,
+		ipValidator,
+	)
+	provider.Retrier = retrier.NewWithMaxRetries(2, 0)
+
+	if err := provider.InstallStorageClass(context.Background(), &types.Cluster{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestProviderInstallStorageClassDisableCSI(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
@@ -3431,6 +3552,112 @@ func TestProviderGenerateDeploymentFileForUbuntuWithNTPConfig(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_ntp_config_md.yaml")
 }
 
+func TestProviderGenerateDeploymentFileForUbuntuWithRunCmd(t *testing.T) {
+	clusterSpecManifest := "cluster_ubuntu_runcmd_config.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	ctx := context.Background()
+	govc := NewDummyProviderGovcClient()
+	vscb, _ := newMockVSphereClientBuilder(mockCtrl)
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+	v := NewValidator(govc, vscb)
+	provider := newProvider(
+		t,
+		datacenterConfig,
+		clusterSpec.Cluster,
+		govc,
+		kubectl,
+		v,
+		ipValidator,
+	)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ubuntu_runcmd_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_ubuntu_runcmd_config_md.yaml")
+}
+
+func TestProviderGenerateDeploymentFileWithAuditLogRotation(t *testing.T) {
+	clusterSpecManifest := "cluster_audit_log_rotation_config.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	ctx := context.Background()
+	govc := NewDummyProviderGovcClient()
+	vscb, _ := newMockVSphereClientBuilder(mockCtrl)
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+	v := NewValidator(govc, vscb)
+	provider := newProvider(
+		t,
+		datacenterConfig,
+		clusterSpec.Cluster,
+		govc,
+		kubectl,
+		v,
+		ipValidator,
+	)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_audit_log_rotation_config_cp.yaml")
+}
+
+func TestProviderGenerateDeploymentFileWithAPIServerExtraArgs(t *testing.T) {
+	clusterSpecManifest := "cluster_main_apiserver_extra_args.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	ctx := context.Background()
+	govc := NewDummyProviderGovcClient()
+	vscb, _ := newMockVSphereClientBuilder(mockCtrl)
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+	v := NewValidator(govc, vscb)
+	provider := newProvider(
+		t,
+		datacenterConfig,
+		clusterSpec.Cluster,
+		govc,
+		kubectl,
+		v,
+		ipValidator,
+	)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_main_apiserver_extra_args_cp.yaml")
+}
+
 func TestProviderGenerateDeploymentFileForBottlerocketWithBottlerocketSettingsConfig(t *testing.T) {
 	clusterSpecManifest := "cluster_bottlerocket_settings_config.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -1,6 +1,7 @@
 package retrier
 
 import (
+	"context"
 	"math"
 	"time"
 
@@ -68,6 +69,12 @@ func WithRetryPolicy(policy RetryPolicy) RetrierOpt {
 // Retry runs the fn function until it either successful completes (not error),
 // the set timeout reached or the retry policy aborts the execution.
 func (r *Retrier) Retry(fn func() error) error {
+	return r.RetryWithContext(context.Background(), fn)
+}
+
+// RetryWithContext runs the fn function like Retry but stops retrying as soon as ctx is done,
+// including while waiting between retries, returning ctx.Err().
+func (r *Retrier) RetryWithContext(ctx context.Context, fn func() error) error {
 	// While it seems aberrant to call a method with a nil receiver, several unit tests actually do.  With a previous
 	// version of this module (which didn't attempt to dereference the receiver until after the wrapped function failed)
 	// these passed.  Changes below, to log the receiver struct's key params changed that breaking the unit tests.
@@ -81,6 +88,11 @@ func (r *Retrier) Retry(fn func() error) error {
 	var err error
 	logger.V(5).Info("Retrier:", "timeout", r.timeout, "backoffFactor", r.backoffFactor)
 	for retry := true; retry; retry = time.Since(start) < r.timeout {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			logger.V(5).Info("Context done. Aborting retries", "retries", retries, "error", ctxErr)
+			return ctxErr
+		}
+
 		err = fn()
 		retries += 1
 		if err == nil {
@@ -107,7 +119,10 @@ func (r *Retrier) Retry(fn func() error) error {
 		}
 
 		logger.V(5).Info("Sleeping before next retry", "time", wait)
-		time.Sleep(wait)
+		if err := sleep(ctx, wait); err != nil {
+			logger.V(5).Info("Context done while waiting. Aborting retries", "retries", retries, "error", err)
+			return err
+		}
 	}
 
 	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", time.Since(start), "error", err)
@@ -121,6 +136,23 @@ func Retry(maxRetries int, backOffPeriod time.Duration, fn func() error) error {
 	return r.Retry(fn)
 }
 
+// sleep waits for d or until ctx is done, in which case it returns ctx.Err().
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 func zeroWaitPolicy(_ int, _ error) (retry bool, wait time.Duration) {
 	return true, 0
 }
@@ -1,6 +1,7 @@
 package retrier_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -197,3 +198,50 @@ func TestRetrierWithNilReceiver(t *testing.T) {
 		t.Errorf("Retrier didn't correctly handle nil receiver")
 	}
 }
+
+func TestRetryWithContextCancelledWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := retrier.NewWithMaxRetries(100, time.Minute)
+	gotRetries := 0
+	fn := func() error {
+		gotRetries += 1
+		return errors.New("")
+	}
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	err := r.RetryWithContext(ctx, fn)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Retrier.RetryWithContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Retrier.RetryWithContext() took %s after the context was cancelled", elapsed)
+	}
+
+	if gotRetries != 1 {
+		t.Fatalf("Wrong number of retries, got %d, want %d", gotRetries, 1)
+	}
+}
+
+func TestRetryWithContextAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := retrier.NewWithMaxRetries(10, 0)
+	gotRetries := 0
+	fn := func() error {
+		gotRetries += 1
+		return nil
+	}
+
+	if err := r.RetryWithContext(ctx, fn); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Retrier.RetryWithContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	if gotRetries != 0 {
+		t.Fatalf("Wrong number of retries, got %d, want %d", gotRetries, 0)
+	}
+}
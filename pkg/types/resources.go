@@ -82,9 +82,15 @@ func WithNodeRef() NodeReadyChecker {
 }
 
 func WithNodeHealthy() NodeReadyChecker {
+	return WithConditionTrue("NodeHealthy")
+}
+
+// WithConditionTrue returns a NodeReadyChecker that passes when the machine has a conditionType
+// condition with status "True".
+func WithConditionTrue(conditionType string) NodeReadyChecker {
 	return func(status MachineStatus) bool {
 		for _, c := range status.Conditions {
-			if c.Type == "NodeHealthy" {
+			if string(c.Type) == conditionType {
 				return c.Status == "True"
 			}
 		}